@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -21,6 +22,22 @@ type Configuration struct {
 
 	JWTRefreshExpireMinute string `mapstructure:"JWT_REFRESH_EXP_MINUTE"`
 	JWTRefreshSecret       string `mapstructure:"JWT_REFRESH_SECRET"`
+
+	WebBaseUrl string `mapstructure:"WEB_BASE_URL"`
+
+	GuestMeterSecret string `mapstructure:"GUEST_METER_SECRET"`
+	ShareLinkSecret  string `mapstructure:"SHARE_LINK_SECRET"`
+	PriceQuoteSecret string `mapstructure:"PRICE_QUOTE_SECRET"`
+
+	PlanChangeQuotaCarryOver string `mapstructure:"PLAN_CHANGE_QUOTA_CARRY_OVER"`
+
+	EmailVerificationSecret string `mapstructure:"EMAIL_VERIFICATION_SECRET"`
+
+	SmtpHost     string `mapstructure:"SMTP_HOST"`
+	SmtpPort     string `mapstructure:"SMTP_PORT"`
+	SmtpUsername string `mapstructure:"SMTP_USERNAME"`
+	SmtpPassword string `mapstructure:"SMTP_PASSWORD"`
+	SmtpSender   string `mapstructure:"SMTP_SENDER"`
 }
 
 func initConfig() {
@@ -42,6 +59,22 @@ func initConfig() {
 
 		viper.BindEnv("JWT_REFRESH_EXP_MINUTE")
 		viper.BindEnv("JWT_REFRESH_SECRET")
+
+		viper.BindEnv("WEB_BASE_URL")
+
+		viper.BindEnv("GUEST_METER_SECRET")
+		viper.BindEnv("SHARE_LINK_SECRET")
+		viper.BindEnv("PRICE_QUOTE_SECRET")
+
+		viper.BindEnv("PLAN_CHANGE_QUOTA_CARRY_OVER")
+
+		viper.BindEnv("EMAIL_VERIFICATION_SECRET")
+
+		viper.BindEnv("SMTP_HOST")
+		viper.BindEnv("SMTP_PORT")
+		viper.BindEnv("SMTP_USERNAME")
+		viper.BindEnv("SMTP_PASSWORD")
+		viper.BindEnv("SMTP_SENDER")
 	} else {
 		viper.SetConfigFile(".env")
 		viper.AutomaticEnv()
@@ -86,3 +119,100 @@ func InitConfigJwt() []string {
 		config.JWTRefreshSecret,
 	}
 }
+
+func InitConfigWeb() string {
+	initConfig()
+
+	var config Configuration
+
+	err := viper.Unmarshal(&config)
+	if err != nil {
+		fmt.Println("[Config][InitConfigWeb] Unable to decode into struct:", err)
+	}
+
+	return strings.TrimSuffix(config.WebBaseUrl, "/")
+}
+
+func InitConfigGuestMeter() string {
+	initConfig()
+
+	var config Configuration
+
+	err := viper.Unmarshal(&config)
+	if err != nil {
+		fmt.Println("[Config][InitConfigGuestMeter] Unable to decode into struct:", err)
+	}
+
+	return config.GuestMeterSecret
+}
+
+func InitConfigShareLink() string {
+	initConfig()
+
+	var config Configuration
+
+	err := viper.Unmarshal(&config)
+	if err != nil {
+		fmt.Println("[Config][InitConfigShareLink] Unable to decode into struct:", err)
+	}
+
+	return config.ShareLinkSecret
+}
+
+func InitConfigPriceQuote() string {
+	initConfig()
+
+	var config Configuration
+
+	err := viper.Unmarshal(&config)
+	if err != nil {
+		fmt.Println("[Config][InitConfigPriceQuote] Unable to decode into struct:", err)
+	}
+
+	return config.PriceQuoteSecret
+}
+
+func InitConfigPlanChange() string {
+	initConfig()
+
+	var config Configuration
+
+	err := viper.Unmarshal(&config)
+	if err != nil {
+		fmt.Println("[Config][InitConfigPlanChange] Unable to decode into struct:", err)
+	}
+
+	return config.PlanChangeQuotaCarryOver
+}
+
+func InitConfigEmailVerification() string {
+	initConfig()
+
+	var config Configuration
+
+	err := viper.Unmarshal(&config)
+	if err != nil {
+		fmt.Println("[Config][InitConfigEmailVerification] Unable to decode into struct:", err)
+	}
+
+	return config.EmailVerificationSecret
+}
+
+func InitConfigSmtp() []string {
+	initConfig()
+
+	var config Configuration
+
+	err := viper.Unmarshal(&config)
+	if err != nil {
+		fmt.Println("[Config][InitConfigSmtp] Unable to decode into struct:", err)
+	}
+
+	return []string{
+		config.SmtpHost,
+		config.SmtpPort,
+		config.SmtpUsername,
+		config.SmtpPassword,
+		config.SmtpSender,
+	}
+}
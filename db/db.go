@@ -19,7 +19,7 @@ func Connect() error {
 		return err
 	}
 
-	err = db.AutoMigrate(&models.User{}, &models.Post{}, &models.History{}, &models.UserSubscriptions{}, &models.Invoice{}, &models.UserToken{}, &models.Gift{}, &models.UserGift{}, &models.Voucher{}, &models.UserVoucher{}, &models.UserSpending{})
+	err = db.AutoMigrate(&models.User{}, &models.Post{}, &models.History{}, &models.UserSubscriptions{}, &models.Invoice{}, &models.UserToken{}, &models.Gift{}, &models.UserGift{}, &models.Voucher{}, &models.UserVoucher{}, &models.UserSpending{}, &models.Author{}, &models.AuthorSocialLink{}, &models.AuthorFollower{}, &models.Tag{}, &models.ShareLink{}, &models.ShareLinkRedemption{}, &models.TrendingScore{}, &models.PostNeighbor{}, &models.AchievementRule{}, &models.UserAchievement{}, &models.QuotaLedger{}, &models.Notification{}, &models.TrialGrant{})
 	if err != nil {
 		return err
 	}
@@ -34,11 +34,24 @@ func Connect() error {
 		return err
 	}
 
+	err = migrateAuthorNames(db)
+	if err != nil {
+		return err
+	}
+
 	seedAdmin(db)
 	seedCategories(db)
 	seedGifts(db)
 	seedPostTypes(db)
 	seedSubscriptions(db)
+	seedTrialSubscription(db)
+	seedAchievementRules(db)
+
+	// Runs after seeding so seeded plans are numbered too.
+	err = migrateSubscriptionPlans(db)
+	if err != nil {
+		return err
+	}
 
 	return nil
 
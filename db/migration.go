@@ -0,0 +1,46 @@
+package db
+
+import (
+	"final-project-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// migrateAuthorNames moves the free-text posts.author_name column into the
+// authors table and links every post to its author. It is a no-op once the
+// column has been dropped.
+func migrateAuthorNames(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.Post{}, "author_name") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO authors (name, created_at, updated_at)
+			SELECT DISTINCT TRIM(posts.author_name), NOW(), NOW()
+			FROM posts
+			WHERE TRIM(COALESCE(posts.author_name, '')) <> ''
+			ON CONFLICT (name) DO NOTHING
+		`).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec(`
+			UPDATE posts SET author_id = authors.id
+			FROM authors
+			WHERE posts.author_id IS NULL AND authors.name = TRIM(posts.author_name)
+		`).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Migrator().DropColumn(&models.Post{}, "author_name")
+	})
+}
+
+// migrateSubscriptionPlans makes every subscription without a plan the first
+// version of its own plan.
+func migrateSubscriptionPlans(db *gorm.DB) error {
+	return db.Exec("UPDATE subscriptions SET plan_id = id WHERE plan_id IS NULL OR plan_id = 0").Error
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"errors"
 
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/models"
 
 	"gorm.io/gorm"
@@ -47,3 +48,26 @@ func seedSubscriptions(db *gorm.DB) {
 		db.Create(&categories)
 	}
 }
+
+func seedTrialSubscription(db *gorm.DB) {
+	if err := db.Where("type = ?", constants.PLAN_TRIAL).First(&models.Subscription{}).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		db.Create(&models.Subscription{Name: "Trial", Price: 0, Quota: 3, Type: constants.PLAN_TRIAL})
+	}
+}
+
+func seedAchievementRules(db *gorm.DB) {
+	if err := db.First(&models.AchievementRule{}).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		var rules = []models.AchievementRule{
+			{Name: "7-Day Streak", Description: "Read something 7 days in a row", Kind: constants.ACHIEVEMENT_STREAK, Threshold: 7, IsActive: true},
+			{Name: "Bookworm", Description: "Read 50 posts", Kind: constants.ACHIEVEMENT_TOTAL_READS, Threshold: 50, IsActive: true},
+		}
+
+		var technology models.Category
+		if db.Where("name = ?", "Technology").First(&technology).Error == nil {
+			categoryID := int64(technology.ID)
+			rules = append(rules, models.AchievementRule{Name: "Tech Enthusiast", Description: "Read 10 Technology posts", Kind: constants.ACHIEVEMENT_CATEGORY_READS, CategoryID: &categoryID, Threshold: 10, IsActive: true})
+		}
+
+		db.Create(&rules)
+	}
+}
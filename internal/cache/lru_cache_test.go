@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCache(t *testing.T) {
+	now := time.Now()
+	c := NewLRUCache[string, int](2, time.Minute)
+	c.now = func() time.Time { return now }
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	got, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, got)
+
+	c.Set("c", 3)
+	_, ok = c.Get("b")
+	assert.False(t, ok, "least recently used entry is evicted")
+
+	now = now.Add(time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok, "expired entry is dropped")
+
+	assert.Equal(t, Stats{Hits: 1, Misses: 3, Evictions: 1, Size: 1, Capacity: 2}, c.Stats())
+
+	c.Clear()
+	assert.Equal(t, 0, c.Stats().Size)
+}
@@ -0,0 +1,27 @@
+package constants
+
+type AchievementKind string
+
+const (
+	// ACHIEVEMENT_STREAK is reached by the member's longest daily reading
+	// streak.
+	ACHIEVEMENT_STREAK AchievementKind = "streak"
+	// ACHIEVEMENT_CATEGORY_READS counts posts read in the rule's category.
+	ACHIEVEMENT_CATEGORY_READS AchievementKind = "category_reads"
+	ACHIEVEMENT_TOTAL_READS    AchievementKind = "total_reads"
+)
+
+func ParseAchievementKind(s string) (AchievementKind, bool) {
+	switch kind := AchievementKind(s); kind {
+	case ACHIEVEMENT_STREAK, ACHIEVEMENT_CATEGORY_READS, ACHIEVEMENT_TOTAL_READS:
+		return kind, true
+	default:
+		return "", false
+	}
+}
+
+const ACHIEVEMENT_VOUCHER_VALID_MONTHS = 1
+
+// DEFAULT_TIMEZONE is used to split reading days for members who haven't set
+// their own timezone.
+const DEFAULT_TIMEZONE = "Asia/Jakarta"
@@ -0,0 +1,15 @@
+package constants
+
+import "time"
+
+const (
+	POST_LIST_CACHE_SIZE = 1000
+	POST_LIST_CACHE_TTL  = 5 * time.Minute
+	TAXONOMY_CACHE_TTL   = time.Hour
+)
+
+const (
+	CACHE_KEY_ALL         = "all"
+	CACHE_KEY_TRENDING    = "trending:%d"
+	CACHE_KEY_RECOMMENDED = "recommended:%d"
+)
@@ -0,0 +1,10 @@
+package constants
+
+const (
+	FEED_TITLE            = "SeaNews"
+	FEED_DESCRIPTION      = "Recent news from SeaNews"
+	FEED_ITEM_LIMIT       = 50
+	FEED_CACHE_MAX_AGE    = 900
+	SITEMAP_PAGE_SIZE     = 1000
+	SITEMAP_CACHE_MAX_AGE = 3600
+)
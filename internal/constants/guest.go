@@ -0,0 +1,8 @@
+package constants
+
+const (
+	GUEST_METER_COOKIE         = "seanews_meter"
+	GUEST_METER_COOKIE_MAX_AGE = 60 * 60 * 24 * 365
+	GUEST_METER_LIMIT          = 3
+	GUEST_METER_PERIOD_FORMAT  = "2006-01"
+)
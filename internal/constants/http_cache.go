@@ -0,0 +1,17 @@
+package constants
+
+const (
+	CACHE_CONTROL_PUBLIC_POST = "public, max-age=60"
+	CACHE_CONTROL_PUBLIC_LIST = "public, max-age=30"
+	// Types and categories are only served to signed-in users, so shared
+	// caches must not keep them.
+	CACHE_CONTROL_TAXONOMY = "private, max-age=300"
+	// CACHE_CONTROL_PRIVATE lets only the user's own client keep the response
+	// and makes it revalidate with the ETag before every reuse.
+	CACHE_CONTROL_PRIVATE = "private, no-cache"
+)
+
+const (
+	VARY_AUTHORIZATION        = "Authorization"
+	VARY_AUTHORIZATION_COOKIE = "Authorization, Cookie"
+)
@@ -1,6 +1,9 @@
 package constants
 
+import "time"
+
 const (
 	INVOICE_STARTING_NUMBER = 100000
 	INVOICE_PREFIX          = "INV-"
+	PRICE_QUOTE_TTL         = 15 * time.Minute
 )
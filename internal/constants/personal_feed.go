@@ -0,0 +1,24 @@
+package constants
+
+import "time"
+
+const (
+	PERSONAL_FEED_INTEREST_WINDOW    = 90 * 24 * time.Hour
+	PERSONAL_FEED_INTEREST_HALF_LIFE = 14 * 24 * time.Hour
+	PERSONAL_FEED_MAX_INTERESTS      = 3
+	// PERSONAL_FEED_EXPLORATION_RATIO is the share of every page given to
+	// new posts outside the member's top interests.
+	PERSONAL_FEED_EXPLORATION_RATIO = 0.2
+)
+
+// Weights of each kind of engagement in a member's interest in a category.
+const (
+	PERSONAL_FEED_READ_WEIGHT  = 1.0
+	PERSONAL_FEED_LIKE_WEIGHT  = 2.0
+	PERSONAL_FEED_SHARE_WEIGHT = 3.0
+)
+
+const (
+	PERSONAL_FEED_REASON_INTEREST = "Because you read %s"
+	PERSONAL_FEED_REASON_EXPLORE  = "New in %s"
+)
@@ -0,0 +1,41 @@
+package constants
+
+import "time"
+
+type PostSort string
+
+const (
+	SORT_NEWEST       PostSort = "newest"
+	SORT_OLDEST       PostSort = "oldest"
+	SORT_MOST_LIKED   PostSort = "most_liked"
+	SORT_MOST_SHARED  PostSort = "most_shared"
+	SORT_MOST_READ    PostSort = "most_read"
+	SORT_MOST_REACTED PostSort = "most_reacted"
+	SORT_RELEVANCE    PostSort = "relevance"
+)
+
+func ParsePostSort(sort string) (PostSort, bool) {
+	switch PostSort(sort) {
+	case SORT_NEWEST, "desc":
+		return SORT_NEWEST, true
+	case SORT_OLDEST, "asc":
+		return SORT_OLDEST, true
+	case SORT_MOST_LIKED, SORT_MOST_SHARED, SORT_MOST_READ, SORT_MOST_REACTED, SORT_RELEVANCE:
+		return PostSort(sort), true
+	default:
+		return "", false
+	}
+}
+
+const POST_DATE_FORMAT = "2006-01-02"
+
+const (
+	RELATED_POSTS_LIMIT           = 5
+	RELATED_POSTS_CANDIDATE_LIMIT = 30
+	RELATED_POSTS_CACHE_TTL       = 15 * time.Minute
+	RELATED_POSTS_CACHE_SIZE      = 1000
+)
+
+const PAYWALL_TEASER_PARAGRAPHS = 2
+
+const RECONCILE_COUNTERS_INTERVAL = time.Hour
@@ -0,0 +1,19 @@
+package constants
+
+import "time"
+
+const (
+	POST_NEIGHBORS_LIMIT            = 20
+	POST_NEIGHBORS_MIN_CO_READERS   = 2
+	POST_NEIGHBORS_REFRESH_INTERVAL = 6 * time.Hour
+)
+
+const (
+	ALSO_READ_LIMIT        = 10
+	ALSO_READ_RECENT_READS = 20
+)
+
+const (
+	POST_NEIGHBORS_CACHE_SIZE = 1000
+	POST_NEIGHBORS_CACHE_TTL  = 15 * time.Minute
+)
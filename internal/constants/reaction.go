@@ -0,0 +1,32 @@
+package constants
+
+type Reaction string
+
+const (
+	REACTION_NONE       Reaction = ""
+	REACTION_INSIGHTFUL Reaction = "insightful"
+	REACTION_FUNNY      Reaction = "funny"
+	REACTION_SAD        Reaction = "sad"
+	REACTION_ANGRY      Reaction = "angry"
+)
+
+var REACTIONS = []Reaction{REACTION_INSIGHTFUL, REACTION_FUNNY, REACTION_SAD, REACTION_ANGRY}
+
+// ParseReaction accepts an empty string, which clears the member's reaction.
+func ParseReaction(reaction string) (Reaction, bool) {
+	if reaction == string(REACTION_NONE) {
+		return REACTION_NONE, true
+	}
+
+	for _, r := range REACTIONS {
+		if Reaction(reaction) == r {
+			return r, true
+		}
+	}
+
+	return "", false
+}
+
+func (r Reaction) CountColumn() string {
+	return string(r) + "_count"
+}
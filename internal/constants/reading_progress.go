@@ -0,0 +1,7 @@
+package constants
+
+// A read counts as finished once the reported progress reaches this
+// percentage, since page footers often keep readers from scrolling to 100.
+const READ_COMPLETION_PROGRESS = 95
+
+const CONTINUE_READING_LIMIT = 10
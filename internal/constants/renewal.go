@@ -0,0 +1,21 @@
+package constants
+
+import "time"
+
+const (
+	// The renewal invoice is issued this long before the subscription ends.
+	RENEWAL_INVOICE_LEAD_TIME = 3 * 24 * time.Hour
+	// An unpaid renewal keeps the subscription usable for this long after it
+	// ends. The renewal invoice expires once the grace period is over.
+	RENEWAL_GRACE_PERIOD         = 7 * 24 * time.Hour
+	RENEWAL_JOB_INTERVAL         = time.Hour
+	SUBSCRIPTION_DURATION_MONTHS = 1
+)
+
+// RENEWAL_REMINDER_OFFSETS are when the dunning reminders for an unpaid
+// renewal invoice go out, counted from the end of the subscription.
+var RENEWAL_REMINDER_OFFSETS = []time.Duration{
+	0,
+	3 * 24 * time.Hour,
+	6 * 24 * time.Hour,
+}
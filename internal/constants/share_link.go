@@ -0,0 +1,8 @@
+package constants
+
+import "time"
+
+const (
+	SHARE_LINK_MONTHLY_LIMIT = 5
+	SHARE_LINK_TTL           = 7 * 24 * time.Hour
+)
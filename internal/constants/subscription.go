@@ -0,0 +1,60 @@
+package constants
+
+type SubscriptionStatus string
+
+const (
+	SUBSCRIPTION_ACTIVE    SubscriptionStatus = "active"
+	SUBSCRIPTION_PAUSED    SubscriptionStatus = "paused"
+	SUBSCRIPTION_CANCELLED SubscriptionStatus = "cancelled"
+	SUBSCRIPTION_EXPIRED   SubscriptionStatus = "expired"
+)
+
+func ParseSubscriptionStatus(status string) (SubscriptionStatus, bool) {
+	switch SubscriptionStatus(status) {
+	case SUBSCRIPTION_ACTIVE, SUBSCRIPTION_PAUSED, SUBSCRIPTION_CANCELLED, SUBSCRIPTION_EXPIRED:
+		return SubscriptionStatus(status), true
+	default:
+		return "", false
+	}
+}
+
+// A subscription can be paused for at most this many days at a time.
+const SUBSCRIPTION_MAX_PAUSE_DAYS = 30
+
+// QuotaCarryOverPolicy decides how much of the old plan's remaining quota a
+// member keeps after switching plans. The prorated credit already pays back
+// the unused quota, so the default is to carry none of it over.
+type QuotaCarryOverPolicy string
+
+const (
+	QUOTA_CARRY_OVER_NONE   QuotaCarryOverPolicy = "none"
+	QUOTA_CARRY_OVER_FULL   QuotaCarryOverPolicy = "full"
+	QUOTA_CARRY_OVER_CAPPED QuotaCarryOverPolicy = "capped"
+)
+
+func ParseQuotaCarryOverPolicy(policy string) (QuotaCarryOverPolicy, bool) {
+	switch QuotaCarryOverPolicy(policy) {
+	case QUOTA_CARRY_OVER_NONE, QUOTA_CARRY_OVER_FULL, QUOTA_CARRY_OVER_CAPPED:
+		return QuotaCarryOverPolicy(policy), true
+	default:
+		return "", false
+	}
+}
+
+// PlanType tells plans that are sold apart from the trial plan new members
+// get for free. Trial plans are never sold or renewed.
+type PlanType string
+
+const (
+	PLAN_PAID  PlanType = "paid"
+	PLAN_TRIAL PlanType = "trial"
+)
+
+func ParsePlanType(planType string) (PlanType, bool) {
+	switch PlanType(planType) {
+	case PLAN_PAID, PLAN_TRIAL:
+		return PlanType(planType), true
+	default:
+		return "", false
+	}
+}
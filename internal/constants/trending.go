@@ -0,0 +1,17 @@
+package constants
+
+import "time"
+
+const (
+	TRENDING_POSTS_LIMIT      = 5
+	TRENDING_WINDOW           = 7 * 24 * time.Hour
+	TRENDING_HALF_LIFE        = 24 * time.Hour
+	TRENDING_REFRESH_INTERVAL = 10 * time.Minute
+)
+
+// Weights of each kind of engagement in the trending score before decay.
+const (
+	TRENDING_READ_WEIGHT  = 1.0
+	TRENDING_LIKE_WEIGHT  = 3.0
+	TRENDING_SHARE_WEIGHT = 5.0
+)
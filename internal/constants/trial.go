@@ -0,0 +1,14 @@
+package constants
+
+import "time"
+
+const TRIAL_DURATION_DAYS = 7
+
+// Members are reminded this long before their trial ends.
+const TRIAL_EXPIRY_REMINDER = 24 * time.Hour
+
+const TRIAL_JOB_INTERVAL = time.Hour
+
+// Verification links are sent on registration and only a verified email can
+// start a trial.
+const EMAIL_VERIFICATION_TTL = 48 * time.Hour
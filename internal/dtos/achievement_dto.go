@@ -0,0 +1,142 @@
+package dtos
+
+import (
+	"time"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/models"
+)
+
+// AchievementRuleRequest is used both to create and to replace a rule.
+// IsActive defaults to true when left out.
+type AchievementRuleRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Kind        string `json:"kind" binding:"required"`
+	CategoryID  *int64 `json:"category_id"`
+	Threshold   int    `json:"threshold" binding:"required,min=1"`
+	VoucherID   *int64 `json:"voucher_id"`
+	IsActive    *bool  `json:"is_active"`
+}
+
+type AchievementRuleResponse struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Kind        string    `json:"kind"`
+	CategoryID  *int64    `json:"category_id"`
+	Category    string    `json:"category,omitempty"`
+	Threshold   int       `json:"threshold"`
+	VoucherID   *int64    `json:"voucher_id"`
+	Voucher     string    `json:"voucher,omitempty"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type BadgeResponse struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Kind        string     `json:"kind"`
+	Category    string     `json:"category,omitempty"`
+	Threshold   int        `json:"threshold"`
+	Progress    int        `json:"progress"`
+	IsAchieved  bool       `json:"is_achieved"`
+	AchievedAt  *time.Time `json:"achieved_at"`
+	VoucherCode string     `json:"voucher_code,omitempty"`
+}
+
+type UserAchievementsResponse struct {
+	Timezone      string           `json:"timezone"`
+	CurrentStreak int              `json:"current_streak"`
+	LongestStreak int              `json:"longest_streak"`
+	Badges        []*BadgeResponse `json:"badges"`
+}
+
+func FormatAchievementRuleRequest(request *AchievementRuleRequest, kind constants.AchievementKind) *models.AchievementRule {
+	isActive := true
+	if request.IsActive != nil {
+		isActive = *request.IsActive
+	}
+
+	return &models.AchievementRule{
+		Name:        request.Name,
+		Description: request.Description,
+		Kind:        kind,
+		CategoryID:  request.CategoryID,
+		Threshold:   request.Threshold,
+		VoucherID:   request.VoucherID,
+		IsActive:    isActive,
+	}
+}
+
+func FormatAchievementRule(rule *models.AchievementRule) *AchievementRuleResponse {
+	response := &AchievementRuleResponse{
+		ID:          rule.ID,
+		Name:        rule.Name,
+		Description: rule.Description,
+		Kind:        string(rule.Kind),
+		CategoryID:  rule.CategoryID,
+		Threshold:   rule.Threshold,
+		VoucherID:   rule.VoucherID,
+		IsActive:    rule.IsActive,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+
+	if rule.Category != nil {
+		response.Category = rule.Category.Name
+	}
+
+	if rule.Voucher != nil {
+		response.Voucher = rule.Voucher.Name
+	}
+
+	return response
+}
+
+func FormatAchievementRules(rules []*models.AchievementRule) []*AchievementRuleResponse {
+	formattedRules := []*AchievementRuleResponse{}
+	for _, rule := range rules {
+		formattedRules = append(formattedRules, FormatAchievementRule(rule))
+	}
+
+	return formattedRules
+}
+
+func FormatUserAchievements(summary *models.AchievementSummary) *UserAchievementsResponse {
+	badges := []*BadgeResponse{}
+	for _, badge := range summary.Badges {
+		formatted := &BadgeResponse{
+			ID:          badge.Rule.ID,
+			Name:        badge.Rule.Name,
+			Description: badge.Rule.Description,
+			Kind:        string(badge.Rule.Kind),
+			Threshold:   badge.Rule.Threshold,
+			Progress:    badge.Progress,
+		}
+
+		if badge.Rule.Category != nil {
+			formatted.Category = badge.Rule.Category.Name
+		}
+
+		if badge.Achievement != nil {
+			formatted.IsAchieved = true
+			formatted.AchievedAt = &badge.Achievement.AchievedAt
+
+			if badge.Achievement.UserVoucher != nil {
+				formatted.VoucherCode = badge.Achievement.UserVoucher.Code
+			}
+		}
+
+		badges = append(badges, formatted)
+	}
+
+	return &UserAchievementsResponse{
+		Timezone:      summary.Timezone,
+		CurrentStreak: summary.CurrentStreak,
+		LongestStreak: summary.LongestStreak,
+		Badges:        badges,
+	}
+}
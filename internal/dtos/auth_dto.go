@@ -1,5 +1,7 @@
 package dtos
 
+import "time"
+
 type RegisterRequestDTO struct {
 	Email        string  `json:"email" binding:"required"`
 	Password     string  `json:"password" binding:"required"`
@@ -17,6 +19,25 @@ type RegisterResponseDTO struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// EmailVerificationClaims is the signed payload of an email verification link.
+type EmailVerificationClaims struct {
+	UserID    int64     `json:"u"`
+	Email     string    `json:"m"`
+	ExpiresAt time.Time `json:"e"`
+}
+
+type VerifyEmailRequestDTO struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type VerifyEmailResponseDTO struct {
+	ID              int64      `json:"id"`
+	Email           string     `json:"email"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// Trial is the free trial started for the member, if any.
+	Trial *UserSubscriptionDTO `json:"trial,omitempty"`
+}
+
 type LoginRequestDTO struct {
 	Email    string `json:"email" binding:"required"`
 	Password string `json:"password" binding:"required"`
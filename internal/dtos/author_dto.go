@@ -0,0 +1,102 @@
+package dtos
+
+import (
+	"final-project-backend/internal/models"
+)
+
+type AuthorSocialLinkRequest struct {
+	Platform string `json:"platform" binding:"required"`
+	Url      string `json:"url" binding:"required"`
+}
+
+type CreateAuthorRequest struct {
+	Name        string                     `json:"name" binding:"required"`
+	Bio         string                     `json:"bio"`
+	AvatarUrl   string                     `json:"avatar_url"`
+	SocialLinks []*AuthorSocialLinkRequest `json:"social_links" binding:"dive"`
+}
+
+type UpdateAuthorRequest struct {
+	Name        string                     `json:"name"`
+	Bio         string                     `json:"bio"`
+	AvatarUrl   string                     `json:"avatar_url"`
+	SocialLinks []*AuthorSocialLinkRequest `json:"social_links" binding:"omitempty,dive"`
+}
+
+type AuthorSocialLinkResponse struct {
+	Platform string `json:"platform"`
+	Url      string `json:"url"`
+}
+
+type AuthorResponse struct {
+	ID          int64                       `json:"id"`
+	Name        string                      `json:"name"`
+	Bio         string                      `json:"bio"`
+	AvatarUrl   string                      `json:"avatar_url"`
+	SocialLinks []*AuthorSocialLinkResponse `json:"social_links"`
+}
+
+type AuthorProfileResponse struct {
+	AuthorResponse
+	FollowerCount int64 `json:"follower_count"`
+	IsFollowing   bool  `json:"is_following"`
+}
+
+type GetAllAuthorsResponse = []*AuthorResponse
+
+type GetAuthorByIDResponse struct {
+	AuthorProfileResponse
+	Posts []*PostResponseCompact `json:"posts"`
+	PaginationResponse
+}
+
+type FollowAuthorResponse struct {
+	AuthorID    int64 `json:"author_id"`
+	IsFollowing bool  `json:"is_following"`
+}
+
+func FormatAuthorSocialLinksRequest(links []*AuthorSocialLinkRequest) []*models.AuthorSocialLink {
+	formattedLinks := []*models.AuthorSocialLink{}
+	for _, link := range links {
+		formattedLinks = append(formattedLinks, &models.AuthorSocialLink{
+			Platform: link.Platform,
+			Url:      link.Url,
+		})
+	}
+	return formattedLinks
+}
+
+func FormatAuthor(author *models.Author) *AuthorResponse {
+	socialLinks := []*AuthorSocialLinkResponse{}
+	for _, link := range author.SocialLinks {
+		socialLinks = append(socialLinks, &AuthorSocialLinkResponse{
+			Platform: link.Platform,
+			Url:      link.Url,
+		})
+	}
+
+	return &AuthorResponse{
+		ID:          author.ID,
+		Name:        author.Name,
+		Bio:         author.Bio,
+		AvatarUrl:   author.AvatarUrl,
+		SocialLinks: socialLinks,
+	}
+}
+
+func FormatAuthors(authors []*models.Author) []*AuthorResponse {
+	formattedAuthors := []*AuthorResponse{}
+	for _, author := range authors {
+		formattedAuthor := FormatAuthor(author)
+		formattedAuthors = append(formattedAuthors, formattedAuthor)
+	}
+	return formattedAuthors
+}
+
+func FormatAuthorProfile(profile *models.AuthorProfile) *AuthorProfileResponse {
+	return &AuthorProfileResponse{
+		AuthorResponse: *FormatAuthor(&profile.Author),
+		FollowerCount:  profile.FollowerCount,
+		IsFollowing:    profile.IsFollowing,
+	}
+}
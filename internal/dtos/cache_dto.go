@@ -0,0 +1,34 @@
+package dtos
+
+import "final-project-backend/internal/cache"
+
+type CacheStatsResponse struct {
+	Hits      uint64  `json:"hits"`
+	Misses    uint64  `json:"misses"`
+	HitRatio  float64 `json:"hit_ratio"`
+	Evictions uint64  `json:"evictions"`
+	Size      int     `json:"size"`
+	Capacity  int     `json:"capacity"`
+}
+
+type GetCacheStatsResponse = map[string]*CacheStatsResponse
+
+func FormatCacheStats(stats map[string]cache.Stats) GetCacheStatsResponse {
+	formattedStats := GetCacheStatsResponse{}
+	for name, stat := range stats {
+		hitRatio := 0.0
+		if lookups := stat.Hits + stat.Misses; lookups > 0 {
+			hitRatio = float64(stat.Hits) / float64(lookups)
+		}
+
+		formattedStats[name] = &CacheStatsResponse{
+			Hits:      stat.Hits,
+			Misses:    stat.Misses,
+			HitRatio:  hitRatio,
+			Evictions: stat.Evictions,
+			Size:      stat.Size,
+			Capacity:  stat.Capacity,
+		}
+	}
+	return formattedStats
+}
@@ -0,0 +1,209 @@
+package dtos
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/models"
+)
+
+type RSSFeed struct {
+	XMLName xml.Name    `xml:"rss"`
+	Version string      `xml:"version,attr"`
+	AtomNS  string      `xml:"xmlns:atom,attr"`
+	Channel *RSSChannel `xml:"channel"`
+}
+
+type RSSChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	LastBuildDate string     `xml:"lastBuildDate,omitempty"`
+	SelfLink      *RSSLink   `xml:"atom:link"`
+	Items         []*RSSItem `xml:"item"`
+}
+
+type RSSLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type RSSItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	Category    string   `xml:"category,omitempty"`
+	GUID        *RSSGUID `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+type RSSGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type AtomFeed struct {
+	XMLName xml.Name     `xml:"feed"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	ID      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Links   []*AtomLink  `xml:"link"`
+	Entries []*AtomEntry `xml:"entry"`
+}
+
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type AtomEntry struct {
+	ID        string        `xml:"id"`
+	Title     string        `xml:"title"`
+	Link      *AtomLink     `xml:"link"`
+	Published string        `xml:"published"`
+	Updated   string        `xml:"updated"`
+	Summary   string        `xml:"summary"`
+	Author    *AtomAuthor   `xml:"author,omitempty"`
+	Category  *AtomCategory `xml:"category,omitempty"`
+}
+
+type AtomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type SitemapIndex struct {
+	XMLName  xml.Name           `xml:"sitemapindex"`
+	Xmlns    string             `xml:"xmlns,attr"`
+	Sitemaps []*SitemapLocation `xml:"sitemap"`
+}
+
+type SitemapLocation struct {
+	Loc string `xml:"loc"`
+}
+
+type SitemapURLSet struct {
+	XMLName xml.Name      `xml:"urlset"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	URLs    []*SitemapURL `xml:"url"`
+}
+
+type SitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+func FormatPostLink(baseUrl string, post *models.Post) string {
+	return fmt.Sprintf("%s/posts/%d/%s", baseUrl, post.ID, post.Slug)
+}
+
+func FormatRSSFeed(baseUrl string, selfUrl string, title string, posts []*models.Post) *RSSFeed {
+	channel := &RSSChannel{
+		Title:       title,
+		Link:        baseUrl,
+		Description: constants.FEED_DESCRIPTION,
+		SelfLink:    &RSSLink{Href: selfUrl, Rel: "self", Type: "application/rss+xml"},
+		Items:       []*RSSItem{},
+	}
+
+	if len(posts) > 0 {
+		channel.LastBuildDate = posts[0].CreatedAt.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, post := range posts {
+		link := FormatPostLink(baseUrl, post)
+		channel.Items = append(channel.Items, &RSSItem{
+			Title:       post.Title,
+			Link:        link,
+			Description: post.Summary,
+			Category:    post.Category.Name,
+			GUID:        &RSSGUID{Value: link, IsPermaLink: true},
+			PubDate:     post.CreatedAt.UTC().Format(time.RFC1123Z),
+		})
+	}
+
+	return &RSSFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: channel,
+	}
+}
+
+func FormatAtomFeed(baseUrl string, selfUrl string, title string, posts []*models.Post) *AtomFeed {
+	feed := &AtomFeed{
+		Xmlns: "http://www.w3.org/2005/Atom",
+		ID:    selfUrl,
+		Title: title,
+		Links: []*AtomLink{
+			{Href: selfUrl, Rel: "self"},
+			{Href: baseUrl, Rel: "alternate"},
+		},
+		Entries: []*AtomEntry{},
+	}
+
+	if len(posts) > 0 {
+		feed.Updated = posts[0].CreatedAt.UTC().Format(time.RFC3339)
+	} else {
+		feed.Updated = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	for _, post := range posts {
+		link := FormatPostLink(baseUrl, post)
+		entry := &AtomEntry{
+			ID:        link,
+			Title:     post.Title,
+			Link:      &AtomLink{Href: link},
+			Published: post.CreatedAt.UTC().Format(time.RFC3339),
+			Updated:   post.CreatedAt.UTC().Format(time.RFC3339),
+			Summary:   post.Summary,
+		}
+
+		if post.Author.Name != "" {
+			entry.Author = &AtomAuthor{Name: post.Author.Name}
+		}
+
+		if post.Category.Name != "" {
+			entry.Category = &AtomCategory{Term: post.Category.Name}
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed
+}
+
+func FormatSitemapIndex(baseUrl string, totalPages int64) *SitemapIndex {
+	sitemaps := []*SitemapLocation{}
+	for page := int64(1); page <= totalPages; page++ {
+		sitemaps = append(sitemaps, &SitemapLocation{
+			Loc: fmt.Sprintf("%s/sitemap-posts.xml?page=%d", baseUrl, page),
+		})
+	}
+
+	return &SitemapIndex{
+		Xmlns:    "http://www.sitemaps.org/schemas/sitemap/0.9",
+		Sitemaps: sitemaps,
+	}
+}
+
+func FormatSitemapURLSet(baseUrl string, posts []*models.Post) *SitemapURLSet {
+	urls := []*SitemapURL{}
+	for _, post := range posts {
+		urls = append(urls, &SitemapURL{
+			Loc:     FormatPostLink(baseUrl, post),
+			LastMod: post.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return &SitemapURLSet{
+		Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		URLs:  urls,
+	}
+}
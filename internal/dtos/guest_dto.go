@@ -0,0 +1,9 @@
+package dtos
+
+// GuestMeter is the payload of the signed device cookie that tracks which
+// premium posts an anonymous visitor read during Period.
+type GuestMeter struct {
+	DeviceID string  `json:"d"`
+	Period   string  `json:"m"`
+	PostIDs  []int64 `json:"p"`
+}
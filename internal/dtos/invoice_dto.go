@@ -1,23 +1,33 @@
 package dtos
 
 import (
+	"fmt"
 	"time"
 
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/models"
 )
 
 type InvoiceResponse struct {
-	ID               int64      `json:"id"`
-	Code             string     `json:"code"`
-	UserID           int64      `json:"user_id"`
-	UserEmail        string     `json:"user_email"`
-	Status           string     `json:"status"`
-	Total            int        `json:"total"`
-	OriginalPrice    int        `json:"original_price"`
-	SubscriptionID   int64      `json:"subscription_id"`
-	SubscriptionPlan string     `json:"subscription_plan"`
-	PaidAt           *time.Time `json:"paid_at,omitempty"`
-	PurchasedAt      time.Time  `json:"purchased_at"`
+	ID               int64  `json:"id"`
+	Code             string `json:"code"`
+	UserID           int64  `json:"user_id"`
+	UserEmail        string `json:"user_email"`
+	Status           string `json:"status"`
+	Total            int    `json:"total"`
+	OriginalPrice    int    `json:"original_price"`
+	SubscriptionID   int64  `json:"subscription_id"`
+	SubscriptionPlan string `json:"subscription_plan"`
+	// SubscriptionVersion is the version of the plan that was bought.
+	SubscriptionVersion int `json:"subscription_version"`
+	// RenewalOfID and DueAt are only set on renewal invoices.
+	RenewalOfID *int64     `json:"renewal_of_id,omitempty"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	// ChangeOfID and Credit are only set on plan change invoices.
+	ChangeOfID  *int64     `json:"change_of_id,omitempty"`
+	Credit      int        `json:"credit,omitempty"`
+	PaidAt      *time.Time `json:"paid_at,omitempty"`
+	PurchasedAt time.Time  `json:"purchased_at"`
 }
 
 type GetWaitingInvoiceByCodeProtectedResponse struct {
@@ -32,10 +42,48 @@ type GetWaitingInvoiceByCodeProtectedResponse struct {
 
 type GetAllInvoicesResponse = []*InvoiceResponse
 
-type CreateInvoiceRequest struct {
-	OriginalPrice  int    `json:"original_price" binding:"required"`
-	SubscriptionID int    `json:"subscription_id" binding:"required"`
+type QuoteInvoiceRequest struct {
+	SubscriptionID int64  `json:"subscription_id" binding:"required"`
 	VoucherCode    string `json:"voucher_code"`
+	// ChangeFromID is the member's subscription to switch to this plan.
+	ChangeFromID int64 `json:"change_from_id"`
+}
+
+// PriceQuoteClaims is the signed payload of a quote ID.
+type PriceQuoteClaims struct {
+	UserID         int64     `json:"u"`
+	SubscriptionID int64     `json:"s"`
+	VoucherCode    string    `json:"v,omitempty"`
+	OriginalPrice  int       `json:"o"`
+	Total          int       `json:"t"`
+	ExpiresAt      time.Time `json:"e"`
+	ChangeFromID   int64     `json:"c,omitempty"`
+	Credit         int       `json:"r,omitempty"`
+	QuotaCarryOver string    `json:"q,omitempty"`
+}
+
+type PriceQuoteLineItem struct {
+	Description string `json:"description"`
+	Amount      int    `json:"amount"`
+}
+
+type PriceQuoteResponse struct {
+	QuoteID             string                `json:"quote_id"`
+	SubscriptionID      int64                 `json:"subscription_id"`
+	SubscriptionPlan    string                `json:"subscription_plan"`
+	SubscriptionVersion int                   `json:"subscription_version"`
+	LineItems           []*PriceQuoteLineItem `json:"line_items"`
+	OriginalPrice       int                   `json:"original_price"`
+	Discount            int                   `json:"discount"`
+	Credit              int                   `json:"credit"`
+	Total               int                   `json:"total"`
+	ExpiresAt           time.Time             `json:"expires_at"`
+	ChangeFromID        int64                 `json:"change_from_id,omitempty"`
+	QuotaCarryOver      string                `json:"quota_carry_over,omitempty"`
+}
+
+type CreateInvoiceRequest struct {
+	QuoteID string `json:"quote_id" binding:"required"`
 }
 
 type CreateInvoiceResponse = InvoiceResponse
@@ -52,17 +100,22 @@ type UpdateProcessedInvoiceResponse struct {
 
 func FormatInvoice(s *models.Invoice) *InvoiceResponse {
 	return &InvoiceResponse{
-		ID:               int64(s.ID),
-		UserID:           s.UserID,
-		Status:           s.Status.String(),
-		Total:            s.Total,
-		SubscriptionID:   s.SubscriptionID,
-		PaidAt:           s.PaidAt,
-		Code:             s.Code,
-		UserEmail:        s.User.Email,
-		SubscriptionPlan: s.Subscription.Name,
-		PurchasedAt:      s.Model.CreatedAt,
-		OriginalPrice:    s.OriginalPrice,
+		ID:                  int64(s.ID),
+		UserID:              s.UserID,
+		Status:              s.Status.String(),
+		Total:               s.Total,
+		SubscriptionID:      s.SubscriptionID,
+		PaidAt:              s.PaidAt,
+		Code:                s.Code,
+		UserEmail:           s.User.Email,
+		SubscriptionPlan:    s.Subscription.Name,
+		SubscriptionVersion: s.Subscription.Version,
+		RenewalOfID:         s.RenewalOfID,
+		DueAt:               s.DueAt,
+		ChangeOfID:          s.ChangeOfID,
+		Credit:              s.Credit,
+		PurchasedAt:         s.Model.CreatedAt,
+		OriginalPrice:       s.OriginalPrice,
 	}
 }
 
@@ -74,3 +127,83 @@ func FormatInvoices(invoices []*models.Invoice) []*InvoiceResponse {
 	}
 	return formattedInvoices
 }
+
+func FormatQuoteInvoiceRequest(request *QuoteInvoiceRequest, userID int64, policy constants.QuotaCarryOverPolicy) *models.PriceQuote {
+	return &models.PriceQuote{
+		UserID:         userID,
+		SubscriptionID: request.SubscriptionID,
+		VoucherCode:    request.VoucherCode,
+		ChangeFromID:   request.ChangeFromID,
+		QuotaCarryOver: policy,
+	}
+}
+
+func FormatPriceQuoteClaims(quote *models.PriceQuote) *PriceQuoteClaims {
+	return &PriceQuoteClaims{
+		UserID:         quote.UserID,
+		SubscriptionID: quote.SubscriptionID,
+		VoucherCode:    quote.VoucherCode,
+		OriginalPrice:  quote.OriginalPrice,
+		Total:          quote.Total,
+		ExpiresAt:      quote.ExpiresAt,
+		ChangeFromID:   quote.ChangeFromID,
+		Credit:         quote.Credit,
+		QuotaCarryOver: string(quote.QuotaCarryOver),
+	}
+}
+
+func FormatPriceQuoteFromClaims(claims *PriceQuoteClaims) *models.PriceQuote {
+	return &models.PriceQuote{
+		UserID:         claims.UserID,
+		SubscriptionID: claims.SubscriptionID,
+		VoucherCode:    claims.VoucherCode,
+		OriginalPrice:  claims.OriginalPrice,
+		Discount:       claims.OriginalPrice - claims.Credit - claims.Total,
+		Total:          claims.Total,
+		ExpiresAt:      claims.ExpiresAt,
+		ChangeFromID:   claims.ChangeFromID,
+		Credit:         claims.Credit,
+		QuotaCarryOver: constants.QuotaCarryOverPolicy(claims.QuotaCarryOver),
+	}
+}
+
+func FormatPriceQuote(quote *models.PriceQuote, quoteID string) *PriceQuoteResponse {
+	response := &PriceQuoteResponse{
+		QuoteID:        quoteID,
+		SubscriptionID: quote.SubscriptionID,
+		LineItems:      []*PriceQuoteLineItem{},
+		OriginalPrice:  quote.OriginalPrice,
+		Discount:       quote.Discount,
+		Credit:         quote.Credit,
+		Total:          quote.Total,
+		ExpiresAt:      quote.ExpiresAt,
+		ChangeFromID:   quote.ChangeFromID,
+		QuotaCarryOver: string(quote.QuotaCarryOver),
+	}
+
+	if quote.Subscription != nil {
+		response.SubscriptionPlan = quote.Subscription.Name
+		response.SubscriptionVersion = quote.Subscription.Version
+	}
+
+	response.LineItems = append(response.LineItems, &PriceQuoteLineItem{
+		Description: fmt.Sprintf("%s subscription", response.SubscriptionPlan),
+		Amount:      quote.OriginalPrice,
+	})
+
+	if quote.VoucherCode != "" {
+		response.LineItems = append(response.LineItems, &PriceQuoteLineItem{
+			Description: fmt.Sprintf("Voucher %s", quote.VoucherCode),
+			Amount:      -quote.Discount,
+		})
+	}
+
+	if quote.ChangeFrom != nil {
+		response.LineItems = append(response.LineItems, &PriceQuoteLineItem{
+			Description: fmt.Sprintf("Credit for unused %s subscription", quote.ChangeFrom.Subscription.Name),
+			Amount:      -quote.Credit,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,34 @@
+package dtos
+
+import (
+	"time"
+
+	"final-project-backend/internal/models"
+)
+
+type NotificationResponse struct {
+	ID        int64     `json:"id"`
+	Kind      string    `json:"kind"`
+	Message   string    `json:"message"`
+	InvoiceID *int64    `json:"invoice_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetNotificationsResponse struct {
+	Data []*NotificationResponse `json:"data"`
+	PaginationResponse
+}
+
+func FormatNotifications(notifications []*models.Notification) []*NotificationResponse {
+	formattedNotifications := []*NotificationResponse{}
+	for _, notification := range notifications {
+		formattedNotifications = append(formattedNotifications, &NotificationResponse{
+			ID:        notification.ID,
+			Kind:      string(notification.Kind),
+			Message:   notification.Message,
+			InvoiceID: notification.InvoiceID,
+			CreatedAt: notification.CreatedAt,
+		})
+	}
+	return formattedNotifications
+}
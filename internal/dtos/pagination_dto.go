@@ -6,3 +6,9 @@ type PaginationResponse struct {
 	TotalRows   int64 `json:"total"`
 	TotalPages  int64 `json:"total_pages"`
 }
+
+type CursorPaginationResponse struct {
+	PerPage    int    `json:"per_page"`
+	NextCursor string `json:"next_cursor"`
+	PrevCursor string `json:"prev_cursor"`
+}
@@ -1,19 +1,22 @@
 package dtos
 
 import (
+	"strings"
 	"time"
 
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/models"
 )
 
 type CreatePostRequest struct {
-	Title      string `json:"title" binding:"required"`
-	Content    string `json:"content" binding:"required"`
-	Summary    string `json:"summary" binding:"required"`
-	CategoryID int64  `json:"category_id" binding:"required"`
-	TypeID     int64  `json:"type_id" binding:"required"`
-	ImgUrl     string `json:"img_url" binding:"required"`
-	AuthorName string `json:"author_name" binding:"required"`
+	Title      string   `json:"title" binding:"required"`
+	Content    string   `json:"content" binding:"required"`
+	Summary    string   `json:"summary" binding:"required"`
+	CategoryID int64    `json:"category_id" binding:"required"`
+	TypeID     int64    `json:"type_id" binding:"required"`
+	ImgUrl     string   `json:"img_url" binding:"required"`
+	AuthorID   int64    `json:"author_id" binding:"required"`
+	Tags       []string `json:"tags" binding:"omitempty,dive,required"`
 }
 
 type CreatePostResponse struct {
@@ -21,35 +24,43 @@ type CreatePostResponse struct {
 }
 
 type PostResponse struct {
-	ID           int64     `json:"id"`
-	Title        string    `json:"title"`
-	Slug         string    `json:"slug"`
-	Content      string    `json:"content,omitempty"`
-	Summary      string    `json:"summary"`
-	CategoryID   int64     `json:"category_id,omitempty"`
-	Category     string    `json:"category"`
-	TypeID       int64     `json:"type_id,omitempty"`
-	Type         string    `json:"type"`
-	ImgUrl       string    `json:"img_url,omitempty"`
-	ImgThumbnail string    `json:"img_thumbnail"`
-	AuthorName   string    `json:"author_name"`
-	ShareCount   int       `json:"share_count"`
-	LikeCount    int       `json:"like_count"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID                   int64          `json:"id"`
+	Title                string         `json:"title"`
+	Slug                 string         `json:"slug"`
+	Content              string         `json:"content,omitempty"`
+	Summary              string         `json:"summary"`
+	CategoryID           int64          `json:"category_id,omitempty"`
+	Category             string         `json:"category"`
+	TypeID               int64          `json:"type_id,omitempty"`
+	Type                 string         `json:"type"`
+	ImgUrl               string         `json:"img_url,omitempty"`
+	ImgThumbnail         string         `json:"img_thumbnail"`
+	AuthorID             int64          `json:"author_id"`
+	AuthorName           string         `json:"author_name"`
+	Tags                 []string       `json:"tags"`
+	ShareCount           int            `json:"share_count"`
+	LikeCount            int            `json:"like_count"`
+	ShareRedemptionCount int            `json:"share_redemption_count"`
+	ReactionCount        int            `json:"reaction_count"`
+	Reactions            map[string]int `json:"reactions"`
+	CreatedAt            time.Time      `json:"created_at"`
 }
 
 type PostResponseCompact struct {
-	ID           int64     `json:"id"`
-	Title        string    `json:"title"`
-	Slug         string    `json:"slug"`
-	Summary      string    `json:"summary"`
-	Category     string    `json:"category"`
-	Type         string    `json:"type"`
-	ImgThumbnail string    `json:"img_thumbnail"`
-	AuthorName   string    `json:"author_name"`
-	ShareCount   int       `json:"share_count"`
-	LikeCount    int       `json:"like_count"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID            int64          `json:"id"`
+	Title         string         `json:"title"`
+	Slug          string         `json:"slug"`
+	Summary       string         `json:"summary"`
+	Category      string         `json:"category"`
+	Type          string         `json:"type"`
+	ImgThumbnail  string         `json:"img_thumbnail"`
+	AuthorID      int64          `json:"author_id"`
+	AuthorName    string         `json:"author_name"`
+	ShareCount    int            `json:"share_count"`
+	LikeCount     int            `json:"like_count"`
+	ReactionCount int            `json:"reaction_count"`
+	Reactions     map[string]int `json:"reactions"`
+	CreatedAt     time.Time      `json:"created_at"`
 }
 
 type GetPostByIDWithHistoryResponse struct {
@@ -57,61 +68,214 @@ type GetPostByIDWithHistoryResponse struct {
 	HistoryResponseDTO
 }
 
+type PostPaywallResponse struct {
+	PostResponse
+	Paywall *PaywallResponse `json:"paywall"`
+}
+
+type PaywallResponse struct {
+	Teaser         string                  `json:"teaser"`
+	QuotaCost      int                     `json:"quota_cost"`
+	RemainingQuota int                     `json:"remaining_quota"`
+	CanUnlock      bool                    `json:"can_unlock"`
+	Plans          []*SubscriptionResponse `json:"plans"`
+	// RequiresRegistration is set for anonymous visitors whose metered
+	// allowance of premium reads is used up.
+	RequiresRegistration bool `json:"requires_registration"`
+}
+
 type GetAllPostResponse struct {
 	Data []*PostResponseCompact `json:"data"`
 	PaginationResponse
 }
 
+type GetAllPostCursorResponse struct {
+	Data []*PostResponseCompact `json:"data"`
+	CursorPaginationResponse
+}
+
+type GetRelatedPostsResponse = []*PostResponseCompact
+
+type FeedItem struct {
+	Post   *models.Post
+	Reason string
+}
+
+type FeedItemResponse struct {
+	PostResponseCompact
+	Reason string `json:"reason"`
+}
+
+type GetFeedResponse struct {
+	Data       []*FeedItemResponse `json:"data"`
+	PerPage    int                 `json:"per_page"`
+	NextCursor string              `json:"next_cursor"`
+	HasMore    bool                `json:"has_more"`
+}
+
 type GetAllTypesResponse = []*models.PostType
 
 type GetAllCategoriesResponse = []*models.Category
 
+type CreateTypeRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Quota *int   `json:"quota" binding:"required,min=0"`
+}
+
+type CreateCategoryRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
 type LikePostRequest struct {
 	IsLike *bool `json:"is_like" binding:"required"`
 }
 
-type PostsRequestQuery struct {
-	Search     string
+type ReadingProgressRequest struct {
+	Progress *int `json:"progress" binding:"required,min=0,max=100"`
+}
+
+type PostAnalyticsResponse struct {
+	PostID          int64          `json:"post_id"`
+	ReadCount       int64          `json:"read_count"`
+	CompletedCount  int64          `json:"completed_count"`
+	CompletionRate  float64        `json:"completion_rate"`
+	AverageProgress float64        `json:"average_progress"`
+	LikeCount       int            `json:"like_count"`
+	ShareCount      int            `json:"share_count"`
+	ReactionCount   int            `json:"reaction_count"`
+	Reactions       map[string]int `json:"reactions"`
+}
+
+// ReactPostRequest clears the member's reaction when Reaction is empty.
+type ReactPostRequest struct {
+	Reaction *string `json:"reaction" binding:"required"`
+}
+
+// TrendingPostsQuery narrows trending to one category when CategoryID is
+// set, to every category when IsGlobal is set, and otherwise to the
+// category the user read most in the past week.
+type TrendingPostsQuery struct {
+	UserID     int64
 	CategoryID int64
-	TypeID     int64
-	Sort       string
-	Limit      int
-	Page       int
+	IsGlobal   bool
+}
+
+type PostsRequestQuery struct {
+	Search         string
+	CategoryIDs    []int64
+	TypeIDs        []int64
+	AuthorID       int64
+	FollowerID     int64
+	UnreadByUserID int64
+	// ExcludedCategoryIDs leaves out posts of these categories.
+	ExcludedCategoryIDs []int64
+	DateFrom            *time.Time
+	DateTo              *time.Time
+	Sort                constants.PostSort
+	Limit               int
+	Page                int
+	// IsCursorMode switches GetAll from offset pagination to keyset
+	// pagination on (created_at, id), starting after Cursor when set.
+	IsCursorMode bool
+	Cursor       *PostCursor
+}
+
+type PostCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int64     `json:"id"`
+	IsPrev    bool      `json:"p,omitempty"`
+}
+
+// FeedCursor keeps the interests the personal feed started with, so every
+// page draws from the same sources, and where each source left off.
+type FeedCursor struct {
+	Interests []*FeedSourceCursor `json:"i"`
+	Explore   *FeedSourceCursor   `json:"x"`
+}
+
+type FeedSourceCursor struct {
+	CategoryID int64       `json:"c,omitempty"`
+	Score      float64     `json:"s,omitempty"`
+	After      *PostCursor `json:"a,omitempty"`
+	IsDone     bool        `json:"d,omitempty"`
 }
 
 func FormatPost(post *models.Post) *PostResponse {
 	return &PostResponse{
-		ID:           post.ID,
-		Title:        post.Title,
-		Slug:         post.Slug,
-		Content:      post.Content,
-		Summary:      post.Summary,
-		CategoryID:   post.CategoryID,
-		Category:     post.Category.Name,
-		TypeID:       post.TypeID,
-		Type:         post.Type.Name,
-		ImgUrl:       post.ImgUrl,
-		ImgThumbnail: post.ImgThumbnail,
-		AuthorName:   post.AuthorName,
-		CreatedAt:    post.Model.CreatedAt,
-		LikeCount:    post.LikeCount,
-		ShareCount:   post.ShareCount,
+		ID:                   post.ID,
+		Title:                post.Title,
+		Slug:                 post.Slug,
+		Content:              post.Content,
+		Summary:              post.Summary,
+		CategoryID:           post.CategoryID,
+		Category:             post.Category.Name,
+		TypeID:               post.TypeID,
+		Type:                 post.Type.Name,
+		ImgUrl:               post.ImgUrl,
+		ImgThumbnail:         post.ImgThumbnail,
+		AuthorID:             post.AuthorID,
+		AuthorName:           post.Author.Name,
+		Tags:                 FormatTagNames(post.Tags),
+		CreatedAt:            post.Model.CreatedAt,
+		LikeCount:            post.LikeCount,
+		ShareCount:           post.ShareCount,
+		ShareRedemptionCount: post.ShareRedemptionCount,
+		ReactionCount:        post.ReactionCount,
+		Reactions:            FormatReactionCounts(post),
+	}
+}
+
+func FormatReactionCounts(post *models.Post) map[string]int {
+	return map[string]int{
+		string(constants.REACTION_INSIGHTFUL): post.InsightfulCount,
+		string(constants.REACTION_FUNNY):      post.FunnyCount,
+		string(constants.REACTION_SAD):        post.SadCount,
+		string(constants.REACTION_ANGRY):      post.AngryCount,
+	}
+}
+
+// FormatPostPaywall never includes the post content, only the given teaser.
+// Plans are the subscriptions whose quota would cover the missing amount.
+func FormatPostPaywall(post *models.Post, teaser string, remainingQuota int, subscriptions []*models.Subscription) *PostPaywallResponse {
+	formattedPost := FormatPost(post)
+	formattedPost.Content = ""
+
+	quotaCost := post.Type.Quota
+	plans := []*models.Subscription{}
+	for _, subscription := range subscriptions {
+		if remainingQuota+subscription.Quota >= quotaCost {
+			plans = append(plans, subscription)
+		}
+	}
+
+	return &PostPaywallResponse{
+		PostResponse: *formattedPost,
+		Paywall: &PaywallResponse{
+			Teaser:         teaser,
+			QuotaCost:      quotaCost,
+			RemainingQuota: remainingQuota,
+			CanUnlock:      remainingQuota >= quotaCost,
+			Plans:          FormatSubscriptions(plans),
+		},
 	}
 }
 
 func FormatPostCompact(post *models.Post) *PostResponseCompact {
 	return &PostResponseCompact{
-		ID:           post.ID,
-		Title:        post.Title,
-		Slug:         post.Slug,
-		Summary:      post.Summary,
-		Category:     post.Category.Name,
-		Type:         post.Type.Name,
-		ImgThumbnail: post.ImgThumbnail,
-		AuthorName:   post.AuthorName,
-		CreatedAt:    post.Model.CreatedAt,
-		LikeCount:    post.LikeCount,
-		ShareCount:   post.ShareCount,
+		ID:            post.ID,
+		Title:         post.Title,
+		Slug:          post.Slug,
+		Summary:       post.Summary,
+		Category:      post.Category.Name,
+		Type:          post.Type.Name,
+		ImgThumbnail:  post.ImgThumbnail,
+		AuthorID:      post.AuthorID,
+		AuthorName:    post.Author.Name,
+		CreatedAt:     post.Model.CreatedAt,
+		LikeCount:     post.LikeCount,
+		ShareCount:    post.ShareCount,
+		ReactionCount: post.ReactionCount,
+		Reactions:     FormatReactionCounts(post),
 	}
 }
 
@@ -123,3 +287,56 @@ func FormatPostsCompact(posts []*models.Post) []*PostResponseCompact {
 	}
 	return formattedPosts
 }
+
+func FormatTagNames(tags []*models.Tag) []string {
+	names := []string{}
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func FormatTagsRequest(names []string) []*models.Tag {
+	tags := []*models.Tag{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		tags = append(tags, &models.Tag{Name: name})
+	}
+	return tags
+}
+
+func FormatFeedItems(items []*FeedItem) []*FeedItemResponse {
+	formattedItems := []*FeedItemResponse{}
+	for _, item := range items {
+		formattedItems = append(formattedItems, &FeedItemResponse{
+			PostResponseCompact: *FormatPostCompact(item.Post),
+			Reason:              item.Reason,
+		})
+	}
+	return formattedItems
+}
+
+func FormatPostAnalytics(post *models.Post, stats *models.PostReadStats) *PostAnalyticsResponse {
+	var completionRate float64
+	if stats.ReadCount > 0 {
+		completionRate = float64(stats.CompletedCount) / float64(stats.ReadCount)
+	}
+
+	return &PostAnalyticsResponse{
+		PostID:          post.ID,
+		ReadCount:       stats.ReadCount,
+		CompletedCount:  stats.CompletedCount,
+		CompletionRate:  completionRate,
+		AverageProgress: stats.AverageProgress,
+		LikeCount:       post.LikeCount,
+		ShareCount:      post.ShareCount,
+		ReactionCount:   post.ReactionCount,
+		Reactions:       FormatReactionCounts(post),
+	}
+}
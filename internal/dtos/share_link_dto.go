@@ -0,0 +1,29 @@
+package dtos
+
+import (
+	"time"
+
+	"final-project-backend/internal/models"
+)
+
+// ShareLinkClaims is the signed payload of a share link token.
+type ShareLinkClaims struct {
+	ShareLinkID int64 `json:"l"`
+	PostID      int64 `json:"p"`
+}
+
+type CreateShareLinkResponse struct {
+	Token              string    `json:"token"`
+	Url                string    `json:"url"`
+	ExpiresAt          time.Time `json:"expires_at"`
+	RemainingThisMonth int64     `json:"remaining_this_month"`
+}
+
+func FormatShareLink(baseUrl string, shareLink *models.ShareLink, token string, remaining int64) *CreateShareLinkResponse {
+	return &CreateShareLinkResponse{
+		Token:              token,
+		Url:                FormatPostLink(baseUrl, &shareLink.Post) + "?share=" + token,
+		ExpiresAt:          shareLink.ExpiresAt,
+		RemainingThisMonth: remaining,
+	}
+}
@@ -9,10 +9,42 @@ import (
 type GetAllSubscriptionsResponse = []*SubscriptionResponse
 
 type SubscriptionResponse struct {
-	ID    int64  `json:"id"`
-	Name  string `json:"name"`
-	Price int    `json:"price"`
-	Quota int    `json:"quota"`
+	ID      int64  `json:"id"`
+	PlanID  int64  `json:"plan_id"`
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+	Price   int    `json:"price"`
+	Quota   int    `json:"quota"`
+}
+
+type SubscriptionVersionResponse struct {
+	SubscriptionResponse
+	Type         string     `json:"type"`
+	CreatedAt    time.Time  `json:"created_at"`
+	SupersededAt *time.Time `json:"superseded_at"`
+	RetiredAt    *time.Time `json:"retired_at"`
+}
+
+type CreateSubscriptionRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Price int    `json:"price" binding:"min=0"`
+	Quota int    `json:"quota" binding:"required,min=1"`
+	// Type defaults to a paid plan. Trial plans must be free.
+	Type string `json:"type"`
+}
+
+type TrialStatsResponse struct {
+	Granted        int64   `json:"granted"`
+	Active         int64   `json:"active"`
+	Converted      int64   `json:"converted"`
+	Expired        int64   `json:"expired"`
+	ConversionRate float64 `json:"conversion_rate"`
+}
+
+type UpdateSubscriptionRequest struct {
+	Name  *string `json:"name"`
+	Price *int    `json:"price" binding:"omitempty,min=1"`
+	Quota *int    `json:"quota" binding:"omitempty,min=1"`
 }
 
 type AddUserSubscriptionRequest struct {
@@ -30,13 +62,48 @@ type AddUserSubscriptionResponse struct {
 
 func FormatSubscription(s *models.Subscription) *SubscriptionResponse {
 	return &SubscriptionResponse{
-		ID:    s.ID,
-		Name:  s.Name,
-		Price: s.Price,
-		Quota: s.Quota,
+		ID:      s.ID,
+		PlanID:  s.PlanID,
+		Version: s.Version,
+		Name:    s.Name,
+		Price:   s.Price,
+		Quota:   s.Quota,
+	}
+}
+
+func FormatSubscriptionVersion(s *models.Subscription) *SubscriptionVersionResponse {
+	return &SubscriptionVersionResponse{
+		SubscriptionResponse: *FormatSubscription(s),
+		Type:                 string(s.Type),
+		CreatedAt:            s.CreatedAt,
+		SupersededAt:         s.SupersededAt,
+		RetiredAt:            s.RetiredAt,
 	}
 }
 
+func FormatSubscriptionVersions(subscriptions []*models.Subscription) []*SubscriptionVersionResponse {
+	formattedSubscriptions := []*SubscriptionVersionResponse{}
+	for _, subscription := range subscriptions {
+		formattedSubscriptions = append(formattedSubscriptions, FormatSubscriptionVersion(subscription))
+	}
+	return formattedSubscriptions
+}
+
+func FormatTrialStats(stats *models.TrialStats) *TrialStatsResponse {
+	response := &TrialStatsResponse{
+		Granted:   stats.Granted,
+		Active:    stats.Active,
+		Converted: stats.Converted,
+		Expired:   stats.Expired,
+	}
+
+	if stats.Granted > 0 {
+		response.ConversionRate = float64(stats.Converted) / float64(stats.Granted)
+	}
+
+	return response
+}
+
 func FormatSubscriptions(subscriptions []*models.Subscription) []*SubscriptionResponse {
 	formattedSubscriptions := []*SubscriptionResponse{}
 	for _, subscription := range subscriptions {
@@ -16,6 +16,7 @@ type GetUserProfileResponseDTO struct {
 	Fullname     string      `json:"fullname"`
 	Address      string      `json:"address"`
 	ReferralCode string      `json:"referral_code"`
+	Timezone     string      `json:"timezone"`
 }
 
 type HistoryResponseDTO struct {
@@ -24,22 +25,98 @@ type HistoryResponseDTO struct {
 	Post         *PostResponseCompact `json:"post,omitempty"`
 	IsLiked      bool                 `json:"is_liked"`
 	IsShared     bool                 `json:"is_shared"`
+	Reaction     string               `json:"reaction"`
+	Progress     int                  `json:"progress"`
+	CompletedAt  *time.Time           `json:"completed_at"`
 	DeletedAt    gorm.DeletedAt
 	CreatedAt    time.Time
 }
 
-type GetUserHistoriesDTO = []*HistoryResponseDTO
+type GetUserHistoriesDTO struct {
+	Data []*HistoryResponseDTO `json:"data"`
+	PaginationResponse
+}
+
+type HistoriesRequestQuery struct {
+	UserID      int64
+	Search      string
+	CategoryIDs []int64
+	TypeIDs     []int64
+	DateFrom    *time.Time
+	DateTo      *time.Time
+	IsLiked     *bool
+	IsShared    *bool
+	// IsUnfinished keeps only posts that were started but not finished.
+	IsUnfinished bool
+	Sort         constants.PostSort
+	Limit        int
+	Page         int
+}
+
+type ClearHistoriesResponse struct {
+	RemovedCount int64 `json:"removed_count"`
+}
 
 type UserSubscriptionDTO struct {
-	ID             int64     `json:"id"`
-	SubscriptionID int64     `json:"subscription_id"`
-	RemainingQuota int       `json:"remaining_quota"`
-	DateStarted    time.Time `json:"date_started"`
-	DateEnded      time.Time `json:"date_ended"`
+	ID             int64      `json:"id"`
+	SubscriptionID int64      `json:"subscription_id"`
+	RemainingQuota int        `json:"remaining_quota"`
+	DateStarted    time.Time  `json:"date_started"`
+	DateEnded      time.Time  `json:"date_ended"`
+	AutoRenew      bool       `json:"auto_renew"`
+	IsTrial        bool       `json:"is_trial"`
+	GraceEndsAt    *time.Time `json:"grace_ends_at"`
+	Status         string     `json:"status"`
+	PausedAt       *time.Time `json:"paused_at"`
+	PauseEndsAt    *time.Time `json:"pause_ends_at"`
+	PausedDays     int        `json:"paused_days"`
+	CancelledAt    *time.Time `json:"cancelled_at"`
+	ReplacedByID   *int64     `json:"replaced_by_id,omitempty"`
+}
+
+type AdminUserSubscriptionDTO struct {
+	UserSubscriptionDTO
+	UserID           int64  `json:"user_id"`
+	SubscriptionPlan string `json:"subscription_plan"`
+}
+
+type GetAdminUserSubscriptionsDTO struct {
+	Data []*AdminUserSubscriptionDTO `json:"data"`
+	PaginationResponse
+}
+
+type UserSubscriptionsRequestQuery struct {
+	UserID int64
+	Status constants.SubscriptionStatus
+	Limit  int
+	Page   int
+}
+
+type PauseSubscriptionRequest struct {
+	Days int `json:"days" binding:"required"`
+}
+
+type AutoRenewRequest struct {
+	AutoRenew *bool `json:"auto_renew" binding:"required"`
 }
 
 type GetUserSubscriptionsDTO = []*UserSubscriptionDTO
 
+type QuotaLedgerEntryDTO struct {
+	ID                 int64     `json:"id"`
+	UserSubscriptionID int64     `json:"user_subscription_id"`
+	SubscriptionPlan   string    `json:"subscription_plan"`
+	PostID             int64     `json:"post_id"`
+	PostTitle          string    `json:"post_title"`
+	Amount             int       `json:"amount"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type GetQuotaLedgerDTO struct {
+	Data []*QuotaLedgerEntryDTO `json:"data"`
+	PaginationResponse
+}
+
 type GetUserGiftsResponse = []*UserGiftResponse
 
 type UserReferralResponse struct {
@@ -68,6 +145,9 @@ type GetUserVouchersResponse = []*UserVoucherResponse
 type UpdateUserRequest struct {
 	Fullname string `json:"fullname"`
 	Address  string `json:"address"`
+	// Timezone is an IANA name such as Asia/Jakarta, used to split reading
+	// streaks into days.
+	Timezone string `json:"timezone"`
 }
 
 type UpdateUserResponse struct {
@@ -75,6 +155,7 @@ type UpdateUserResponse struct {
 	Email    string `json:"email" gorm:"unique"`
 	Fullname string `json:"fullname"`
 	Address  string `json:"address"`
+	Timezone string `json:"timezone"`
 }
 
 func FormatUpdattedUser(user *models.User) *UpdateUserResponse {
@@ -83,6 +164,7 @@ func FormatUpdattedUser(user *models.User) *UpdateUserResponse {
 		Email:    user.Email,
 		Fullname: user.Fullname,
 		Address:  user.Address,
+		Timezone: user.Timezone,
 	}
 }
 
@@ -135,6 +217,9 @@ func FormatHistory(history *models.History) *HistoryResponseDTO {
 		LastAccessed: history.LastAccessed,
 		IsLiked:      history.IsLiked,
 		IsShared:     history.IsShared,
+		Reaction:     string(history.Reaction),
+		Progress:     history.Progress,
+		CompletedAt:  history.CompletedAt,
 		Post:         FormatPostCompact(&history.Post),
 	}
 }
@@ -155,7 +240,44 @@ func FormatUserSubscription(userSubscription *models.UserSubscriptions) *UserSub
 		RemainingQuota: userSubscription.RemainingQuota,
 		DateStarted:    userSubscription.DateStarted,
 		DateEnded:      userSubscription.DateEnded,
+		AutoRenew:      userSubscription.AutoRenew,
+		IsTrial:        userSubscription.IsTrial,
+		GraceEndsAt:    userSubscription.GraceEndsAt,
+		Status:         string(userSubscription.Status(time.Now())),
+		PausedAt:       userSubscription.PausedAt,
+		PauseEndsAt:    userSubscription.PauseEndsAt,
+		PausedDays:     userSubscription.PausedDays,
+		CancelledAt:    userSubscription.CancelledAt,
+		ReplacedByID:   userSubscription.ReplacedByID,
+	}
+}
+
+func FormatAdminUserSubscriptions(userSubscriptions []*models.UserSubscriptions) []*AdminUserSubscriptionDTO {
+	formattedUserSubscriptions := []*AdminUserSubscriptionDTO{}
+	for _, userSubscription := range userSubscriptions {
+		formattedUserSubscriptions = append(formattedUserSubscriptions, &AdminUserSubscriptionDTO{
+			UserSubscriptionDTO: *FormatUserSubscription(userSubscription),
+			UserID:              userSubscription.UserID,
+			SubscriptionPlan:    userSubscription.Subscription.Name,
+		})
+	}
+	return formattedUserSubscriptions
+}
+
+func FormatQuotaLedgerEntries(entries []*models.QuotaLedger) []*QuotaLedgerEntryDTO {
+	formattedEntries := []*QuotaLedgerEntryDTO{}
+	for _, entry := range entries {
+		formattedEntries = append(formattedEntries, &QuotaLedgerEntryDTO{
+			ID:                 entry.ID,
+			UserSubscriptionID: entry.UserSubscriptionID,
+			SubscriptionPlan:   entry.UserSubscription.Subscription.Name,
+			PostID:             entry.PostID,
+			PostTitle:          entry.Post.Title,
+			Amount:             entry.Amount,
+			CreatedAt:          entry.CreatedAt,
+		})
 	}
+	return formattedEntries
 }
 
 func FormatUserSubscriptions(userSubscriptions []*models.UserSubscriptions) []*UserSubscriptionDTO {
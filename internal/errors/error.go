@@ -28,4 +28,74 @@ var (
 	ErrInvalidVoucher = errors.New("invalid voucher")
 
 	ErrVoucherExpired = errors.New("voucher expired")
+
+	ErrAuthorNotFound = errors.New("author not found")
+
+	ErrAuthorAlreadyExist = errors.New("author already exists")
+
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	ErrInvalidSort = errors.New("invalid sort option")
+
+	ErrCategoryNotFound = errors.New("category not found")
+
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	ErrPostIsFree = errors.New("post is free to read")
+
+	ErrPostNotUnlocked = errors.New("post must be unlocked before sharing")
+
+	ErrShareLinkLimitReached = errors.New("monthly share link limit reached")
+
+	ErrShareLinkExpired = errors.New("share link expired")
+
+	ErrInvalidShareLink = errors.New("invalid share link")
+
+	ErrInvalidReaction = errors.New("invalid reaction")
+
+	ErrHistoryNotFound = errors.New("history not found")
+
+	ErrInvalidAchievementRule = errors.New("invalid achievement rule")
+
+	ErrAchievementRuleNotFound = errors.New("achievement rule not found")
+
+	ErrInvalidTimezone = errors.New("invalid timezone")
+
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+
+	ErrSubscriptionNotAvailable = errors.New("subscription is no longer available")
+
+	ErrInvalidPriceQuote = errors.New("invalid price quote")
+
+	ErrPriceQuoteExpired = errors.New("price quote expired")
+
+	ErrPriceQuoteStale = errors.New("price has changed since the quote, please request a new quote")
+
+	ErrUserSubscriptionNotFound = errors.New("user subscription not found")
+
+	ErrInvalidPauseDuration = errors.New("invalid pause duration")
+
+	ErrSubscriptionNotPausable = errors.New("subscription cannot be paused")
+
+	ErrPauseLimitReached = errors.New("subscription has no pause days left in this period")
+
+	ErrSubscriptionNotPaused = errors.New("subscription is not paused")
+
+	ErrSubscriptionAlreadyCancelled = errors.New("subscription is already cancelled")
+
+	ErrInvalidPlanChange = errors.New("subscription cannot be switched to this plan")
+
+	ErrPlanChangePending = errors.New("a plan change for this subscription is already waiting for payment")
+
+	ErrInvalidSubscriptionPlan = errors.New("paid plans need a price and trial plans must be free")
+
+	ErrTrialAlreadyUsed = errors.New("trial has already been used")
+
+	ErrTrialSubscription = errors.New("trial subscriptions cannot be renewed or paused")
+
+	ErrInvalidEmailVerification = errors.New("invalid email verification link")
+
+	ErrEmailVerificationExpired = errors.New("email verification link expired")
+
+	ErrEmailAlreadyVerified = errors.New("email is already verified")
 )
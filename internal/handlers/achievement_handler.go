@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/dtos"
+	errn "final-project-backend/internal/errors"
+	"final-project-backend/internal/helpers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgconn"
+	"gorm.io/gorm"
+)
+
+func (h *Handler) GetUserAchievements(c *gin.Context) {
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	summary, err := h.services.Achievement.GetUserAchievements(userContext.(dtos.JwtData).ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrUserNotFound.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatUserAchievements(summary))
+}
+
+func (h *Handler) GetAllAchievementRules(c *gin.Context) {
+	rules, err := h.services.Achievement.GetRules()
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatAchievementRules(rules))
+}
+
+func (h *Handler) CreateAchievementRule(c *gin.Context) {
+	var request dtos.AchievementRuleRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	kind, ok := constants.ParseAchievementKind(request.Kind)
+	if !ok {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidAchievementRule.Error())
+		return
+	}
+
+	rule, err := h.services.Achievement.CreateRule(dtos.FormatAchievementRuleRequest(&request, kind))
+	if err != nil {
+		h.sendAchievementRuleError(c, err)
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusCreated, http.StatusText(http.StatusCreated), dtos.FormatAchievementRule(rule))
+}
+
+func (h *Handler) UpdateAchievementRule(c *gin.Context) {
+	var request dtos.AchievementRuleRequest
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	kind, ok := constants.ParseAchievementKind(request.Kind)
+	if !ok {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidAchievementRule.Error())
+		return
+	}
+
+	rule := dtos.FormatAchievementRuleRequest(&request, kind)
+	rule.ID = id
+
+	updatedRule, err := h.services.Achievement.UpdateRule(rule)
+	if err != nil {
+		h.sendAchievementRuleError(c, err)
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatAchievementRule(updatedRule))
+}
+
+func (h *Handler) sendAchievementRuleError(c *gin.Context, err error) {
+	if errors.Is(err, errn.ErrInvalidAchievementRule) {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrAchievementRuleNotFound.Error())
+		return
+	}
+
+	// An unknown category or voucher.
+	if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == errn.ForeignKeyViolation {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidAchievementRule.Error())
+		return
+	}
+
+	helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
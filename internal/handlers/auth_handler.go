@@ -2,8 +2,14 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"net/url"
+	"time"
 
+	"final-project-backend/config"
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/dtos"
 	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/helpers"
@@ -59,6 +65,13 @@ func (h *Handler) Register(c *gin.Context) {
 		RefreshToken: *refreshToken,
 	}
 
+	// The trial only starts once the member verifies their email, so a failed
+	// email does not fail the registration. They can ask for a new one.
+	err = h.sendVerificationEmail(createdUser)
+	if err != nil {
+		log.Printf("failed to send the verification email to user %d: %v", createdUser.ID, err)
+	}
+
 	helpers.SendSuccessResponse(c, http.StatusCreated, http.StatusText(http.StatusCreated), response)
 }
 
@@ -125,3 +138,105 @@ func (h *Handler) RefreshToken(c *gin.Context) {
 
 	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
 }
+
+// VerifyEmail is public, the signed token in the link is what proves the
+// member owns the address. Verifying starts their free trial.
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	var request dtos.VerifyEmailRequestDTO
+	var claims dtos.EmailVerificationClaims
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	err = helpers.VerifyPayload(request.Token, config.InitConfigEmailVerification(), &claims)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidEmailVerification.Error())
+		return
+	}
+
+	if time.Now().After(claims.ExpiresAt) {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrEmailVerificationExpired.Error())
+		return
+	}
+
+	user, err := h.services.Auth.VerifyEmail(claims.UserID, claims.Email)
+	if err != nil {
+		if errors.Is(err, errn.ErrInvalidEmailVerification) || errors.Is(err, errn.ErrEmailAlreadyVerified) {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response := dtos.VerifyEmailResponseDTO{
+		ID:              user.ID,
+		Email:           user.Email,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+	}
+
+	// A member who can't get a trial is still verified.
+	trial, err := h.services.Trial.Grant(user)
+	if err != nil {
+		log.Printf("failed to grant a trial to user %d: %v", user.ID, err)
+	} else {
+		response.Trial = dtos.FormatUserSubscription(trial)
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
+func (h *Handler) ResendVerificationEmail(c *gin.Context) {
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	user, err := h.services.User.GetByID(userContext.(dtos.JwtData).ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	err = h.sendVerificationEmail(user)
+	if err != nil {
+		if errors.Is(err, errn.ErrEmailAlreadyVerified) {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), nil)
+}
+
+func (h *Handler) sendVerificationEmail(user *models.User) error {
+	token, err := helpers.SignPayload(&dtos.EmailVerificationClaims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(constants.EMAIL_VERIFICATION_TTL),
+	}, config.InitConfigEmailVerification())
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", config.InitConfigWeb(), url.QueryEscape(token))
+
+	return h.services.Auth.SendVerificationEmail(user, link)
+}
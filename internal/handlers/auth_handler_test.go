@@ -6,7 +6,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"final-project-backend/internal/dtos"
 	errn "final-project-backend/internal/errors"
@@ -17,6 +19,7 @@ import (
 
 	"github.com/jackc/pgconn"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
@@ -141,6 +144,36 @@ func TestHandler_Register(t *testing.T) {
 						Address:  validRequest.Address,
 						ID:       validResponse.ID,
 					}, &validResponse.AccessToken, &validResponse.RefreshToken, nil)
+				as.On("SendVerificationEmail", mock.MatchedBy(func(u *models.User) bool {
+					return u.ID == validResponse.ID
+				}), mock.MatchedBy(func(link string) bool {
+					return strings.HasPrefix(link, "https://example.com/verify-email?token=")
+				})).Return(nil)
+			},
+			want: helpers.JsonResponse{
+				Code:    http.StatusCreated,
+				Message: http.StatusText(http.StatusCreated),
+				Data:    mockValidDataInInterface,
+				IsError: false,
+			},
+		},
+		{
+			name: "SUCCESS | Verification email fails",
+			fields: fields{
+				authService: mocks.NewIAuthService(t),
+			},
+			args: args{
+				body: helpers.MakeRequestBody(validRequest),
+			},
+			mock: func(as *mocks.IAuthService) {
+				as.On("Register", mock.Anything, (*string)(nil)).
+					Return(&models.User{
+						Fullname: validRequest.Fullname,
+						Email:    validRequest.Email,
+						Address:  validRequest.Address,
+						ID:       validResponse.ID,
+					}, &validResponse.AccessToken, &validResponse.RefreshToken, nil)
+				as.On("SendVerificationEmail", mock.Anything, mock.Anything).Return(mockError)
 			},
 			want: helpers.JsonResponse{
 				Code:    http.StatusCreated,
@@ -152,6 +185,9 @@ func TestHandler_Register(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ENV", "DEPLOY")
+			t.Setenv("WEB_BASE_URL", "https://example.com")
+			t.Setenv("EMAIL_VERIFICATION_SECRET", "secret")
 			h := &Handler{
 				services: &services.Services{
 					Auth: tt.fields.authService,
@@ -313,3 +349,166 @@ func TestHandler_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_VerifyEmail(t *testing.T) {
+	secret := "secret"
+	mockUser := &models.User{
+		ID:              1,
+		Email:           "email@email.com",
+		EmailVerifiedAt: &time.Time{},
+	}
+	validToken, err := helpers.SignPayload(&dtos.EmailVerificationClaims{
+		UserID:    mockUser.ID,
+		Email:     mockUser.Email,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, secret)
+	require.NoError(t, err)
+	expiredToken, err := helpers.SignPayload(&dtos.EmailVerificationClaims{
+		UserID:    mockUser.ID,
+		Email:     mockUser.Email,
+		ExpiresAt: time.Now().Add(-time.Hour),
+	}, secret)
+	require.NoError(t, err)
+	forgedToken, err := helpers.SignPayload(&dtos.EmailVerificationClaims{
+		UserID:    mockUser.ID,
+		Email:     mockUser.Email,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, "other")
+	require.NoError(t, err)
+	mockTrial := &models.UserSubscriptions{
+		UserID:         mockUser.ID,
+		SubscriptionID: 4,
+		RemainingQuota: 3,
+		DateStarted:    time.Now().Truncate(time.Second),
+		DateEnded:      time.Now().Truncate(time.Second).AddDate(0, 0, 7),
+		IsTrial:        true,
+	}
+	verifiedResponse := dtos.VerifyEmailResponseDTO{
+		ID:              mockUser.ID,
+		Email:           mockUser.Email,
+		EmailVerifiedAt: mockUser.EmailVerifiedAt,
+	}
+	mockVerifiedData, err := helpers.StructToMap(verifiedResponse)
+	require.NoError(t, err)
+	verifiedResponse.Trial = dtos.FormatUserSubscription(mockTrial)
+	mockVerifiedDataWithTrial, err := helpers.StructToMap(verifiedResponse)
+	require.NoError(t, err)
+
+	type fields struct {
+		authService  *mocks.IAuthService
+		trialService *mocks.ITrialService
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		body   io.Reader
+		mock   func(fields)
+		want   helpers.JsonResponse
+	}{
+		{
+			name: "ERROR | Forged token",
+			fields: fields{
+				authService:  mocks.NewIAuthService(t),
+				trialService: mocks.NewITrialService(t),
+			},
+			body: helpers.MakeRequestBody(&dtos.VerifyEmailRequestDTO{Token: forgedToken}),
+			mock: func(f fields) {},
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: errn.ErrInvalidEmailVerification.Error(),
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Expired token",
+			fields: fields{
+				authService:  mocks.NewIAuthService(t),
+				trialService: mocks.NewITrialService(t),
+			},
+			body: helpers.MakeRequestBody(&dtos.VerifyEmailRequestDTO{Token: expiredToken}),
+			mock: func(f fields) {},
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: errn.ErrEmailVerificationExpired.Error(),
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Already verified",
+			fields: fields{
+				authService:  mocks.NewIAuthService(t),
+				trialService: mocks.NewITrialService(t),
+			},
+			body: helpers.MakeRequestBody(&dtos.VerifyEmailRequestDTO{Token: validToken}),
+			mock: func(f fields) {
+				f.authService.On("VerifyEmail", mockUser.ID, mockUser.Email).Return(nil, errn.ErrEmailAlreadyVerified)
+			},
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: errn.ErrEmailAlreadyVerified.Error(),
+				IsError: true,
+			},
+		},
+		{
+			name: "SUCCESS | Starts a free trial",
+			fields: fields{
+				authService:  mocks.NewIAuthService(t),
+				trialService: mocks.NewITrialService(t),
+			},
+			body: helpers.MakeRequestBody(&dtos.VerifyEmailRequestDTO{Token: validToken}),
+			mock: func(f fields) {
+				f.authService.On("VerifyEmail", mockUser.ID, mockUser.Email).Return(mockUser, nil)
+				f.trialService.On("Grant", mockUser).Return(mockTrial, nil)
+			},
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockVerifiedDataWithTrial,
+			},
+		},
+		{
+			name: "SUCCESS | Verified without a trial",
+			fields: fields{
+				authService:  mocks.NewIAuthService(t),
+				trialService: mocks.NewITrialService(t),
+			},
+			body: helpers.MakeRequestBody(&dtos.VerifyEmailRequestDTO{Token: validToken}),
+			mock: func(f fields) {
+				f.authService.On("VerifyEmail", mockUser.ID, mockUser.Email).Return(mockUser, nil)
+				f.trialService.On("Grant", mockUser).Return(nil, errn.ErrTrialAlreadyUsed)
+			},
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockVerifiedData,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ENV", "DEPLOY")
+			t.Setenv("EMAIL_VERIFICATION_SECRET", secret)
+			h := &Handler{
+				services: &services.Services{
+					Auth:  tt.fields.authService,
+					Trial: tt.fields.trialService,
+				},
+			}
+
+			tt.mock(tt.fields)
+			r := helpers.SetUpRouter()
+			endpoint := "/verify-email"
+			r.POST(endpoint, h.VerifyEmail)
+			req, _ := http.NewRequest(http.MethodPost, endpoint, tt.body)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helpers.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
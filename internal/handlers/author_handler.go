@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/dtos"
+	errn "final-project-backend/internal/errors"
+	"final-project-backend/internal/helpers"
+	"final-project-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgconn"
+	"gorm.io/gorm"
+)
+
+func (h *Handler) CreateAuthor(c *gin.Context) {
+	var request dtos.CreateAuthorRequest
+	var response dtos.AuthorResponse
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	author := &models.Author{
+		Name:        request.Name,
+		Bio:         request.Bio,
+		AvatarUrl:   request.AvatarUrl,
+		SocialLinks: dtos.FormatAuthorSocialLinksRequest(request.SocialLinks),
+	}
+
+	createdAuthor, err := h.services.Author.Create(author)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == errn.UniqueViolation {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrAuthorAlreadyExist.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response = *dtos.FormatAuthor(createdAuthor)
+
+	helpers.SendSuccessResponse(c, http.StatusCreated, http.StatusText(http.StatusCreated), response)
+}
+
+func (h *Handler) UpdateAuthor(c *gin.Context) {
+	var request dtos.UpdateAuthorRequest
+	var response dtos.AuthorResponse
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	author := &models.Author{
+		ID:        id,
+		Name:      request.Name,
+		Bio:       request.Bio,
+		AvatarUrl: request.AvatarUrl,
+	}
+	if request.SocialLinks != nil {
+		author.SocialLinks = dtos.FormatAuthorSocialLinksRequest(request.SocialLinks)
+	}
+
+	updatedAuthor, err := h.services.Author.Update(author)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrAuthorNotFound.Error())
+			return
+		}
+
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == errn.UniqueViolation {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrAuthorAlreadyExist.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	// Cached post lists embed the author.
+	h.services.Post.InvalidateCaches()
+
+	response = *dtos.FormatAuthor(updatedAuthor)
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
+func (h *Handler) GetAllAuthors(c *gin.Context) {
+	var response dtos.GetAllAuthorsResponse
+
+	authors, err := h.services.Author.GetAll()
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response = dtos.FormatAuthors(authors)
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
+func (h *Handler) GetAuthorByID(c *gin.Context) {
+	var response dtos.GetAuthorByIDResponse
+
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	limit, err1 := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page, err2 := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err1 != nil || err2 != nil || limit < 1 || page < 1 {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	profile, err := h.services.Author.GetProfile(id, userContext.(dtos.JwtData).ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrAuthorNotFound.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	query := &dtos.PostsRequestQuery{
+		AuthorID: id,
+		Sort:     constants.SORT_NEWEST,
+		Limit:    limit,
+		Page:     page,
+	}
+
+	posts, err := h.services.Post.GetAll(query)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	totalRows, totalPages, err := h.services.Post.CountByQuery(query)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response = dtos.GetAuthorByIDResponse{
+		AuthorProfileResponse: *dtos.FormatAuthorProfile(profile),
+		Posts:                 dtos.FormatPostsCompact(posts),
+		PaginationResponse: dtos.PaginationResponse{
+			PerPage:     limit,
+			CurrentPage: page,
+			TotalRows:   totalRows,
+			TotalPages:  totalPages,
+		},
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
+func (h *Handler) FollowAuthor(c *gin.Context) {
+	h.updateAuthorFollow(c, true)
+}
+
+func (h *Handler) UnfollowAuthor(c *gin.Context) {
+	h.updateAuthorFollow(c, false)
+}
+
+func (h *Handler) updateAuthorFollow(c *gin.Context, isFollow bool) {
+	var response dtos.FollowAuthorResponse
+
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	userID := userContext.(dtos.JwtData).ID
+	if isFollow {
+		err = h.services.Author.Follow(userID, id)
+	} else {
+		err = h.services.Author.Unfollow(userID, id)
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrAuthorNotFound.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response = dtos.FollowAuthorResponse{
+		AuthorID:    id,
+		IsFollowing: isFollow,
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
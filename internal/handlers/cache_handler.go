@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"net/http"
+
+	"final-project-backend/internal/dtos"
+	"final-project-backend/internal/helpers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetCacheStats(c *gin.Context) {
+	response := dtos.FormatCacheStats(h.services.Post.GetCacheStats())
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
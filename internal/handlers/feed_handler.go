@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"final-project-backend/config"
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/dtos"
+	errn "final-project-backend/internal/errors"
+	"final-project-backend/internal/helpers"
+	"final-project-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func (h *Handler) GetRSSFeed(c *gin.Context) {
+	baseUrl := config.InitConfigWeb()
+
+	title, posts, ok := h.getFeedPosts(c)
+	if !ok {
+		return
+	}
+
+	selfUrl := baseUrl + c.Request.URL.RequestURI()
+	sendXML(c, "application/rss+xml; charset=utf-8", constants.FEED_CACHE_MAX_AGE, dtos.FormatRSSFeed(baseUrl, selfUrl, title, posts))
+}
+
+func (h *Handler) GetAtomFeed(c *gin.Context) {
+	baseUrl := config.InitConfigWeb()
+
+	title, posts, ok := h.getFeedPosts(c)
+	if !ok {
+		return
+	}
+
+	selfUrl := baseUrl + c.Request.URL.RequestURI()
+	sendXML(c, "application/atom+xml; charset=utf-8", constants.FEED_CACHE_MAX_AGE, dtos.FormatAtomFeed(baseUrl, selfUrl, title, posts))
+}
+
+func (h *Handler) GetSitemapIndex(c *gin.Context) {
+	totalPages, err := h.services.Post.CountSitemapPages()
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	sendXML(c, "application/xml; charset=utf-8", constants.SITEMAP_CACHE_MAX_AGE, dtos.FormatSitemapIndex(config.InitConfigWeb(), totalPages))
+}
+
+func (h *Handler) GetSitemap(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	posts, err := h.services.Post.GetSitemapPage(page)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	if len(posts) == 0 && page > 1 {
+		helpers.SendErrorResponse(c, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	sendXML(c, "application/xml; charset=utf-8", constants.SITEMAP_CACHE_MAX_AGE, dtos.FormatSitemapURLSet(config.InitConfigWeb(), posts))
+}
+
+// getFeedPosts only goes through Post.GetAll, which never selects the post
+// content, so premium bodies cannot leak into a feed.
+func (h *Handler) getFeedPosts(c *gin.Context) (string, []*models.Post, bool) {
+	title := constants.FEED_TITLE
+	query := &dtos.PostsRequestQuery{
+		Sort:  constants.SORT_NEWEST,
+		Limit: constants.FEED_ITEM_LIMIT,
+		Page:  1,
+	}
+
+	if categoryQuery := c.Query("category"); categoryQuery != "" {
+		categoryID, err := strconv.Atoi(categoryQuery)
+		if err != nil {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+			return "", nil, false
+		}
+
+		categories, err := h.services.Post.GetCategories()
+		if err != nil {
+			helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return "", nil, false
+		}
+
+		var category *models.Category
+		for _, cat := range categories {
+			if cat.ID == categoryID {
+				category = cat
+			}
+		}
+
+		if category == nil {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrCategoryNotFound.Error())
+			return "", nil, false
+		}
+
+		title = fmt.Sprintf("%s - %s", title, category.Name)
+		query.CategoryIDs = []int64{int64(categoryID)}
+	}
+
+	if authorQuery := c.Query("author"); authorQuery != "" {
+		authorID, err := strconv.Atoi(authorQuery)
+		if err != nil {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+			return "", nil, false
+		}
+
+		author, err := h.services.Author.GetByID(int64(authorID))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrAuthorNotFound.Error())
+				return "", nil, false
+			}
+
+			helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return "", nil, false
+		}
+
+		title = fmt.Sprintf("%s - %s", title, author.Name)
+		query.AuthorID = int64(authorID)
+	}
+
+	posts, err := h.services.Post.GetAll(query)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return "", nil, false
+	}
+
+	if len(posts) > 0 {
+		c.Header("Last-Modified", posts[0].CreatedAt.UTC().Format(http.TimeFormat))
+	}
+
+	return title, posts, true
+}
+
+func sendXML(c *gin.Context, contentType string, maxAge int, obj interface{}) {
+	body, err := xml.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	c.Data(http.StatusOK, contentType, append([]byte(xml.Header), body...))
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"strings"
 
+	"final-project-backend/config"
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/dtos"
 	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/helpers"
@@ -97,9 +99,8 @@ func (h *Handler) GetWaitingInvoiceByCodeProtected(c *gin.Context) {
 	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
 }
 
-func (h *Handler) CreateInvoice(c *gin.Context) {
-	var request dtos.CreateInvoiceRequest
-	var response dtos.CreateInvoiceResponse
+func (h *Handler) QuoteInvoice(c *gin.Context) {
+	var request dtos.QuoteInvoiceRequest
 
 	err := c.ShouldBindJSON(&request)
 	if err != nil {
@@ -117,36 +118,56 @@ func (h *Handler) CreateInvoice(c *gin.Context) {
 		return
 	}
 
-	user := userContext.(dtos.JwtData)
-	createdInvoice, err := h.services.Invoice.Create(&models.Invoice{
-		UserID:         user.ID,
-		OriginalPrice:  request.OriginalPrice,
-		Total:          request.OriginalPrice,
-		Status:         models.WAITING,
-		SubscriptionID: int64(request.SubscriptionID),
-		VoucherCode:    request.VoucherCode,
-	})
-	if err != nil {
-		if errors.Is(err, errn.ErrInvalidVoucher) {
-			helpers.SendErrorResponse(
-				c,
-				http.StatusBadRequest,
-				err.Error(),
-			)
-			return
-		}
+	policy, ok := constants.ParseQuotaCarryOverPolicy(config.InitConfigPlanChange())
+	if !ok {
+		policy = constants.QUOTA_CARRY_OVER_NONE
+	}
 
-		if errors.Is(err, errn.ErrVoucherExpired) {
-			helpers.SendErrorResponse(
-				c,
-				http.StatusBadRequest,
-				err.Error(),
-			)
-			return
-		}
+	quote, err := h.services.Invoice.Quote(dtos.FormatQuoteInvoiceRequest(&request, userContext.(dtos.JwtData).ID, policy))
+	if err != nil {
+		h.sendInvoicePricingError(c, err)
+		return
+	}
 
+	quoteID, err := helpers.SignPayload(dtos.FormatPriceQuoteClaims(quote), config.InitConfigPriceQuote())
+	if err != nil {
 		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatPriceQuote(quote, quoteID))
+}
 
+func (h *Handler) CreateInvoice(c *gin.Context) {
+	var request dtos.CreateInvoiceRequest
+	var response dtos.CreateInvoiceResponse
+	var claims dtos.PriceQuoteClaims
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	err = helpers.VerifyPayload(request.QuoteID, config.InitConfigPriceQuote(), &claims)
+	if err != nil || claims.UserID != userContext.(dtos.JwtData).ID {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidPriceQuote.Error())
+		return
+	}
+
+	createdInvoice, err := h.services.Invoice.Create(dtos.FormatPriceQuoteFromClaims(&claims))
+	if err != nil {
+		h.sendInvoicePricingError(c, err)
 		return
 	}
 
@@ -155,6 +176,21 @@ func (h *Handler) CreateInvoice(c *gin.Context) {
 	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
 }
 
+func (h *Handler) sendInvoicePricingError(c *gin.Context, err error) {
+	if errors.Is(err, errn.ErrInvalidVoucher) ||
+		errors.Is(err, errn.ErrVoucherExpired) ||
+		errors.Is(err, errn.ErrSubscriptionNotAvailable) ||
+		errors.Is(err, errn.ErrPriceQuoteExpired) ||
+		errors.Is(err, errn.ErrPriceQuoteStale) ||
+		errors.Is(err, errn.ErrInvalidPlanChange) ||
+		errors.Is(err, errn.ErrPlanChangePending) {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
+
 func (h *Handler) UpdateWaitingInvoiceToProcessed(c *gin.Context) {
 	var response dtos.InvoiceResponse
 
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"final-project-backend/internal/dtos"
 	errn "final-project-backend/internal/errors"
@@ -17,6 +18,7 @@ import (
 	"final-project-backend/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
@@ -98,11 +100,30 @@ func TestHandler_GetAllInvoice(t *testing.T) {
 }
 
 func TestHandler_CreateInvoice(t *testing.T) {
+	t.Setenv("ENV", "DEPLOY")
+	t.Setenv("PRICE_QUOTE_SECRET", "quote-secret")
+
+	mockClaims := &dtos.PriceQuoteClaims{
+		UserID:         1,
+		SubscriptionID: 1,
+		OriginalPrice:  90000,
+		Total:          90000,
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+	validQuoteID, err := helpers.SignPayload(mockClaims, "quote-secret")
+	require.NoError(t, err)
+	forgedQuoteID, err := helpers.SignPayload(&dtos.PriceQuoteClaims{UserID: 1, SubscriptionID: 1, OriginalPrice: 1, Total: 1}, "other-secret")
+	require.NoError(t, err)
+	otherUserQuoteID, err := helpers.SignPayload(&dtos.PriceQuoteClaims{UserID: 2, SubscriptionID: 1, OriginalPrice: 90000, Total: 90000}, "quote-secret")
+	require.NoError(t, err)
+
 	invalidRequest := &dtos.CreateInvoiceRequest{}
 	validRequest := &dtos.CreateInvoiceRequest{
-		OriginalPrice:  10000,
-		SubscriptionID: 1,
+		QuoteID: validQuoteID,
 	}
+	matchesQuote := mock.MatchedBy(func(quote *models.PriceQuote) bool {
+		return quote.UserID == 1 && quote.SubscriptionID == 1 && quote.OriginalPrice == 90000 && quote.Total == 90000
+	})
 
 	mockValidDataInInterface, err := helpers.StructToMap(&dtos.CreateInvoiceResponse{})
 	require.NoError(t, err)
@@ -158,6 +179,61 @@ func TestHandler_CreateInvoice(t *testing.T) {
 				IsError: true,
 			},
 		},
+		{
+			name: "ERROR | Tampered quote",
+			fields: fields{
+				invoiceService: mocks.NewIInvoiceService(t),
+			},
+			args: args{
+				body: helpers.MakeRequestBody(&dtos.CreateInvoiceRequest{QuoteID: forgedQuoteID}),
+			},
+			mock: func(s *mocks.IInvoiceService) {
+			},
+			mockUserFromMiddleware: true,
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: errn.ErrInvalidPriceQuote.Error(),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Quote of another member",
+			fields: fields{
+				invoiceService: mocks.NewIInvoiceService(t),
+			},
+			args: args{
+				body: helpers.MakeRequestBody(&dtos.CreateInvoiceRequest{QuoteID: otherUserQuoteID}),
+			},
+			mock: func(s *mocks.IInvoiceService) {
+			},
+			mockUserFromMiddleware: true,
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: errn.ErrInvalidPriceQuote.Error(),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Stale quote",
+			fields: fields{
+				invoiceService: mocks.NewIInvoiceService(t),
+			},
+			args: args{
+				body: helpers.MakeRequestBody(validRequest),
+			},
+			mock: func(s *mocks.IInvoiceService) {
+				s.On("Create", matchesQuote).Return(nil, errn.ErrPriceQuoteStale)
+			},
+			mockUserFromMiddleware: true,
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: errn.ErrPriceQuoteStale.Error(),
+				Data:    nil,
+				IsError: true,
+			},
+		},
 		{
 			name: "ERROR | Error from InvoiceService.Create",
 			fields: fields{
@@ -167,13 +243,7 @@ func TestHandler_CreateInvoice(t *testing.T) {
 				body: helpers.MakeRequestBody(validRequest),
 			},
 			mock: func(s *mocks.IInvoiceService) {
-				s.On("Create", &models.Invoice{
-					UserID:         int64(1),
-					OriginalPrice:  validRequest.OriginalPrice,
-					Total:          validRequest.OriginalPrice,
-					Status:         models.WAITING,
-					SubscriptionID: int64(validRequest.SubscriptionID),
-				}).Return(nil, mockError)
+				s.On("Create", matchesQuote).Return(nil, mockError)
 			},
 			mockUserFromMiddleware: true,
 			want: helpers.JsonResponse{
@@ -192,13 +262,7 @@ func TestHandler_CreateInvoice(t *testing.T) {
 				body: helpers.MakeRequestBody(validRequest),
 			},
 			mock: func(s *mocks.IInvoiceService) {
-				s.On("Create", &models.Invoice{
-					UserID:         int64(1),
-					OriginalPrice:  validRequest.OriginalPrice,
-					Total:          validRequest.OriginalPrice,
-					Status:         models.WAITING,
-					SubscriptionID: int64(validRequest.SubscriptionID),
-				}).Return(&models.Invoice{}, nil)
+				s.On("Create", matchesQuote).Return(&models.Invoice{}, nil)
 			},
 			mockUserFromMiddleware: true,
 			want: helpers.JsonResponse{
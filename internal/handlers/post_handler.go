@@ -5,7 +5,10 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
+	"final-project-backend/config"
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/dtos"
 	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/helpers"
@@ -36,7 +39,8 @@ func (h *Handler) CreatePost(c *gin.Context) {
 		TypeID:       request.TypeID,
 		ImgUrl:       request.ImgUrl,
 		ImgThumbnail: request.ImgUrl,
-		AuthorName:   request.AuthorName,
+		AuthorID:     request.AuthorID,
+		Tags:         dtos.FormatTagsRequest(request.Tags),
 	}
 
 	createdPost, err := h.services.Post.Create(post)
@@ -62,13 +66,15 @@ func (h *Handler) GetAllPosts(c *gin.Context) {
 	var response dtos.GetAllPostResponse
 
 	search := c.DefaultQuery("s", "")
-	sort := c.DefaultQuery("sort", "desc")
-	category, err1 := strconv.Atoi(c.DefaultQuery("category", "0"))
-	postType, err2 := strconv.Atoi(c.DefaultQuery("type", "0"))
+	sort, isValidSort := constants.ParsePostSort(c.DefaultQuery("sort", string(constants.SORT_NEWEST)))
+	categories, err1 := helpers.ParseIDList(c.DefaultQuery("category", ""))
+	postTypes, err2 := helpers.ParseIDList(c.DefaultQuery("type", ""))
 	limit, err3 := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	page, err4 := strconv.Atoi(c.DefaultQuery("page", "1"))
+	author, err5 := strconv.ParseInt(c.DefaultQuery("author", "0"), 10, 64)
+	dateFrom, dateTo, err6 := parseDateRange(c.Query("from"), c.Query("to"))
 
-	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil || err6 != nil {
 		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
 		return
 	}
@@ -78,13 +84,50 @@ func (h *Handler) GetAllPosts(c *gin.Context) {
 		return
 	}
 
+	if !isValidSort {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidSort.Error())
+		return
+	}
+
 	query := &dtos.PostsRequestQuery{
-		CategoryID: int64(category),
-		TypeID:     int64(postType),
-		Search:     search,
-		Sort:       sort,
-		Limit:      limit,
-		Page:       page,
+		CategoryIDs: categories,
+		TypeIDs:     postTypes,
+		AuthorID:    author,
+		DateFrom:    dateFrom,
+		DateTo:      dateTo,
+		Search:      search,
+		Sort:        sort,
+		Limit:       limit,
+		Page:        page,
+	}
+
+	if c.Query("unread") == "true" {
+		userContext, ok := c.Get("user")
+		if !ok {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+			return
+		}
+
+		query.UnreadByUserID = userContext.(dtos.JwtData).ID
+	}
+
+	if cursor, isCursorMode := c.GetQuery("cursor"); isCursorMode {
+		if sort != constants.SORT_NEWEST && sort != constants.SORT_OLDEST {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidSort.Error())
+			return
+		}
+
+		if cursor != "" {
+			decodedCursor, err := helpers.DecodeCursor(cursor)
+			if err != nil {
+				helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidCursor.Error())
+				return
+			}
+			query.Cursor = decodedCursor
+		}
+
+		h.getAllPostsByCursor(c, query)
+		return
 	}
 
 	posts, err := h.services.Post.GetAll(query)
@@ -117,10 +160,100 @@ func (h *Handler) GetAllPosts(c *gin.Context) {
 		},
 	}
 
+	helpers.SendConditionalSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response, postListCacheOptions(query))
+}
+
+func (h *Handler) getAllPostsByCursor(c *gin.Context, query *dtos.PostsRequestQuery) {
+	var response dtos.GetAllPostCursorResponse
+
+	posts, nextCursor, prevCursor, err := h.services.Post.GetAllByCursor(query)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response = dtos.GetAllPostCursorResponse{
+		Data: dtos.FormatPostsCompact(posts),
+		CursorPaginationResponse: dtos.CursorPaginationResponse{
+			PerPage:    query.Limit,
+			NextCursor: nextCursor,
+			PrevCursor: prevCursor,
+		},
+	}
+
+	helpers.SendConditionalSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response, postListCacheOptions(query))
+}
+
+// postListCacheOptions lets shared caches keep listings unless they were
+// narrowed down to what the requesting member has not read.
+func postListCacheOptions(query *dtos.PostsRequestQuery) *helpers.CacheOptions {
+	options := &helpers.CacheOptions{
+		CacheControl: constants.CACHE_CONTROL_PUBLIC_LIST,
+		Vary:         constants.VARY_AUTHORIZATION,
+	}
+
+	if query.UnreadByUserID != 0 {
+		options.CacheControl = constants.CACHE_CONTROL_PRIVATE
+	}
+
+	return options
+}
+
+func (h *Handler) GetFollowingPosts(c *gin.Context) {
+	var response dtos.GetAllPostResponse
+
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	limit, err1 := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page, err2 := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err1 != nil || err2 != nil || limit < 1 || page < 1 {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	query := &dtos.PostsRequestQuery{
+		FollowerID: userContext.(dtos.JwtData).ID,
+		Sort:       constants.SORT_NEWEST,
+		Limit:      limit,
+		Page:       page,
+	}
+
+	posts, err := h.services.Post.GetAll(query)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	totalRows, totalPages, err := h.services.Post.CountByQuery(query)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response = dtos.GetAllPostResponse{
+		Data: dtos.FormatPostsCompact(posts),
+		PaginationResponse: dtos.PaginationResponse{
+			PerPage:     limit,
+			CurrentPage: page,
+			TotalRows:   totalRows,
+			TotalPages:  totalPages,
+		},
+	}
+
 	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
 }
 
-func (h *Handler) GetPostByID(c *gin.Context) {
+func (h *Handler) GetFeed(c *gin.Context) {
+	var response dtos.GetFeedResponse
+
 	userContext, ok := c.Get("user")
 	if !ok {
 		helpers.SendErrorResponse(
@@ -131,6 +264,41 @@ func (h *Handler) GetPostByID(c *gin.Context) {
 		return
 	}
 
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	var cursor *dtos.FeedCursor
+	if encodedCursor := c.Query("cursor"); encodedCursor != "" {
+		cursor, err = helpers.DecodeFeedCursor(encodedCursor)
+		if err != nil {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidCursor.Error())
+			return
+		}
+	}
+
+	items, nextCursor, err := h.services.Post.GetFeed(userContext.(dtos.JwtData).ID, cursor, limit)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response = dtos.GetFeedResponse{
+		Data:    dtos.FormatFeedItems(items),
+		PerPage: limit,
+		HasMore: nextCursor != nil,
+	}
+
+	if nextCursor != nil {
+		response.NextCursor = helpers.EncodeFeedCursor(nextCursor)
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
+func (h *Handler) GetPostByID(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
@@ -150,52 +318,243 @@ func (h *Handler) GetPostByID(c *gin.Context) {
 		return
 	}
 
+	userContext, ok := c.Get("user")
+	if !ok {
+		h.sendPostToGuest(c, post)
+		return
+	}
+
 	user := userContext.(dtos.JwtData)
 
 	if user.Role == string(models.Member) {
 		_, err := h.services.History.GetByUserAndPostID(user.ID, post.ID)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			err = h.services.UserSubscription.ValidateUserQuota(user.ID, post.Type.Quota)
-		}
-
-		if err != nil {
-			if errors.Is(err, errn.ErrNotEnoughQuota) {
-				helpers.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			if post.Type.Quota > 0 {
+				h.sendPostPaywall(c, user.ID, post)
 				return
 			}
 
+			err = nil
+		}
+
+		if err != nil {
 			helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 			return
 		}
 
-		history := &models.History{
-			UserID: userContext.(dtos.JwtData).ID,
-			PostID: post.ID,
-		}
+		h.sendPostWithHistory(c, user.ID, post)
+		return
+	}
 
-		updatedHistory, err := h.services.History.UpdateOrInsert(history)
-		if err != nil {
-			helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	response := dtos.GetPostByIDWithHistoryResponse{
+		PostResponse: *dtos.FormatPost(post),
+	}
 
+	helpers.SendConditionalSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response, &helpers.CacheOptions{
+		CacheControl: constants.CACHE_CONTROL_PRIVATE,
+		Vary:         constants.VARY_AUTHORIZATION,
+		LastModified: post.UpdatedAt,
+	})
+}
+
+func (h *Handler) UnlockPost(c *gin.Context) {
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	post, err := h.services.Post.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrNoPostsFound.Error())
 			return
 		}
 
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	user := userContext.(dtos.JwtData)
+
+	if user.Role != string(models.Member) {
 		response := dtos.GetPostByIDWithHistoryResponse{
-			PostResponse:       *dtos.FormatPost(post),
-			HistoryResponseDTO: *dtos.FormatHistory(updatedHistory),
+			PostResponse: *dtos.FormatPost(post),
 		}
 
-		response.HistoryResponseDTO.Post = nil
-
 		helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
 		return
 	}
 
+	err = h.services.UserSubscription.UnlockPost(user.ID, post.ID, post.Type.Quota)
+	if err != nil {
+		if errors.Is(err, errn.ErrNotEnoughQuota) {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	h.sendPostWithHistory(c, user.ID, post)
+}
+
+func (h *Handler) sendPostWithHistory(c *gin.Context, userID int64, post *models.Post) {
+	history := &models.History{
+		UserID: userID,
+		PostID: post.ID,
+	}
+
+	updatedHistory, err := h.services.History.UpdateOrInsert(history)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+
+		return
+	}
+
+	response := dtos.GetPostByIDWithHistoryResponse{
+		PostResponse:       *dtos.FormatPost(post),
+		HistoryResponseDTO: *dtos.FormatHistory(updatedHistory),
+	}
+
+	response.HistoryResponseDTO.Post = nil
+
+	// last_accessed moves on every view, so it is left out of the ETag.
+	validator := response
+	validator.HistoryResponseDTO.LastAccessed = time.Time{}
+
+	helpers.SendConditionalSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response, &helpers.CacheOptions{
+		CacheControl: constants.CACHE_CONTROL_PRIVATE,
+		Vary:         constants.VARY_AUTHORIZATION,
+		ETagSource:   validator,
+	})
+}
+
+// guestPremiumCacheOptions keeps metered responses out of shared caches since
+// they depend on the visitor's meter cookie.
+var guestPremiumCacheOptions = &helpers.CacheOptions{
+	CacheControl: constants.CACHE_CONTROL_PRIVATE,
+	Vary:         constants.VARY_AUTHORIZATION_COOKIE,
+}
+
+// sendPostToGuest serves free posts as is. Premium posts are metered per
+// device through a signed cookie, and once the monthly allowance is used up
+// the visitor gets the paywall with a prompt to register.
+func (h *Handler) sendPostToGuest(c *gin.Context, post *models.Post) {
 	response := dtos.GetPostByIDWithHistoryResponse{
 		PostResponse: *dtos.FormatPost(post),
 	}
 
-	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+	if post.Type.Quota == 0 {
+		helpers.SendConditionalSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response, &helpers.CacheOptions{
+			CacheControl: constants.CACHE_CONTROL_PUBLIC_POST,
+			Vary:         constants.VARY_AUTHORIZATION,
+			LastModified: post.UpdatedAt,
+		})
+		return
+	}
+
+	meter := readGuestMeter(c)
+
+	isRead := false
+	for _, postID := range meter.PostIDs {
+		if postID == post.ID {
+			isRead = true
+		}
+	}
+
+	if !isRead {
+		if len(meter.PostIDs) >= constants.GUEST_METER_LIMIT {
+			subscriptions, err := h.services.Subscription.GetAll()
+			if err != nil {
+				helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+				return
+			}
+
+			teaser := helpers.TruncateParagraphs(post.Content, constants.PAYWALL_TEASER_PARAGRAPHS)
+			paywall := dtos.FormatPostPaywall(post, teaser, 0, subscriptions)
+			paywall.Paywall.RequiresRegistration = true
+
+			helpers.SendConditionalSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), paywall, guestPremiumCacheOptions)
+			return
+		}
+
+		meter.PostIDs = append(meter.PostIDs, post.ID)
+	}
+
+	err := writeGuestMeter(c, meter)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	c.Header("X-Guest-Reads-Remaining", strconv.Itoa(constants.GUEST_METER_LIMIT-len(meter.PostIDs)))
+
+	helpers.SendConditionalSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response, guestPremiumCacheOptions)
+}
+
+// readGuestMeter starts a fresh meter for a new device, a tampered cookie or
+// a new month.
+func readGuestMeter(c *gin.Context) *dtos.GuestMeter {
+	period := time.Now().Format(constants.GUEST_METER_PERIOD_FORMAT)
+
+	meter := &dtos.GuestMeter{}
+	cookie, err := c.Cookie(constants.GUEST_METER_COOKIE)
+	if err != nil || helpers.VerifyPayload(cookie, config.InitConfigGuestMeter(), meter) != nil {
+		meter = &dtos.GuestMeter{DeviceID: helpers.RandSeq(16)}
+	}
+
+	if meter.Period != period {
+		meter.Period = period
+		meter.PostIDs = []int64{}
+	}
+
+	return meter
+}
+
+func writeGuestMeter(c *gin.Context, meter *dtos.GuestMeter) error {
+	token, err := helpers.SignPayload(meter, config.InitConfigGuestMeter())
+	if err != nil {
+		return err
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(constants.GUEST_METER_COOKIE, token, constants.GUEST_METER_COOKIE_MAX_AGE, "/", "", false, true)
+
+	return nil
+}
+
+func (h *Handler) sendPostPaywall(c *gin.Context, userID int64, post *models.Post) {
+	remainingQuota, err := h.services.UserSubscription.GetRemainingQuota(userID)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	subscriptions, err := h.services.Subscription.GetAll()
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	teaser := helpers.TruncateParagraphs(post.Content, constants.PAYWALL_TEASER_PARAGRAPHS)
+	response := dtos.FormatPostPaywall(post, teaser, remainingQuota, subscriptions)
+
+	helpers.SendConditionalSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response, &helpers.CacheOptions{
+		CacheControl: constants.CACHE_CONTROL_PRIVATE,
+		Vary:         constants.VARY_AUTHORIZATION,
+	})
 }
 
 func (h *Handler) LikePost(c *gin.Context) {
@@ -228,7 +587,13 @@ func (h *Handler) LikePost(c *gin.Context) {
 
 	post, history, err := h.services.Post.Like(id, user.ID, *request.IsLike)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrNoPostsFound.Error())
+			return
+		}
+
 		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
 	}
 
 	response = dtos.GetPostByIDWithHistoryResponse{
@@ -262,7 +627,66 @@ func (h *Handler) SharePost(c *gin.Context) {
 
 	post, history, err := h.services.Post.Share(id, user.ID)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrNoPostsFound.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response = dtos.GetPostByIDWithHistoryResponse{
+		PostResponse:       *dtos.FormatPost(post),
+		HistoryResponseDTO: *dtos.FormatHistory(history),
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
+func (h *Handler) ReactPost(c *gin.Context) {
+	var request dtos.ReactPostRequest
+	var response dtos.GetPostByIDWithHistoryResponse
+
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+	user := userContext.(dtos.JwtData)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+
+		return
+	}
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	reaction, ok := constants.ParseReaction(*request.Reaction)
+	if !ok {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidReaction.Error())
+		return
+	}
+
+	post, history, err := h.services.Post.React(id, user.ID, reaction)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrNoPostsFound.Error())
+			return
+		}
+
 		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
 	}
 
 	response = dtos.GetPostByIDWithHistoryResponse{
@@ -273,6 +697,89 @@ func (h *Handler) SharePost(c *gin.Context) {
 	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
 }
 
+func (h *Handler) UpdateReadingProgress(c *gin.Context) {
+	var request dtos.ReadingProgressRequest
+
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	history, err := h.services.History.UpdateProgress(userContext.(dtos.JwtData).ID, id, *request.Progress)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrHistoryNotFound.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response := dtos.FormatHistory(history)
+	response.Post = nil
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
+func (h *Handler) GetContinueReading(c *gin.Context) {
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	histories, err := h.services.History.GetContinueReading(userContext.(dtos.JwtData).ID)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatHistories(histories))
+}
+
+func (h *Handler) GetPostAnalytics(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	post, stats, err := h.services.Post.GetAnalytics(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrNoPostsFound.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatPostAnalytics(post, stats))
+}
+
 func (h *Handler) DeletePost(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
@@ -296,6 +803,42 @@ func (h *Handler) DeletePost(c *gin.Context) {
 	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), id)
 }
 
+func (h *Handler) CreateType(c *gin.Context) {
+	var request dtos.CreateTypeRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	postType, err := h.services.Post.CreateType(&models.PostType{Name: request.Name, Quota: *request.Quota})
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusCreated, http.StatusText(http.StatusCreated), postType)
+}
+
+func (h *Handler) CreateCategory(c *gin.Context) {
+	var request dtos.CreateCategoryRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	category, err := h.services.Post.CreateCategory(&models.Category{Name: request.Name})
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusCreated, http.StatusText(http.StatusCreated), category)
+}
+
 func (h *Handler) GetAllTypes(c *gin.Context) {
 	var response dtos.GetAllTypesResponse
 
@@ -308,7 +851,9 @@ func (h *Handler) GetAllTypes(c *gin.Context) {
 
 	response = types
 
-	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+	helpers.SendConditionalSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response, &helpers.CacheOptions{
+		CacheControl: constants.CACHE_CONTROL_TAXONOMY,
+	})
 }
 
 func (h *Handler) GetAllCategories(c *gin.Context) {
@@ -323,7 +868,9 @@ func (h *Handler) GetAllCategories(c *gin.Context) {
 
 	response = categories
 
-	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+	helpers.SendConditionalSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response, &helpers.CacheOptions{
+		CacheControl: constants.CACHE_CONTROL_TAXONOMY,
+	})
 }
 
 func (h *Handler) GetRecommendedPost(c *gin.Context) {
@@ -351,6 +898,41 @@ func (h *Handler) GetRecommendedPost(c *gin.Context) {
 	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
 }
 
+func (h *Handler) GetRelatedPosts(c *gin.Context) {
+	var response dtos.GetRelatedPostsResponse
+
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	posts, err := h.services.Post.GetRelatedPosts(postID, userContext.(dtos.JwtData).ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response = dtos.FormatPostsCompact(posts)
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
 func (h *Handler) GetTrendingPosts(c *gin.Context) {
 	var response []*dtos.PostResponseCompact
 
@@ -364,7 +946,21 @@ func (h *Handler) GetTrendingPosts(c *gin.Context) {
 		return
 	}
 
-	posts, err := h.services.Post.GetTrendingPosts(userContext.(dtos.JwtData).ID)
+	query := &dtos.TrendingPostsQuery{
+		UserID:   userContext.(dtos.JwtData).ID,
+		IsGlobal: c.Query("scope") == "global",
+	}
+
+	if category := c.Query("category"); category != "" {
+		categoryID, err := strconv.ParseInt(category, 10, 64)
+		if err != nil {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+			return
+		}
+		query.CategoryID = categoryID
+	}
+
+	posts, err := h.services.Post.GetTrendingPosts(query)
 	if err != nil {
 		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 
@@ -375,3 +971,51 @@ func (h *Handler) GetTrendingPosts(c *gin.Context) {
 
 	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
 }
+
+func (h *Handler) GetAlsoReadPosts(c *gin.Context) {
+	var response []*dtos.PostResponseCompact
+
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	posts, err := h.services.Post.GetAlsoReadPosts(userContext.(dtos.JwtData).ID)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+
+		return
+	}
+
+	response = dtos.FormatPostsCompact(posts)
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
+func parseDateRange(from string, to string) (*time.Time, *time.Time, error) {
+	var dateFrom, dateTo *time.Time
+
+	if from != "" {
+		parsed, err := time.ParseInLocation(constants.POST_DATE_FORMAT, from, time.Local)
+		if err != nil {
+			return nil, nil, err
+		}
+		dateFrom = &parsed
+	}
+
+	if to != "" {
+		parsed, err := time.ParseInLocation(constants.POST_DATE_FORMAT, to, time.Local)
+		if err != nil {
+			return nil, nil, err
+		}
+		parsed = parsed.AddDate(0, 0, 1)
+		dateTo = &parsed
+	}
+
+	return dateFrom, dateTo, nil
+}
@@ -6,9 +6,12 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/dtos"
 	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/helpers"
@@ -31,7 +34,8 @@ func TestHandler_CreatePost(t *testing.T) {
 		CategoryID: 1,
 		TypeID:     1,
 		ImgUrl:     "ImgUrl",
-		AuthorName: "AuthorName",
+		AuthorID:   1,
+		Tags:       []string{" Go ", "go", "news"},
 	}
 	formattedPostDto := &models.Post{
 		Title:        validRequest.Title,
@@ -42,7 +46,8 @@ func TestHandler_CreatePost(t *testing.T) {
 		TypeID:       validRequest.TypeID,
 		ImgUrl:       validRequest.ImgUrl,
 		ImgThumbnail: validRequest.ImgUrl,
-		AuthorName:   validRequest.AuthorName,
+		AuthorID:     validRequest.AuthorID,
+		Tags:         []*models.Tag{{Name: "go"}, {Name: "news"}},
 	}
 	mockPostReturn := &models.Post{ID: 1}
 
@@ -171,7 +176,7 @@ func TestHandler_CreatePost(t *testing.T) {
 
 func TestHandler_GetAllPosts(t *testing.T) {
 	endpoint := "/posts"
-	defaultQuery := &dtos.PostsRequestQuery{Sort: "desc", Limit: 10, Page: 1}
+	defaultQuery := &dtos.PostsRequestQuery{Sort: constants.SORT_NEWEST, Limit: 10, Page: 1}
 	mockPosts := []*models.Post{}
 	validResponse := &dtos.GetAllPostResponse{
 		Data: dtos.FormatPostsCompact(mockPosts),
@@ -287,6 +292,65 @@ func TestHandler_GetAllPosts(t *testing.T) {
 				IsError: true,
 			},
 		},
+		{
+			name:  "ERROR | Error from sort query not in whitelist",
+			query: "?sort=title",
+			fields: fields{
+				postService: *mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {
+			},
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: errn.ErrInvalidSort.Error(),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name:  "ERROR | Error from invalid date range",
+			query: "?from=yesterday",
+			fields: fields{
+				postService: *mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {
+			},
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name:  "SUCCESS | With multiple categories, types, author, date range and sort",
+			query: "?sort=most_read&category=1,2&type=3&author=4&from=2022-10-01&to=2022-10-31",
+			fields: fields{
+				postService: *mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {
+				dateFrom := time.Date(2022, 10, 1, 0, 0, 0, 0, time.Local)
+				dateTo := time.Date(2022, 11, 1, 0, 0, 0, 0, time.Local)
+				filteredQuery := &dtos.PostsRequestQuery{
+					CategoryIDs: []int64{1, 2},
+					TypeIDs:     []int64{3},
+					AuthorID:    4,
+					DateFrom:    &dateFrom,
+					DateTo:      &dateTo,
+					Sort:        constants.SORT_MOST_READ,
+					Limit:       10,
+					Page:        1,
+				}
+				s.On("GetAll", filteredQuery).Return(mockPosts, nil)
+				s.On("CountByQuery", filteredQuery).Return(int64(1), int64(1), nil)
+			},
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockValidDataInInterface,
+				IsError: false,
+			},
+		},
 		{
 			name: "ERROR | Error from PostService.GetAll (posts not found)",
 			fields: fields{
@@ -383,8 +447,16 @@ func TestHandler_GetAllPosts(t *testing.T) {
 
 func TestHandler_GetPostByID(t *testing.T) {
 	mockPost := &models.Post{}
+	mockPremiumPost := &models.Post{
+		ID:      2,
+		Content: "<p>First</p><p>Second</p><p>Third</p>",
+		Type:    models.PostType{Quota: 2},
+	}
+	mockSubscriptions := []*models.Subscription{{ID: 1, Quota: 1}, {ID: 2, Quota: 5}}
+	mockPaywallInInterface, err := helpers.StructToMap(dtos.FormatPostPaywall(mockPremiumPost, "<p>First</p><p>Second</p>", 0, mockSubscriptions))
+	require.NoError(t, err)
 	mockJwtUserID := 1
-	validResponse := &dtos.GetPostByIDWithHistoryResponse{}
+	validResponse := &dtos.GetPostByIDWithHistoryResponse{PostResponse: dtos.PostResponse{Tags: []string{}, Reactions: dtos.FormatReactionCounts(&models.Post{})}}
 	mockValidDataInInterface, err := helpers.StructToMap(validResponse)
 	require.NoError(t, err)
 	mockError := fmt.Errorf("error")
@@ -395,21 +467,22 @@ func TestHandler_GetPostByID(t *testing.T) {
 		postService             mocks.IPostService
 		historyService          mocks.IHistoryService
 		userSubscriptionService mocks.IUserSubscriptionService
+		subscriptionService     mocks.ISubscriptionService
 	}
 	tests := []struct {
 		name                     string
 		fields                   fields
-		mock                     func(*mocks.IPostService, *mocks.IHistoryService, *mocks.IUserSubscriptionService)
+		mock                     func(*mocks.IPostService, *mocks.IHistoryService, *mocks.IUserSubscriptionService, *mocks.ISubscriptionService)
 		mockParamsFromMiddleware bool
 		mockedUserRole           string
 		want                     helpers.JsonResponse
 	}{
 		{
-			name: "ERROR | Error from Context.Get",
+			name: "ERROR | Error from invalid params as guest",
 			fields: fields{
 				postService: *mocks.NewIPostService(t),
 			},
-			mock: func(as *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+			mock: func(as *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService, ss *mocks.ISubscriptionService) {
 			},
 			mockParamsFromMiddleware: false,
 			mockedUserRole:           "",
@@ -425,7 +498,7 @@ func TestHandler_GetPostByID(t *testing.T) {
 			fields: fields{
 				postService: *mocks.NewIPostService(t),
 			},
-			mock: func(as *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+			mock: func(as *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService, ss *mocks.ISubscriptionService) {
 			},
 			mockParamsFromMiddleware: false,
 			mockedUserRole:           "member",
@@ -441,7 +514,7 @@ func TestHandler_GetPostByID(t *testing.T) {
 			fields: fields{
 				postService: *mocks.NewIPostService(t),
 			},
-			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService, ss *mocks.ISubscriptionService) {
 				s.On("GetByID", mockID).Return(nil, gorm.ErrRecordNotFound)
 			},
 			mockParamsFromMiddleware: true,
@@ -458,7 +531,7 @@ func TestHandler_GetPostByID(t *testing.T) {
 			fields: fields{
 				postService: *mocks.NewIPostService(t),
 			},
-			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService, ss *mocks.ISubscriptionService) {
 				s.On("GetByID", mockID).Return(nil, mockError)
 			},
 			mockParamsFromMiddleware: true,
@@ -475,7 +548,7 @@ func TestHandler_GetPostByID(t *testing.T) {
 			fields: fields{
 				postService: *mocks.NewIPostService(t),
 			},
-			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService, ss *mocks.ISubscriptionService) {
 				s.On("GetByID", mockID).Return(mockPost, nil)
 				h.On("GetByUserAndPostID", int64(mockJwtUserID), mockPost.ID).Return(nil, mockError)
 			},
@@ -489,33 +562,34 @@ func TestHandler_GetPostByID(t *testing.T) {
 			},
 		},
 		{
-			name: "ERROR | when user is member, Error NotEnoughQuota from UserSubscription.ValidateUserQuota",
+			name: "ERROR | when post is locked, Error from UserSubscription.GetRemainingQuota",
 			fields: fields{
 				postService: *mocks.NewIPostService(t),
 			},
-			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
-				s.On("GetByID", mockID).Return(mockPost, nil)
-				h.On("GetByUserAndPostID", int64(mockJwtUserID), mockPost.ID).Return(nil, gorm.ErrRecordNotFound)
-				us.On("ValidateUserQuota", int64(mockJwtUserID), mockPost.Type.Quota).Return(errn.ErrNotEnoughQuota)
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService, ss *mocks.ISubscriptionService) {
+				s.On("GetByID", mockID).Return(mockPremiumPost, nil)
+				h.On("GetByUserAndPostID", int64(mockJwtUserID), mockPremiumPost.ID).Return(nil, gorm.ErrRecordNotFound)
+				us.On("GetRemainingQuota", int64(mockJwtUserID)).Return(0, mockError)
 			},
 			mockParamsFromMiddleware: true,
 			mockedUserRole:           "member",
 			want: helpers.JsonResponse{
-				Code:    http.StatusBadRequest,
-				Message: errn.ErrNotEnoughQuota.Error(),
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
 				Data:    nil,
 				IsError: true,
 			},
 		},
 		{
-			name: "ERROR | when user is member, Error others from UserSubscription.ValidateUserQuota",
+			name: "ERROR | when post is locked, Error from Subscription.GetAll",
 			fields: fields{
 				postService: *mocks.NewIPostService(t),
 			},
-			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
-				s.On("GetByID", mockID).Return(mockPost, nil)
-				h.On("GetByUserAndPostID", int64(mockJwtUserID), mockPost.ID).Return(nil, gorm.ErrRecordNotFound)
-				us.On("ValidateUserQuota", int64(mockJwtUserID), mockPost.Type.Quota).Return(mockError)
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService, ss *mocks.ISubscriptionService) {
+				s.On("GetByID", mockID).Return(mockPremiumPost, nil)
+				h.On("GetByUserAndPostID", int64(mockJwtUserID), mockPremiumPost.ID).Return(nil, gorm.ErrRecordNotFound)
+				us.On("GetRemainingQuota", int64(mockJwtUserID)).Return(0, nil)
+				ss.On("GetAll").Return(nil, mockError)
 			},
 			mockParamsFromMiddleware: true,
 			mockedUserRole:           "member",
@@ -526,15 +600,34 @@ func TestHandler_GetPostByID(t *testing.T) {
 				IsError: true,
 			},
 		},
+		{
+			name: "SUCCESS | when post is locked, returns paywall without spending quota",
+			fields: fields{
+				postService: *mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService, ss *mocks.ISubscriptionService) {
+				s.On("GetByID", mockID).Return(mockPremiumPost, nil)
+				h.On("GetByUserAndPostID", int64(mockJwtUserID), mockPremiumPost.ID).Return(nil, gorm.ErrRecordNotFound)
+				us.On("GetRemainingQuota", int64(mockJwtUserID)).Return(0, nil)
+				ss.On("GetAll").Return(mockSubscriptions, nil)
+			},
+			mockParamsFromMiddleware: true,
+			mockedUserRole:           "member",
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockPaywallInInterface,
+				IsError: false,
+			},
+		},
 		{
 			name: "ERROR | Error from History.UpdateOrInsert when user is member",
 			fields: fields{
 				postService: *mocks.NewIPostService(t),
 			},
-			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService, ss *mocks.ISubscriptionService) {
 				s.On("GetByID", mockID).Return(mockPost, nil)
 				h.On("GetByUserAndPostID", int64(mockJwtUserID), mockPost.ID).Return(nil, gorm.ErrRecordNotFound)
-				us.On("ValidateUserQuota", int64(mockJwtUserID), mockPost.Type.Quota).Return(nil)
 				h.On("UpdateOrInsert", &models.History{
 					UserID: int64(mockJwtUserID),
 					PostID: mockPost.ID,
@@ -554,10 +647,9 @@ func TestHandler_GetPostByID(t *testing.T) {
 			fields: fields{
 				postService: *mocks.NewIPostService(t),
 			},
-			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService, ss *mocks.ISubscriptionService) {
 				s.On("GetByID", mockID).Return(mockPost, nil)
 				h.On("GetByUserAndPostID", int64(mockJwtUserID), mockPost.ID).Return(nil, gorm.ErrRecordNotFound)
-				us.On("ValidateUserQuota", int64(mockJwtUserID), mockPost.Type.Quota).Return(nil, nil)
 				h.On("UpdateOrInsert", &models.History{
 					UserID: int64(mockJwtUserID),
 					PostID: mockPost.ID,
@@ -580,10 +672,11 @@ func TestHandler_GetPostByID(t *testing.T) {
 					Post:             &tt.fields.postService,
 					History:          &tt.fields.historyService,
 					UserSubscription: &tt.fields.userSubscriptionService,
+					Subscription:     &tt.fields.subscriptionService,
 				},
 			}
 
-			tt.mock(&tt.fields.postService, &tt.fields.historyService, &tt.fields.userSubscriptionService)
+			tt.mock(&tt.fields.postService, &tt.fields.historyService, &tt.fields.userSubscriptionService, &tt.fields.subscriptionService)
 			r := helpers.SetUpRouter()
 			endpoint := "/transactions/1"
 
@@ -615,108 +708,196 @@ func TestHandler_GetPostByID(t *testing.T) {
 	}
 }
 
-func TestHandler_DeletePost(t *testing.T) {
-	mockError := fmt.Errorf("error")
-	mockIDParams := "1"
-	var mockID int64 = 1
+func TestHandler_GetPostByID_Guest(t *testing.T) {
+	t.Setenv("ENV", "DEPLOY")
+	t.Setenv("GUEST_METER_SECRET", "secret")
+
+	mockFreePost := &models.Post{ID: 1}
+	mockPremiumPost := &models.Post{ID: 2, Content: "<p>First</p><p>Second</p><p>Third</p>", Type: models.PostType{Quota: 2}}
+	mockSubscriptions := []*models.Subscription{{ID: 1, Quota: 5}}
+	period := time.Now().Format(constants.GUEST_METER_PERIOD_FORMAT)
+
+	freeDataInInterface, err := helpers.StructToMap(&dtos.GetPostByIDWithHistoryResponse{PostResponse: *dtos.FormatPost(mockFreePost)})
+	require.NoError(t, err)
+	premiumDataInInterface, err := helpers.StructToMap(&dtos.GetPostByIDWithHistoryResponse{PostResponse: *dtos.FormatPost(mockPremiumPost)})
+	require.NoError(t, err)
+	paywall := dtos.FormatPostPaywall(mockPremiumPost, "<p>First</p><p>Second</p>", 0, mockSubscriptions)
+	paywall.Paywall.RequiresRegistration = true
+	paywallDataInInterface, err := helpers.StructToMap(paywall)
+	require.NoError(t, err)
+
+	exhaustedMeter, err := helpers.SignPayload(&dtos.GuestMeter{DeviceID: "device", Period: period, PostIDs: []int64{3, 4, 5}}, "secret")
+	require.NoError(t, err)
+	readMeter, err := helpers.SignPayload(&dtos.GuestMeter{DeviceID: "device", Period: period, PostIDs: []int64{3, 4, 2}}, "secret")
+	require.NoError(t, err)
+	lastMonthMeter, err := helpers.SignPayload(&dtos.GuestMeter{DeviceID: "device", Period: "2000-01", PostIDs: []int64{3, 4, 5}}, "secret")
+	require.NoError(t, err)
 
 	type fields struct {
-		postService mocks.IPostService
+		postService         *mocks.IPostService
+		subscriptionService *mocks.ISubscriptionService
 	}
 	tests := []struct {
-		name                     string
-		fields                   fields
-		mock                     func(*mocks.IPostService)
-		mockParamsFromMiddleware bool
-		want                     helpers.JsonResponse
+		name               string
+		fields             fields
+		mock               func(*mocks.IPostService, *mocks.ISubscriptionService)
+		postID             int64
+		cookie             string
+		wantReadsRemaining string
+		want               helpers.JsonResponse
 	}{
 		{
-			name: "ERROR | Error from invalid params",
+			name: "SUCCESS | Free post is not metered",
 			fields: fields{
-				postService: *mocks.NewIPostService(t),
+				postService:         mocks.NewIPostService(t),
+				subscriptionService: mocks.NewISubscriptionService(t),
 			},
-			mock: func(as *mocks.IPostService) {
+			mock: func(s *mocks.IPostService, ss *mocks.ISubscriptionService) {
+				s.On("GetByID", mockFreePost.ID).Return(mockFreePost, nil)
 			},
-			mockParamsFromMiddleware: false,
+			postID: mockFreePost.ID,
 			want: helpers.JsonResponse{
-				Code:    http.StatusBadRequest,
-				Message: http.StatusText(http.StatusBadRequest),
-				Data:    nil,
-				IsError: true,
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    freeDataInInterface,
+				IsError: false,
 			},
 		},
 		{
-			name: "ERROR | Error from Post.Delete (no posts found)",
+			name: "SUCCESS | Premium post without cookie starts a meter",
 			fields: fields{
-				postService: *mocks.NewIPostService(t),
+				postService:         mocks.NewIPostService(t),
+				subscriptionService: mocks.NewISubscriptionService(t),
 			},
-			mock: func(s *mocks.IPostService) {
-				s.On("Delete", mockID).Return(gorm.ErrRecordNotFound)
+			mock: func(s *mocks.IPostService, ss *mocks.ISubscriptionService) {
+				s.On("GetByID", mockPremiumPost.ID).Return(mockPremiumPost, nil)
 			},
-			mockParamsFromMiddleware: true,
+			postID:             mockPremiumPost.ID,
+			wantReadsRemaining: strconv.Itoa(constants.GUEST_METER_LIMIT - 1),
 			want: helpers.JsonResponse{
-				Code:    http.StatusNotFound,
-				Message: errn.ErrNoPostsFound.Error(),
-				Data:    nil,
-				IsError: true,
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    premiumDataInInterface,
+				IsError: false,
 			},
 		},
 		{
-			name: "ERROR | Error from Post.Delete (other errors)",
+			name: "SUCCESS | Tampered cookie starts a new meter",
 			fields: fields{
-				postService: *mocks.NewIPostService(t),
+				postService:         mocks.NewIPostService(t),
+				subscriptionService: mocks.NewISubscriptionService(t),
 			},
-			mock: func(s *mocks.IPostService) {
-				s.On("Delete", mockID).Return(mockError)
+			mock: func(s *mocks.IPostService, ss *mocks.ISubscriptionService) {
+				s.On("GetByID", mockPremiumPost.ID).Return(mockPremiumPost, nil)
 			},
-			mockParamsFromMiddleware: true,
+			postID:             mockPremiumPost.ID,
+			cookie:             exhaustedMeter + "x",
+			wantReadsRemaining: strconv.Itoa(constants.GUEST_METER_LIMIT - 1),
 			want: helpers.JsonResponse{
-				Code:    http.StatusInternalServerError,
-				Message: mockError.Error(),
-				Data:    nil,
-				IsError: true,
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    premiumDataInInterface,
+				IsError: false,
 			},
 		},
 		{
-			name: "SUCCESS",
+			name: "SUCCESS | Meter resets in a new month",
 			fields: fields{
-				postService: *mocks.NewIPostService(t),
+				postService:         mocks.NewIPostService(t),
+				subscriptionService: mocks.NewISubscriptionService(t),
 			},
-			mock: func(s *mocks.IPostService) {
-				s.On("Delete", mockID).Return(nil)
+			mock: func(s *mocks.IPostService, ss *mocks.ISubscriptionService) {
+				s.On("GetByID", mockPremiumPost.ID).Return(mockPremiumPost, nil)
 			},
-			mockParamsFromMiddleware: true,
+			postID:             mockPremiumPost.ID,
+			cookie:             lastMonthMeter,
+			wantReadsRemaining: strconv.Itoa(constants.GUEST_METER_LIMIT - 1),
 			want: helpers.JsonResponse{
 				Code:    http.StatusOK,
 				Message: http.StatusText(http.StatusOK),
-				Data:    float64(mockID),
+				Data:    premiumDataInInterface,
+				IsError: false,
+			},
+		},
+		{
+			name: "SUCCESS | Re-reading a metered post does not count again",
+			fields: fields{
+				postService:         mocks.NewIPostService(t),
+				subscriptionService: mocks.NewISubscriptionService(t),
+			},
+			mock: func(s *mocks.IPostService, ss *mocks.ISubscriptionService) {
+				s.On("GetByID", mockPremiumPost.ID).Return(mockPremiumPost, nil)
+			},
+			postID:             mockPremiumPost.ID,
+			cookie:             readMeter,
+			wantReadsRemaining: "0",
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    premiumDataInInterface,
+				IsError: false,
+			},
+		},
+		{
+			name: "SUCCESS | Exhausted meter returns paywall with registration prompt",
+			fields: fields{
+				postService:         mocks.NewIPostService(t),
+				subscriptionService: mocks.NewISubscriptionService(t),
+			},
+			mock: func(s *mocks.IPostService, ss *mocks.ISubscriptionService) {
+				s.On("GetByID", mockPremiumPost.ID).Return(mockPremiumPost, nil)
+				ss.On("GetAll").Return(mockSubscriptions, nil)
+			},
+			postID: mockPremiumPost.ID,
+			cookie: exhaustedMeter,
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    paywallDataInInterface,
 				IsError: false,
 			},
 		},
+		{
+			name: "ERROR | Error from Subscription.GetAll when meter is exhausted",
+			fields: fields{
+				postService:         mocks.NewIPostService(t),
+				subscriptionService: mocks.NewISubscriptionService(t),
+			},
+			mock: func(s *mocks.IPostService, ss *mocks.ISubscriptionService) {
+				s.On("GetByID", mockPremiumPost.ID).Return(mockPremiumPost, nil)
+				ss.On("GetAll").Return(nil, fmt.Errorf("error"))
+			},
+			postID: mockPremiumPost.ID,
+			cookie: exhaustedMeter,
+			want: helpers.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
+				Data:    nil,
+				IsError: true,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			h := &Handler{
 				services: &services.Services{
-					Post: &tt.fields.postService,
+					Post:         tt.fields.postService,
+					Subscription: tt.fields.subscriptionService,
 				},
 			}
 
-			tt.mock(&tt.fields.postService)
+			tt.mock(tt.fields.postService, tt.fields.subscriptionService)
 			r := helpers.SetUpRouter()
-			endpoint := "/posts/1"
-
-			if tt.mockParamsFromMiddleware {
-				r.DELETE(endpoint, helpers.MiddlewareMockID(mockIDParams), h.DeletePost)
-			} else {
-				r.DELETE(endpoint, h.DeletePost)
-			}
+			r.GET("/posts/:id", h.GetPostByID)
 
 			req, _ := http.NewRequest(
-				http.MethodDelete,
-				endpoint,
+				http.MethodGet,
+				fmt.Sprintf("/posts/%d", tt.postID),
 				nil,
 			)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: constants.GUEST_METER_COOKIE, Value: tt.cookie})
+			}
 			w := httptest.NewRecorder()
 			r.ServeHTTP(w, req)
 
@@ -726,31 +907,111 @@ func TestHandler_DeletePost(t *testing.T) {
 
 			assert.Equal(t, tt.want.Code, w.Code)
 			assert.Equal(t, tt.want, response)
+			assert.Equal(t, tt.wantReadsRemaining, w.Header().Get("X-Guest-Reads-Remaining"))
 		})
 	}
 }
 
-func TestHandler_GetAllTypes(t *testing.T) {
+func TestHandler_UnlockPost(t *testing.T) {
+	mockPost := &models.Post{ID: 1, Type: models.PostType{Quota: 2}}
+	mockHistory := &models.History{UserID: 1, PostID: 1}
+	validResponse := &dtos.GetPostByIDWithHistoryResponse{
+		PostResponse:       *dtos.FormatPost(mockPost),
+		HistoryResponseDTO: *dtos.FormatHistory(mockHistory),
+	}
+	validResponse.HistoryResponseDTO.Post = nil
+	mockValidDataInInterface, err := helpers.StructToMap(validResponse)
+	require.NoError(t, err)
+	mockAdminDataInInterface, err := helpers.StructToMap(&dtos.GetPostByIDWithHistoryResponse{PostResponse: *dtos.FormatPost(mockPost)})
+	require.NoError(t, err)
 	mockError := fmt.Errorf("error")
-	mockTypes := []*models.PostType{}
+	mockJwtUserID := 1
+	mockIDParams := "1"
+	var mockID int64 = 1
 
 	type fields struct {
-		postService mocks.IPostService
+		postService             *mocks.IPostService
+		historyService          *mocks.IHistoryService
+		userSubscriptionService *mocks.IUserSubscriptionService
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		mock   func(*mocks.IPostService)
-		want   helpers.JsonResponse
+		name                     string
+		fields                   fields
+		mock                     func(*mocks.IPostService, *mocks.IHistoryService, *mocks.IUserSubscriptionService)
+		mockParamsFromMiddleware bool
+		mockedUserRole           string
+		want                     helpers.JsonResponse
 	}{
 		{
-			name: "ERROR | Error from Post.GetTypes",
+			name: "ERROR | Error from invalid params",
 			fields: fields{
-				postService: *mocks.NewIPostService(t),
+				postService:             mocks.NewIPostService(t),
+				historyService:          mocks.NewIHistoryService(t),
+				userSubscriptionService: mocks.NewIUserSubscriptionService(t),
 			},
-			mock: func(s *mocks.IPostService) {
-				s.On("GetTypes").Return(nil, mockError)
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+			},
+			mockParamsFromMiddleware: false,
+			mockedUserRole:           "member",
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Error from Post.GetByID (no posts found)",
+			fields: fields{
+				postService:             mocks.NewIPostService(t),
+				historyService:          mocks.NewIHistoryService(t),
+				userSubscriptionService: mocks.NewIUserSubscriptionService(t),
+			},
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+				s.On("GetByID", mockID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			mockParamsFromMiddleware: true,
+			mockedUserRole:           "member",
+			want: helpers.JsonResponse{
+				Code:    http.StatusNotFound,
+				Message: errn.ErrNoPostsFound.Error(),
+				Data:    nil,
+				IsError: true,
 			},
+		},
+		{
+			name: "ERROR | Error NotEnoughQuota from UserSubscription.UnlockPost",
+			fields: fields{
+				postService:             mocks.NewIPostService(t),
+				historyService:          mocks.NewIHistoryService(t),
+				userSubscriptionService: mocks.NewIUserSubscriptionService(t),
+			},
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+				s.On("GetByID", mockID).Return(mockPost, nil)
+				us.On("UnlockPost", int64(mockJwtUserID), mockPost.ID, mockPost.Type.Quota).Return(errn.ErrNotEnoughQuota)
+			},
+			mockParamsFromMiddleware: true,
+			mockedUserRole:           "member",
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: errn.ErrNotEnoughQuota.Error(),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Error others from UserSubscription.UnlockPost",
+			fields: fields{
+				postService:             mocks.NewIPostService(t),
+				historyService:          mocks.NewIHistoryService(t),
+				userSubscriptionService: mocks.NewIUserSubscriptionService(t),
+			},
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+				s.On("GetByID", mockID).Return(mockPost, nil)
+				us.On("UnlockPost", int64(mockJwtUserID), mockPost.ID, mockPost.Type.Quota).Return(mockError)
+			},
+			mockParamsFromMiddleware: true,
+			mockedUserRole:           "member",
 			want: helpers.JsonResponse{
 				Code:    http.StatusInternalServerError,
 				Message: http.StatusText(http.StatusInternalServerError),
@@ -759,12 +1020,481 @@ func TestHandler_GetAllTypes(t *testing.T) {
 			},
 		},
 		{
-			name: "SUCCESS",
+			name: "SUCCESS | Unlocks post",
 			fields: fields{
-				postService: *mocks.NewIPostService(t),
+				postService:             mocks.NewIPostService(t),
+				historyService:          mocks.NewIHistoryService(t),
+				userSubscriptionService: mocks.NewIUserSubscriptionService(t),
 			},
-			mock: func(s *mocks.IPostService) {
-				s.On("GetTypes").Return(mockTypes, nil)
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+				s.On("GetByID", mockID).Return(mockPost, nil)
+				us.On("UnlockPost", int64(mockJwtUserID), mockPost.ID, mockPost.Type.Quota).Return(nil)
+				h.On("UpdateOrInsert", mockHistory).Return(mockHistory, nil)
+			},
+			mockParamsFromMiddleware: true,
+			mockedUserRole:           "member",
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockValidDataInInterface,
+				IsError: false,
+			},
+		},
+		{
+			name: "SUCCESS | Admin",
+			fields: fields{
+				postService:             mocks.NewIPostService(t),
+				historyService:          mocks.NewIHistoryService(t),
+				userSubscriptionService: mocks.NewIUserSubscriptionService(t),
+			},
+			mock: func(s *mocks.IPostService, h *mocks.IHistoryService, us *mocks.IUserSubscriptionService) {
+				s.On("GetByID", mockID).Return(mockPost, nil)
+			},
+			mockParamsFromMiddleware: true,
+			mockedUserRole:           "admin",
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockAdminDataInInterface,
+				IsError: false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				services: &services.Services{
+					Post:             tt.fields.postService,
+					History:          tt.fields.historyService,
+					UserSubscription: tt.fields.userSubscriptionService,
+				},
+			}
+
+			tt.mock(tt.fields.postService, tt.fields.historyService, tt.fields.userSubscriptionService)
+			r := helpers.SetUpRouter()
+			endpoint := "/posts/1/unlock"
+
+			if !tt.mockParamsFromMiddleware {
+				r.POST(endpoint, helpers.MiddlewareMockUser(dtos.JwtData{Role: tt.mockedUserRole, ID: int64(mockJwtUserID)}), h.UnlockPost)
+			} else {
+				r.POST(endpoint, helpers.MiddlewareMockID(mockIDParams), helpers.MiddlewareMockUser(dtos.JwtData{Role: tt.mockedUserRole, ID: int64(mockJwtUserID)}), h.UnlockPost)
+			}
+
+			req, _ := http.NewRequest(
+				http.MethodPost,
+				endpoint,
+				nil,
+			)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helpers.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_ReactPost(t *testing.T) {
+	mockPost := &models.Post{ID: 1, FunnyCount: 1, ReactionCount: 1}
+	mockHistory := &models.History{UserID: 1, PostID: 1, Reaction: constants.REACTION_FUNNY}
+	validResponse := &dtos.GetPostByIDWithHistoryResponse{
+		PostResponse:       *dtos.FormatPost(mockPost),
+		HistoryResponseDTO: *dtos.FormatHistory(mockHistory),
+	}
+	mockValidDataInInterface, err := helpers.StructToMap(validResponse)
+	require.NoError(t, err)
+	mockError := fmt.Errorf("error")
+	mockJwtUserID := 1
+	mockIDParams := "1"
+	var mockID int64 = 1
+
+	type fields struct {
+		postService *mocks.IPostService
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		mock   func(*mocks.IPostService)
+		body   string
+		want   helpers.JsonResponse
+	}{
+		{
+			name: "ERROR | Error from invalid body",
+			fields: fields{
+				postService: mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {},
+			body: `{}`,
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Error from unknown reaction",
+			fields: fields{
+				postService: mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {},
+			body: `{"reaction": "love"}`,
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: errn.ErrInvalidReaction.Error(),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Error from Post.React (post not read)",
+			fields: fields{
+				postService: mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {
+				s.On("React", mockID, int64(mockJwtUserID), constants.REACTION_FUNNY).Return(nil, nil, gorm.ErrRecordNotFound)
+			},
+			body: `{"reaction": "funny"}`,
+			want: helpers.JsonResponse{
+				Code:    http.StatusNotFound,
+				Message: errn.ErrNoPostsFound.Error(),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Error others from Post.React",
+			fields: fields{
+				postService: mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {
+				s.On("React", mockID, int64(mockJwtUserID), constants.REACTION_FUNNY).Return(nil, nil, mockError)
+			},
+			body: `{"reaction": "funny"}`,
+			want: helpers.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				postService: mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {
+				s.On("React", mockID, int64(mockJwtUserID), constants.REACTION_FUNNY).Return(mockPost, mockHistory, nil)
+			},
+			body: `{"reaction": "funny"}`,
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockValidDataInInterface,
+				IsError: false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				services: &services.Services{
+					Post: tt.fields.postService,
+				},
+			}
+
+			tt.mock(tt.fields.postService)
+			r := helpers.SetUpRouter()
+			endpoint := "/posts/react/1"
+			r.PATCH(endpoint, helpers.MiddlewareMockID(mockIDParams), helpers.MiddlewareMockUser(dtos.JwtData{Role: "member", ID: int64(mockJwtUserID)}), h.ReactPost)
+
+			req, _ := http.NewRequest(
+				http.MethodPatch,
+				endpoint,
+				strings.NewReader(tt.body),
+			)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helpers.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_UpdateReadingProgress(t *testing.T) {
+	mockHistory := &models.History{UserID: 1, PostID: 1, Progress: 40}
+	validResponse := dtos.FormatHistory(mockHistory)
+	validResponse.Post = nil
+	mockValidDataInInterface, err := helpers.StructToMap(validResponse)
+	require.NoError(t, err)
+	mockError := fmt.Errorf("error")
+	mockJwtUserID := 1
+	mockIDParams := "1"
+	var mockID int64 = 1
+
+	type fields struct {
+		historyService *mocks.IHistoryService
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		mock   func(*mocks.IHistoryService)
+		body   string
+		want   helpers.JsonResponse
+	}{
+		{
+			name: "ERROR | Error from progress out of range",
+			fields: fields{
+				historyService: mocks.NewIHistoryService(t),
+			},
+			mock: func(s *mocks.IHistoryService) {},
+			body: `{"progress": 101}`,
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Error from History.UpdateProgress (post not read)",
+			fields: fields{
+				historyService: mocks.NewIHistoryService(t),
+			},
+			mock: func(s *mocks.IHistoryService) {
+				s.On("UpdateProgress", int64(mockJwtUserID), mockID, 40).Return(nil, gorm.ErrRecordNotFound)
+			},
+			body: `{"progress": 40}`,
+			want: helpers.JsonResponse{
+				Code:    http.StatusNotFound,
+				Message: errn.ErrHistoryNotFound.Error(),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Error others from History.UpdateProgress",
+			fields: fields{
+				historyService: mocks.NewIHistoryService(t),
+			},
+			mock: func(s *mocks.IHistoryService) {
+				s.On("UpdateProgress", int64(mockJwtUserID), mockID, 40).Return(nil, mockError)
+			},
+			body: `{"progress": 40}`,
+			want: helpers.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				historyService: mocks.NewIHistoryService(t),
+			},
+			mock: func(s *mocks.IHistoryService) {
+				s.On("UpdateProgress", int64(mockJwtUserID), mockID, 40).Return(mockHistory, nil)
+			},
+			body: `{"progress": 40}`,
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockValidDataInInterface,
+				IsError: false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				services: &services.Services{
+					History: tt.fields.historyService,
+				},
+			}
+
+			tt.mock(tt.fields.historyService)
+			r := helpers.SetUpRouter()
+			endpoint := "/posts/1/progress"
+			r.PATCH(endpoint, helpers.MiddlewareMockID(mockIDParams), helpers.MiddlewareMockUser(dtos.JwtData{Role: "member", ID: int64(mockJwtUserID)}), h.UpdateReadingProgress)
+
+			req, _ := http.NewRequest(
+				http.MethodPatch,
+				endpoint,
+				strings.NewReader(tt.body),
+			)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helpers.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_DeletePost(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockIDParams := "1"
+	var mockID int64 = 1
+
+	type fields struct {
+		postService mocks.IPostService
+	}
+	tests := []struct {
+		name                     string
+		fields                   fields
+		mock                     func(*mocks.IPostService)
+		mockParamsFromMiddleware bool
+		want                     helpers.JsonResponse
+	}{
+		{
+			name: "ERROR | Error from invalid params",
+			fields: fields{
+				postService: *mocks.NewIPostService(t),
+			},
+			mock: func(as *mocks.IPostService) {
+			},
+			mockParamsFromMiddleware: false,
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: http.StatusText(http.StatusBadRequest),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Error from Post.Delete (no posts found)",
+			fields: fields{
+				postService: *mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {
+				s.On("Delete", mockID).Return(gorm.ErrRecordNotFound)
+			},
+			mockParamsFromMiddleware: true,
+			want: helpers.JsonResponse{
+				Code:    http.StatusNotFound,
+				Message: errn.ErrNoPostsFound.Error(),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Error from Post.Delete (other errors)",
+			fields: fields{
+				postService: *mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {
+				s.On("Delete", mockID).Return(mockError)
+			},
+			mockParamsFromMiddleware: true,
+			want: helpers.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: mockError.Error(),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				postService: *mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {
+				s.On("Delete", mockID).Return(nil)
+			},
+			mockParamsFromMiddleware: true,
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    float64(mockID),
+				IsError: false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				services: &services.Services{
+					Post: &tt.fields.postService,
+				},
+			}
+
+			tt.mock(&tt.fields.postService)
+			r := helpers.SetUpRouter()
+			endpoint := "/posts/1"
+
+			if tt.mockParamsFromMiddleware {
+				r.DELETE(endpoint, helpers.MiddlewareMockID(mockIDParams), h.DeletePost)
+			} else {
+				r.DELETE(endpoint, h.DeletePost)
+			}
+
+			req, _ := http.NewRequest(
+				http.MethodDelete,
+				endpoint,
+				nil,
+			)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helpers.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+		})
+	}
+}
+
+func TestHandler_GetAllTypes(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockTypes := []*models.PostType{}
+
+	type fields struct {
+		postService mocks.IPostService
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		mock   func(*mocks.IPostService)
+		want   helpers.JsonResponse
+	}{
+		{
+			name: "ERROR | Error from Post.GetTypes",
+			fields: fields{
+				postService: *mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {
+				s.On("GetTypes").Return(nil, mockError)
+			},
+			want: helpers.JsonResponse{
+				Code:    http.StatusInternalServerError,
+				Message: http.StatusText(http.StatusInternalServerError),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				postService: *mocks.NewIPostService(t),
+			},
+			mock: func(s *mocks.IPostService) {
+				s.On("GetTypes").Return(mockTypes, nil)
 			},
 			want: helpers.JsonResponse{
 				Code:    http.StatusOK,
@@ -806,6 +1536,67 @@ func TestHandler_GetAllTypes(t *testing.T) {
 	}
 }
 
+func TestHandler_GetAllTypes_Conditional(t *testing.T) {
+	mockTypes := []*models.PostType{{ID: 1, Name: "Free"}}
+
+	postService := mocks.NewIPostService(t)
+	postService.On("GetTypes").Return(mockTypes, nil)
+	h := &Handler{
+		services: &services.Services{
+			Post: postService,
+		},
+	}
+
+	r := helpers.SetUpRouter()
+	endpoint := "/types"
+	r.GET(endpoint, h.GetAllTypes)
+
+	req, _ := http.NewRequest(http.MethodGet, endpoint, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	etag := w.Header().Get("ETag")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, "private, max-age=300", w.Header().Get("Cache-Control"))
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        int
+	}{
+		{
+			name:        "SUCCESS | Matching ETag is not modified",
+			ifNoneMatch: etag,
+			want:        http.StatusNotModified,
+		},
+		{
+			name:        "SUCCESS | Matching ETag in a list is not modified",
+			ifNoneMatch: `"other", ` + etag,
+			want:        http.StatusNotModified,
+		},
+		{
+			name:        "SUCCESS | Stale ETag gets the full response",
+			ifNoneMatch: `W/"stale"`,
+			want:        http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, endpoint, nil)
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Code)
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			if tt.want == http.StatusNotModified {
+				assert.Empty(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
 func TestHandler_GetAllCategories(t *testing.T) {
 	mockError := fmt.Errorf("error")
 	mockCategories := []*models.Category{}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"final-project-backend/config"
+	"final-project-backend/internal/dtos"
+	errn "final-project-backend/internal/errors"
+	"final-project-backend/internal/helpers"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func (h *Handler) CreateShareLink(c *gin.Context) {
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	postID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	shareLink, remaining, err := h.services.ShareLink.Create(userContext.(dtos.JwtData).ID, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrNoPostsFound.Error())
+			return
+		}
+
+		if errors.Is(err, errn.ErrPostIsFree) || errors.Is(err, errn.ErrPostNotUnlocked) || errors.Is(err, errn.ErrShareLinkLimitReached) {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	token, err := helpers.SignPayload(&dtos.ShareLinkClaims{
+		ShareLinkID: shareLink.ID,
+		PostID:      shareLink.PostID,
+	}, config.InitConfigShareLink())
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response := dtos.FormatShareLink(config.InitConfigWeb(), shareLink, token, remaining)
+
+	helpers.SendSuccessResponse(c, http.StatusCreated, http.StatusText(http.StatusCreated), response)
+}
+
+// RedeemShareLink is public. Signed-in visitors are told apart by user and
+// anonymous ones by the device ID of their guest meter cookie, so reloading
+// the page does not count as another redemption.
+func (h *Handler) RedeemShareLink(c *gin.Context) {
+	var claims dtos.ShareLinkClaims
+
+	err := helpers.VerifyPayload(c.Param("token"), config.InitConfigShareLink(), &claims)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidShareLink.Error())
+		return
+	}
+
+	var visitorKey string
+	var visitorUserID int64
+
+	userContext, ok := c.Get("user")
+	if ok {
+		visitorUserID = userContext.(dtos.JwtData).ID
+		visitorKey = fmt.Sprintf("user:%d", visitorUserID)
+	} else {
+		meter := readGuestMeter(c)
+
+		err = writeGuestMeter(c, meter)
+		if err != nil {
+			helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		visitorKey = "device:" + meter.DeviceID
+	}
+
+	post, err := h.services.ShareLink.Redeem(claims.ShareLinkID, claims.PostID, visitorKey, visitorUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrInvalidShareLink.Error())
+			return
+		}
+
+		if errors.Is(err, errn.ErrInvalidShareLink) || errors.Is(err, errn.ErrShareLinkExpired) {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response := dtos.GetPostByIDWithHistoryResponse{
+		PostResponse: *dtos.FormatPost(post),
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
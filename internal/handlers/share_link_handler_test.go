@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/dtos"
+	errn "final-project-backend/internal/errors"
+	"final-project-backend/internal/helpers"
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/services"
+	"final-project-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_RedeemShareLink(t *testing.T) {
+	t.Setenv("ENV", "DEPLOY")
+	t.Setenv("GUEST_METER_SECRET", "meter-secret")
+	t.Setenv("SHARE_LINK_SECRET", "share-secret")
+
+	mockPost := &models.Post{ID: 2, Content: "Content", Type: models.PostType{Quota: 2}}
+	validToken, err := helpers.SignPayload(&dtos.ShareLinkClaims{ShareLinkID: 1, PostID: mockPost.ID}, "share-secret")
+	require.NoError(t, err)
+	forgedToken, err := helpers.SignPayload(&dtos.ShareLinkClaims{ShareLinkID: 1, PostID: mockPost.ID}, "other-secret")
+	require.NoError(t, err)
+	mockValidDataInInterface, err := helpers.StructToMap(&dtos.GetPostByIDWithHistoryResponse{PostResponse: *dtos.FormatPost(mockPost)})
+	require.NoError(t, err)
+
+	type fields struct {
+		shareLinkService *mocks.IShareLinkService
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		token          string
+		mockedUser     *dtos.JwtData
+		mock           func(*mocks.IShareLinkService)
+		wantMeterSaved bool
+		want           helpers.JsonResponse
+	}{
+		{
+			name: "ERROR | Forged token",
+			fields: fields{
+				shareLinkService: mocks.NewIShareLinkService(t),
+			},
+			token: forgedToken,
+			mock: func(s *mocks.IShareLinkService) {
+			},
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: errn.ErrInvalidShareLink.Error(),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "ERROR | Expired share link",
+			fields: fields{
+				shareLinkService: mocks.NewIShareLinkService(t),
+			},
+			token: validToken,
+			mock: func(s *mocks.IShareLinkService) {
+				s.On("Redeem", int64(1), mockPost.ID, mock.AnythingOfType("string"), int64(0)).Return(nil, errn.ErrShareLinkExpired)
+			},
+			wantMeterSaved: true,
+			want: helpers.JsonResponse{
+				Code:    http.StatusBadRequest,
+				Message: errn.ErrShareLinkExpired.Error(),
+				Data:    nil,
+				IsError: true,
+			},
+		},
+		{
+			name: "SUCCESS | Signed in visitor",
+			fields: fields{
+				shareLinkService: mocks.NewIShareLinkService(t),
+			},
+			token:      validToken,
+			mockedUser: &dtos.JwtData{ID: 5, Role: "member"},
+			mock: func(s *mocks.IShareLinkService) {
+				s.On("Redeem", int64(1), mockPost.ID, "user:5", int64(5)).Return(mockPost, nil)
+			},
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockValidDataInInterface,
+				IsError: false,
+			},
+		},
+		{
+			name: "SUCCESS | Anonymous visitor",
+			fields: fields{
+				shareLinkService: mocks.NewIShareLinkService(t),
+			},
+			token: validToken,
+			mock: func(s *mocks.IShareLinkService) {
+				s.On("Redeem", int64(1), mockPost.ID, mock.AnythingOfType("string"), int64(0)).Return(mockPost, nil)
+			},
+			wantMeterSaved: true,
+			want: helpers.JsonResponse{
+				Code:    http.StatusOK,
+				Message: http.StatusText(http.StatusOK),
+				Data:    mockValidDataInInterface,
+				IsError: false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				services: &services.Services{
+					ShareLink: tt.fields.shareLinkService,
+				},
+			}
+
+			tt.mock(tt.fields.shareLinkService)
+			r := helpers.SetUpRouter()
+
+			if tt.mockedUser == nil {
+				r.GET("/share-links/:token", h.RedeemShareLink)
+			} else {
+				r.GET("/share-links/:token", helpers.MiddlewareMockUser(*tt.mockedUser), h.RedeemShareLink)
+			}
+
+			req, _ := http.NewRequest(
+				http.MethodGet,
+				"/share-links/"+tt.token,
+				nil,
+			)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var response helpers.JsonResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want.Code, w.Code)
+			assert.Equal(t, tt.want, response)
+
+			isMeterSaved := false
+			for _, cookie := range w.Result().Cookies() {
+				if cookie.Name == constants.GUEST_METER_COOKIE {
+					isMeterSaved = true
+				}
+			}
+			assert.Equal(t, tt.wantMeterSaved, isMeterSaved)
+		})
+	}
+}
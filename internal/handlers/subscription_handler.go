@@ -1,12 +1,18 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/dtos"
+	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/helpers"
+	"final-project-backend/internal/models"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func (h *Handler) GetAllSubscriptions(c *gin.Context) {
@@ -36,6 +42,11 @@ func (h *Handler) AddUserSubscription(c *gin.Context) {
 
 	userSubscription, err := h.services.UserSubscription.AddUserSubscription(request.UserID, request.SubscriptionID)
 	if err != nil {
+		if errors.Is(err, errn.ErrSubscriptionNotAvailable) {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 
 		return
@@ -51,3 +62,162 @@ func (h *Handler) AddUserSubscription(c *gin.Context) {
 
 	helpers.SendSuccessResponse(c, http.StatusCreated, http.StatusText(http.StatusCreated), response)
 }
+
+func (h *Handler) GetAllSubscriptionVersions(c *gin.Context) {
+	subscriptions, err := h.services.Subscription.GetAllVersions()
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatSubscriptionVersions(subscriptions))
+}
+
+func (h *Handler) CreateSubscription(c *gin.Context) {
+	var request dtos.CreateSubscriptionRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	planType := constants.PLAN_PAID
+	if request.Type != "" {
+		var ok bool
+		planType, ok = constants.ParsePlanType(request.Type)
+		if !ok {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+			return
+		}
+	}
+
+	subscription, err := h.services.Subscription.Create(&models.Subscription{
+		Name:  request.Name,
+		Price: request.Price,
+		Quota: request.Quota,
+		Type:  planType,
+	})
+	if err != nil {
+		if errors.Is(err, errn.ErrInvalidSubscriptionPlan) {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusCreated, http.StatusText(http.StatusCreated), dtos.FormatSubscriptionVersion(subscription))
+}
+
+func (h *Handler) UpdateSubscription(c *gin.Context) {
+	var request dtos.UpdateSubscriptionRequest
+
+	planID, err := strconv.ParseInt(c.Param("plan_id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	subscription, err := h.services.Subscription.Update(planID, &request)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrSubscriptionNotFound.Error())
+			return
+		}
+
+		if errors.Is(err, errn.ErrSubscriptionNotAvailable) || errors.Is(err, errn.ErrInvalidSubscriptionPlan) {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatSubscriptionVersion(subscription))
+}
+
+func (h *Handler) RetireSubscription(c *gin.Context) {
+	planID, err := strconv.ParseInt(c.Param("plan_id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	err = h.services.Subscription.Retire(planID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrSubscriptionNotFound.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), nil)
+}
+
+func (h *Handler) GetAllUserSubscriptions(c *gin.Context) {
+	var response dtos.GetAdminUserSubscriptionsDTO
+
+	limit, err1 := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page, err2 := strconv.Atoi(c.DefaultQuery("page", "1"))
+	userID, err3 := strconv.ParseInt(c.DefaultQuery("user_id", "0"), 10, 64)
+
+	if err1 != nil || err2 != nil || err3 != nil || limit < 1 || page < 1 {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	query := &dtos.UserSubscriptionsRequestQuery{
+		UserID: userID,
+		Limit:  limit,
+		Page:   page,
+	}
+
+	if status := c.Query("status"); status != "" {
+		parsedStatus, ok := constants.ParseSubscriptionStatus(status)
+		if !ok {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+			return
+		}
+		query.Status = parsedStatus
+	}
+
+	userSubscriptions, totalRows, totalPages, err := h.services.UserSubscription.GetByQuery(query)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response = dtos.GetAdminUserSubscriptionsDTO{
+		Data: dtos.FormatAdminUserSubscriptions(userSubscriptions),
+		PaginationResponse: dtos.PaginationResponse{
+			PerPage:     limit,
+			CurrentPage: page,
+			TotalRows:   totalRows,
+			TotalPages:  totalPages,
+		},
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
+func (h *Handler) GetTrialStats(c *gin.Context) {
+	stats, err := h.services.Trial.GetStats()
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatTrialStats(stats))
+}
@@ -3,7 +3,9 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/dtos"
 	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/helpers"
@@ -45,6 +47,7 @@ func (h *Handler) GetUserProfile(c *gin.Context) {
 		Fullname:     user.Fullname,
 		Address:      user.Address,
 		ReferralCode: user.ReferralCode,
+		Timezone:     user.Timezone,
 	}
 
 	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
@@ -63,13 +66,120 @@ func (h *Handler) GetUserHistories(c *gin.Context) {
 		return
 	}
 
-	histories, err := h.services.History.GetByUserID(userContext.(dtos.JwtData).ID)
+	sort, isValidSort := constants.ParsePostSort(c.DefaultQuery("sort", string(constants.SORT_NEWEST)))
+	categories, err1 := helpers.ParseIDList(c.DefaultQuery("category", ""))
+	postTypes, err2 := helpers.ParseIDList(c.DefaultQuery("type", ""))
+	limit, err3 := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page, err4 := strconv.Atoi(c.DefaultQuery("page", "1"))
+	dateFrom, dateTo, err5 := parseDateRange(c.Query("from"), c.Query("to"))
+	isLiked, err6 := parseOptionalBool(c.Query("liked"))
+	isShared, err7 := parseOptionalBool(c.Query("shared"))
+
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil || err5 != nil || err6 != nil || err7 != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	if limit < 1 || page < 1 {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	if !isValidSort || (sort != constants.SORT_NEWEST && sort != constants.SORT_OLDEST) {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidSort.Error())
+		return
+	}
+
+	query := &dtos.HistoriesRequestQuery{
+		UserID:      userContext.(dtos.JwtData).ID,
+		Search:      c.DefaultQuery("s", ""),
+		CategoryIDs: categories,
+		TypeIDs:     postTypes,
+		DateFrom:    dateFrom,
+		DateTo:      dateTo,
+		IsLiked:     isLiked,
+		IsShared:    isShared,
+		Sort:        sort,
+		Limit:       limit,
+		Page:        page,
+	}
+
+	histories, err := h.services.History.GetByQuery(query)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	totalRows, totalPages, err := h.services.History.CountByQuery(query)
 	if err != nil {
 		helpers.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	response = dtos.FormatHistories(histories)
+	response = dtos.GetUserHistoriesDTO{
+		Data: dtos.FormatHistories(histories),
+		PaginationResponse: dtos.PaginationResponse{
+			PerPage:     limit,
+			CurrentPage: page,
+			TotalRows:   totalRows,
+			TotalPages:  totalPages,
+		},
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
+func (h *Handler) RemoveUserHistory(c *gin.Context) {
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	postID, err := strconv.ParseInt(c.Param("post_id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	err = h.services.History.Remove(userContext.(dtos.JwtData).ID, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrHistoryNotFound.Error())
+			return
+		}
+
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), nil)
+}
+
+func (h *Handler) ClearUserHistories(c *gin.Context) {
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	removedCount, err := h.services.History.Clear(userContext.(dtos.JwtData).ID)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := dtos.ClearHistoriesResponse{
+		RemovedCount: removedCount,
+	}
 
 	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
 }
@@ -98,6 +208,225 @@ func (h *Handler) GetUserSubscriptions(c *gin.Context) {
 	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
 }
 
+func (h *Handler) SetSubscriptionAutoRenew(c *gin.Context) {
+	var request dtos.AutoRenewRequest
+
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	userSubscription, err := h.services.UserSubscription.SetAutoRenew(userContext.(dtos.JwtData).ID, id, *request.AutoRenew)
+	if err != nil {
+		h.sendUserSubscriptionError(c, err)
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatUserSubscription(userSubscription))
+}
+
+func (h *Handler) CancelUserSubscription(c *gin.Context) {
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	userSubscription, err := h.services.UserSubscription.Cancel(userContext.(dtos.JwtData).ID, id)
+	if err != nil {
+		h.sendUserSubscriptionError(c, err)
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatUserSubscription(userSubscription))
+}
+
+func (h *Handler) PauseUserSubscription(c *gin.Context) {
+	var request dtos.PauseSubscriptionRequest
+
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	userSubscription, err := h.services.UserSubscription.Pause(userContext.(dtos.JwtData).ID, id, request.Days)
+	if err != nil {
+		h.sendUserSubscriptionError(c, err)
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatUserSubscription(userSubscription))
+}
+
+func (h *Handler) ResumeUserSubscription(c *gin.Context) {
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	userSubscription, err := h.services.UserSubscription.Resume(userContext.(dtos.JwtData).ID, id)
+	if err != nil {
+		h.sendUserSubscriptionError(c, err)
+		return
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), dtos.FormatUserSubscription(userSubscription))
+}
+
+func (h *Handler) sendUserSubscriptionError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		helpers.SendErrorResponse(c, http.StatusNotFound, errn.ErrUserSubscriptionNotFound.Error())
+		return
+	}
+
+	if errors.Is(err, errn.ErrInvalidPauseDuration) ||
+		errors.Is(err, errn.ErrSubscriptionNotPausable) ||
+		errors.Is(err, errn.ErrPauseLimitReached) ||
+		errors.Is(err, errn.ErrSubscriptionNotPaused) ||
+		errors.Is(err, errn.ErrSubscriptionAlreadyCancelled) ||
+		errors.Is(err, errn.ErrTrialSubscription) {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
+
+func (h *Handler) GetUserNotifications(c *gin.Context) {
+	var response dtos.GetNotificationsResponse
+
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	limit, err1 := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page, err2 := strconv.Atoi(c.DefaultQuery("page", "1"))
+
+	if err1 != nil || err2 != nil || limit < 1 || page < 1 {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	notifications, totalRows, totalPages, err := h.services.Notification.GetByUserID(userContext.(dtos.JwtData).ID, limit, page)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response = dtos.GetNotificationsResponse{
+		Data: dtos.FormatNotifications(notifications),
+		PaginationResponse: dtos.PaginationResponse{
+			PerPage:     limit,
+			CurrentPage: page,
+			TotalRows:   totalRows,
+			TotalPages:  totalPages,
+		},
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
+func (h *Handler) GetUserQuotaLedger(c *gin.Context) {
+	var response dtos.GetQuotaLedgerDTO
+
+	userContext, ok := c.Get("user")
+	if !ok {
+		helpers.SendErrorResponse(
+			c,
+			http.StatusBadRequest,
+			http.StatusText(http.StatusBadRequest),
+		)
+		return
+	}
+
+	limit, err1 := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page, err2 := strconv.Atoi(c.DefaultQuery("page", "1"))
+
+	if err1 != nil || err2 != nil || limit < 1 || page < 1 {
+		helpers.SendErrorResponse(c, http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+		return
+	}
+
+	entries, totalRows, totalPages, err := h.services.UserSubscription.GetQuotaLedger(userContext.(dtos.JwtData).ID, limit, page)
+	if err != nil {
+		helpers.SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	response = dtos.GetQuotaLedgerDTO{
+		Data: dtos.FormatQuotaLedgerEntries(entries),
+		PaginationResponse: dtos.PaginationResponse{
+			PerPage:     limit,
+			CurrentPage: page,
+			TotalRows:   totalRows,
+			TotalPages:  totalPages,
+		},
+	}
+
+	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
+}
+
 func (h *Handler) GetUserInvoices(c *gin.Context) {
 	var response dtos.GetAllInvoicesResponse
 
@@ -218,10 +547,18 @@ func (h *Handler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	if request.Timezone != "" {
+		if _, err := helpers.LoadTimezone(request.Timezone); err != nil {
+			helpers.SendErrorResponse(c, http.StatusBadRequest, errn.ErrInvalidTimezone.Error())
+			return
+		}
+	}
+
 	user := &models.User{
 		ID:       userContext.(dtos.JwtData).ID,
 		Fullname: request.Fullname,
 		Address:  request.Address,
+		Timezone: request.Timezone,
 	}
 
 	updatedUser, err := h.services.User.Update(user)
@@ -234,3 +571,16 @@ func (h *Handler) UpdateUser(c *gin.Context) {
 
 	helpers.SendSuccessResponse(c, http.StatusOK, http.StatusText(http.StatusOK), response)
 }
+
+func parseOptionalBool(s string) (*bool, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
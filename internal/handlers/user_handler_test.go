@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/dtos"
 	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/helpers"
@@ -149,6 +150,21 @@ func TestHandler_GetUserHistories(t *testing.T) {
 		Token: "token",
 	}
 	mockError := fmt.Errorf("error")
+	mockQuery := &dtos.HistoriesRequestQuery{
+		UserID: mockUserContext.ID,
+		Sort:   constants.SORT_NEWEST,
+		Limit:  10,
+		Page:   1,
+	}
+	validResponse := dtos.GetUserHistoriesDTO{
+		Data: []*dtos.HistoryResponseDTO{},
+		PaginationResponse: dtos.PaginationResponse{
+			PerPage:     10,
+			CurrentPage: 1,
+		},
+	}
+	mockValidDataInInterface, err := helpers.StructToMap(validResponse)
+	require.NoError(t, err)
 
 	type fields struct {
 		historyService mocks.IHistoryService
@@ -176,12 +192,12 @@ func TestHandler_GetUserHistories(t *testing.T) {
 			},
 		},
 		{
-			name: "ERROR | Error from History.GetByUserID",
+			name: "ERROR | Error from History.GetByQuery",
 			fields: fields{
 				historyService: *mocks.NewIHistoryService(t),
 			},
 			mock: func(s *mocks.IHistoryService) {
-				s.On("GetByUserID", mockUserContext.ID).Return(nil, mockError)
+				s.On("GetByQuery", mockQuery).Return(nil, mockError)
 			},
 			mockUserFromMiddleware: true,
 			want: helpers.JsonResponse{
@@ -197,13 +213,14 @@ func TestHandler_GetUserHistories(t *testing.T) {
 				historyService: *mocks.NewIHistoryService(t),
 			},
 			mock: func(s *mocks.IHistoryService) {
-				s.On("GetByUserID", mockUserContext.ID).Return([]*models.History{}, nil)
+				s.On("GetByQuery", mockQuery).Return([]*models.History{}, nil)
+				s.On("CountByQuery", mockQuery).Return(int64(0), int64(0), nil)
 			},
 			mockUserFromMiddleware: true,
 			want: helpers.JsonResponse{
 				Code:    http.StatusOK,
 				Message: http.StatusText(http.StatusOK),
-				Data:    []interface{}{},
+				Data:    mockValidDataInInterface,
 				IsError: false,
 			},
 		},
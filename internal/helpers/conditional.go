@@ -0,0 +1,103 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CacheOptions struct {
+	CacheControl string
+	Vary         string
+	LastModified time.Time
+	// ETagSource is hashed instead of the response data when set, so fields
+	// that change on every request can be left out of the validator.
+	ETagSource interface{}
+}
+
+// SendConditionalSuccessResponse sends data like SendSuccessResponse with
+// ETag, Last-Modified and Cache-Control headers, and answers GET requests
+// whose validators still match with an empty 304.
+func SendConditionalSuccessResponse(
+	c *gin.Context,
+	code int,
+	message string,
+	data interface{},
+	options *CacheOptions,
+) {
+	body, err := json.Marshal(JsonResponse{
+		Code:    code,
+		Message: message,
+		Data:    data,
+		IsError: false,
+	})
+	if err != nil {
+		SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	source := body
+	if options.ETagSource != nil {
+		source, err = json.Marshal(options.ETagSource)
+		if err != nil {
+			SendErrorResponse(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	etag := ComputeETag(source)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", options.CacheControl)
+	if options.Vary != "" {
+		c.Header("Vary", options.Vary)
+	}
+	if !options.LastModified.IsZero() {
+		c.Header("Last-Modified", options.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if isNotModified(c.Request, etag, options.LastModified) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	c.Data(code, "application/json; charset=utf-8", body)
+}
+
+func ComputeETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// isNotModified gives If-None-Match precedence over If-Modified-Since, as
+// RFC 9110 requires, and only applies to safe methods.
+func isNotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(ifModifiedSince)
+}
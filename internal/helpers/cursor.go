@@ -0,0 +1,55 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"final-project-backend/internal/dtos"
+	errn "final-project-backend/internal/errors"
+)
+
+func EncodeCursor(cursor *dtos.PostCursor) string {
+	payload, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func DecodeCursor(encoded string) (*dtos.PostCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor dtos.PostCursor
+	err = json.Unmarshal(payload, &cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
+
+func EncodeFeedCursor(cursor *dtos.FeedCursor) string {
+	payload, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func DecodeFeedCursor(encoded string) (*dtos.FeedCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor dtos.FeedCursor
+	err = json.Unmarshal(payload, &cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.Explore == nil {
+		return nil, errn.ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
@@ -0,0 +1,42 @@
+package helpers
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"final-project-backend/config"
+)
+
+type Mailer interface {
+	Send(to string, subject string, body string) error
+}
+
+type smtpMailer struct{}
+
+func NewMailer() Mailer {
+	return &smtpMailer{}
+}
+
+// Send delivers a plain text email through the SMTP server in the config.
+func (m *smtpMailer) Send(to string, subject string, body string) error {
+	smtpConfig := config.InitConfigSmtp()
+	host, port, username, password, sender := smtpConfig[0], smtpConfig[1], smtpConfig[2], smtpConfig[3], smtpConfig[4]
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	message := strings.Join([]string{
+		"From: " + sender,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	return smtp.SendMail(fmt.Sprintf("%s:%s", host, port), auth, sender, []string{to}, []byte(message))
+}
@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	errn "final-project-backend/internal/errors"
+)
+
+// SignPayload encodes payload as JSON and appends an HMAC-SHA256 signature, so
+// the result can be handed to clients and verified later without storing it.
+func SignPayload(payload interface{}, secret string) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+
+	return encoded + "." + sign(encoded, secret), nil
+}
+
+func VerifyPayload(token string, secret string, payload interface{}) error {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 || !hmac.Equal([]byte(sign(parts[0], secret)), []byte(parts[1])) {
+		return errn.ErrInvalidSignature
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return errn.ErrInvalidSignature
+	}
+
+	err = json.Unmarshal(data, payload)
+	if err != nil {
+		return errn.ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func sign(encoded string, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(encoded))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -2,6 +2,8 @@ package helpers
 
 import (
 	"math/rand"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,3 +17,56 @@ func RandSeq(n int) string {
 	}
 	return string(b)
 }
+
+func ParseIDList(s string) ([]int64, error) {
+	var ids []int64
+	if s == "" {
+		return ids, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		if id != 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// TruncateParagraphs keeps at most n leading paragraphs of HTML (<p>) or plain
+// text content, and always drops at least the last one so a teaser never
+// contains the whole body.
+func TruncateParagraphs(content string, n int) string {
+	var paragraphs []string
+	separator := "\n\n"
+
+	if strings.Contains(content, "</p>") {
+		separator = ""
+		for _, part := range strings.SplitAfter(content, "</p>") {
+			if strings.TrimSpace(part) != "" {
+				paragraphs = append(paragraphs, part)
+			}
+		}
+	} else {
+		for _, part := range strings.Split(content, "\n") {
+			if strings.TrimSpace(part) != "" {
+				paragraphs = append(paragraphs, strings.TrimSpace(part))
+			}
+		}
+	}
+
+	if n > len(paragraphs)-1 {
+		n = len(paragraphs) - 1
+	}
+
+	if n <= 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.Join(paragraphs[:n], separator))
+}
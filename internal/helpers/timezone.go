@@ -0,0 +1,17 @@
+package helpers
+
+import (
+	"time"
+
+	errn "final-project-backend/internal/errors"
+)
+
+// LoadTimezone loads an IANA time zone that Postgres can use as well. Go reads
+// "" and "Local" as the server's own zone, which AT TIME ZONE rejects.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, errn.ErrInvalidTimezone
+	}
+
+	return time.LoadLocation(name)
+}
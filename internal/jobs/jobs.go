@@ -0,0 +1,97 @@
+package jobs
+
+import (
+	"log"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/services"
+)
+
+func New(s *services.Services) *Scheduler {
+	return NewScheduler(
+		&Job{
+			Name:     "reconcile-post-counters",
+			Interval: constants.RECONCILE_COUNTERS_INTERVAL,
+			Run: func() error {
+				return reconcilePostCounters(s.Post)
+			},
+		},
+		&Job{
+			Name:       "refresh-trending-scores",
+			Interval:   constants.TRENDING_REFRESH_INTERVAL,
+			RunOnStart: true,
+			Run: func() error {
+				_, err := s.Post.RefreshTrending()
+				return err
+			},
+		},
+		&Job{
+			Name:     "refresh-post-neighbors",
+			Interval: constants.POST_NEIGHBORS_REFRESH_INTERVAL,
+			Run: func() error {
+				_, err := s.Post.RefreshPostNeighbors()
+				return err
+			},
+		},
+		&Job{
+			Name:       "process-subscription-renewals",
+			Interval:   constants.RENEWAL_JOB_INTERVAL,
+			RunOnStart: true,
+			Run: func() error {
+				return processRenewals(s.Renewal)
+			},
+		},
+		&Job{
+			Name:       "process-trial-expiries",
+			Interval:   constants.TRIAL_JOB_INTERVAL,
+			RunOnStart: true,
+			Run: func() error {
+				return processTrialExpiries(s.Trial)
+			},
+		},
+	)
+}
+
+func reconcilePostCounters(postService services.IPostService) error {
+	updated, err := postService.ReconcileCounters()
+	if err != nil {
+		return err
+	}
+
+	if updated > 0 {
+		log.Printf("reconciled like/share counters of %d posts", updated)
+	}
+
+	return nil
+}
+
+func processRenewals(renewalService services.IRenewalService) error {
+	issued, err := renewalService.IssueRenewalInvoices()
+	if err != nil {
+		return err
+	}
+
+	reminded, expired, err := renewalService.SendRenewalReminders()
+	if err != nil {
+		return err
+	}
+
+	if issued > 0 || reminded > 0 || expired > 0 {
+		log.Printf("issued %d renewal invoices, reminded %d and expired %d unpaid ones", issued, reminded, expired)
+	}
+
+	return nil
+}
+
+func processTrialExpiries(trialService services.ITrialService) error {
+	reminded, expired, err := trialService.SendExpiryNotifications()
+	if err != nil {
+		return err
+	}
+
+	if reminded > 0 || expired > 0 {
+		log.Printf("reminded %d members of their trial ending and notified %d of it expiring", reminded, expired)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	// RunOnStart runs the job once right away instead of waiting a full
+	// interval, for jobs whose output is needed as soon as the server is up.
+	RunOnStart bool
+	Run        func() error
+}
+
+// Scheduler runs every registered job on its own ticker until Stop is called.
+// A job never overlaps with itself since each one is driven by a single goroutine.
+type Scheduler struct {
+	jobs []*Job
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewScheduler(jobs ...*Job) *Scheduler {
+	return &Scheduler{
+		jobs: jobs,
+		stop: make(chan struct{}),
+	}
+}
+
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(job)
+	}
+}
+
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(job *Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	if job.RunOnStart {
+		s.run(job)
+	}
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.run(job)
+		}
+	}
+}
+
+func (s *Scheduler) run(job *Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("job %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	err := job.Run()
+	if err != nil {
+		log.Printf("job %s failed: %v", job.Name, err)
+	}
+}
@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler(t *testing.T) {
+	var runs int32
+	s := NewScheduler(&Job{
+		Name:     "test",
+		Interval: 5 * time.Millisecond,
+		Run: func() error {
+			if atomic.AddInt32(&runs, 1) == 1 {
+				panic("boom")
+			}
+			return fmt.Errorf("error")
+		},
+	})
+
+	s.Start()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) >= 3 }, time.Second, time.Millisecond)
+	s.Stop()
+
+	stopped := atomic.LoadInt32(&runs)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt32(&runs))
+}
@@ -78,3 +78,14 @@ func AuthorizeJWT(c *gin.Context) {
 	c.Next()
 
 }
+
+// OptionalAuthorizeJWT lets anonymous requests through without a user in the
+// context, but still rejects a token that is present and invalid.
+func OptionalAuthorizeJWT(c *gin.Context) {
+	if c.GetHeader("Authorization") == "" {
+		c.Next()
+		return
+	}
+
+	AuthorizeJWT(c)
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"final-project-backend/internal/constants"
+)
+
+// AchievementRule is an admin-defined badge, awarded once Threshold is
+// reached for its Kind. Rewarding a voucher is optional.
+type AchievementRule struct {
+	ID          int64                     `json:"id" gorm:"primaryKey"`
+	Name        string                    `json:"name" gorm:"not null"`
+	Description string                    `json:"description"`
+	Kind        constants.AchievementKind `json:"kind" gorm:"not null"`
+	CategoryID  *int64                    `json:"category_id"`
+	Category    *Category                 `json:"category,omitempty" gorm:"foreignKey:category_id"`
+	Threshold   int                       `json:"threshold" gorm:"not null"`
+	VoucherID   *int64                    `json:"voucher_id"`
+	Voucher     *Voucher                  `json:"voucher,omitempty" gorm:"foreignKey:voucher_id"`
+	IsActive    bool                      `json:"is_active" gorm:"not null"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at"`
+}
+
+type UserAchievement struct {
+	UserID            int64            `json:"user_id" gorm:"primaryKey"`
+	AchievementRuleID int64            `json:"achievement_rule_id" gorm:"primaryKey"`
+	AchievementRule   *AchievementRule `json:"achievement_rule,omitempty" gorm:"foreignKey:achievement_rule_id"`
+	AchievedAt        time.Time        `json:"achieved_at"`
+	UserVoucherID     *int64           `json:"user_voucher_id"`
+	UserVoucher       *UserVoucher     `json:"user_voucher,omitempty" gorm:"foreignKey:user_voucher_id"`
+}
+
+// AchievementProgress is a member's standing on one rule, with Achievement
+// set once the badge has been awarded.
+type AchievementProgress struct {
+	Rule        *AchievementRule
+	Progress    int
+	Achievement *UserAchievement
+}
+
+type AchievementSummary struct {
+	Timezone      string
+	CurrentStreak int
+	LongestStreak int
+	Badges        []*AchievementProgress
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Author struct {
+	gorm.Model
+	ID          int64               `gorm:"primary_key"`
+	Name        string              `json:"name" gorm:"unique"`
+	Bio         string              `json:"bio"`
+	AvatarUrl   string              `json:"avatar_url"`
+	SocialLinks []*AuthorSocialLink `json:"social_links" gorm:"foreignKey:author_id"`
+}
+
+type AuthorSocialLink struct {
+	ID       int64  `json:"id" gorm:"primaryKey"`
+	AuthorID int64  `json:"author_id"`
+	Platform string `json:"platform"`
+	Url      string `json:"url"`
+}
+
+type AuthorFollower struct {
+	UserID    int64     `json:"user_id" gorm:"primaryKey"`
+	AuthorID  int64     `json:"author_id" gorm:"primaryKey"`
+	Author    Author    `json:"author" gorm:"foreignKey:author_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AuthorProfile struct {
+	Author
+	FollowerCount int64 `json:"follower_count"`
+	IsFollowing   bool  `json:"is_following"`
+}
@@ -9,3 +9,11 @@ type PostCategoryReadCount struct {
 	ReadCount  int   `json:"read_count"`
 	CategoryID int64 `json:"category_id"`
 }
+
+// CategoryInterest is how strongly a member engaged with a category, with
+// older engagement decayed.
+type CategoryInterest struct {
+	CategoryID   int64   `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Score        float64 `json:"score"`
+}
@@ -3,18 +3,27 @@ package models
 import (
 	"time"
 
+	"final-project-backend/internal/constants"
+
 	"gorm.io/gorm"
 )
 
 type History struct {
-	UserID       int64     `json:"user_id" gorm:"primaryKey"`
-	PostID       int64     `json:"post_id" gorm:"primaryKey"`
-	Post         Post      `json:"post" gorm:"foreignKey:post_id"`
-	LastAccessed time.Time `json:"last_accessed" gorm:"autoUpdateTime:true"`
-	IsLiked      bool      `json:"is_liked"`
-	IsShared     bool      `json:"is_shared"`
-	DeletedAt    gorm.DeletedAt
-	CreatedAt    time.Time
+	UserID       int64              `json:"user_id" gorm:"primaryKey"`
+	PostID       int64              `json:"post_id" gorm:"primaryKey"`
+	Post         Post               `json:"post" gorm:"foreignKey:post_id"`
+	LastAccessed time.Time          `json:"last_accessed" gorm:"autoUpdateTime:true"`
+	IsLiked      bool               `json:"is_liked"`
+	IsShared     bool               `json:"is_shared"`
+	Reaction     constants.Reaction `json:"reaction" gorm:"not null;default:''"`
+	// Progress is the last reported read position as a percentage.
+	Progress    int        `json:"progress" gorm:"not null;default:0"`
+	CompletedAt *time.Time `json:"completed_at"`
+	// HiddenAt is set when the member removes the post from their reading
+	// history. The row is kept so the post stays unlocked.
+	HiddenAt  *time.Time `json:"hidden_at" gorm:"index"`
+	DeletedAt gorm.DeletedAt
+	CreatedAt time.Time
 }
 
 func (History) BeforeCreate(db *gorm.DB) error {
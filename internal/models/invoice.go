@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"final-project-backend/internal/constants"
+
 	"gorm.io/gorm"
 )
 
@@ -18,6 +20,17 @@ type Invoice struct {
 	SubscriptionID int64         `json:"subscription_id"`
 	Subscription   Subscription  `json:"subscription" gorm:"foreignKey:subscription_id"`
 	VoucherCode    string        `json:"voucher_code"`
+	// RenewalOfID is the user subscription a renewal invoice extends, and
+	// DueAt is the end of the period it pays for.
+	RenewalOfID    *int64     `json:"renewal_of_id" gorm:"index"`
+	DueAt          *time.Time `json:"due_at"`
+	RemindersSent  int        `json:"reminders_sent" gorm:"not null;default:0"`
+	LastRemindedAt *time.Time `json:"last_reminded_at"`
+	// ChangeOfID is the user subscription a plan change invoice replaces.
+	// Credit is the prorated value of it that was taken off the total.
+	ChangeOfID     *int64                         `json:"change_of_id" gorm:"index"`
+	Credit         int                            `json:"credit" gorm:"not null;default:0"`
+	QuotaCarryOver constants.QuotaCarryOverPolicy `json:"quota_carry_over"`
 }
 
 type InvoiceStatus int
@@ -27,6 +40,7 @@ const (
 	PROCESSED
 	COMPLETED
 	REJECTED
+	EXPIRED
 )
 
 func (e InvoiceStatus) String() string {
@@ -39,6 +53,8 @@ func (e InvoiceStatus) String() string {
 		return "COMPLETED"
 	case REJECTED:
 		return "REJECTED"
+	case EXPIRED:
+		return "EXPIRED"
 	default:
 		return ""
 	}
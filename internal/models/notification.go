@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+type NotificationKind string
+
+const (
+	NOTIFICATION_RENEWAL_INVOICE  NotificationKind = "renewal_invoice"
+	NOTIFICATION_RENEWAL_REMINDER NotificationKind = "renewal_reminder"
+	NOTIFICATION_RENEWAL_SUCCESS  NotificationKind = "renewal_success"
+	NOTIFICATION_RENEWAL_FAILED   NotificationKind = "renewal_failed"
+	NOTIFICATION_PLAN_CHANGED     NotificationKind = "plan_changed"
+	NOTIFICATION_TRIAL_STARTED    NotificationKind = "trial_started"
+	NOTIFICATION_TRIAL_ENDING     NotificationKind = "trial_ending"
+	NOTIFICATION_TRIAL_EXPIRED    NotificationKind = "trial_expired"
+)
+
+type Notification struct {
+	ID        int64            `json:"id" gorm:"primaryKey"`
+	UserID    int64            `json:"user_id" gorm:"index"`
+	Kind      NotificationKind `json:"kind"`
+	Message   string           `json:"message"`
+	InvoiceID *int64           `json:"invoice_id"`
+	CreatedAt time.Time        `json:"created_at"`
+}
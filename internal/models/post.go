@@ -17,9 +17,21 @@ type Post struct {
 	Type         PostType `json:"type" gorm:"foreignKey:type_id"`
 	ImgThumbnail string   `json:"img_thumbnail"`
 	ImgUrl       string   `json:"img_url"`
-	AuthorName   string   `json:"author_name"`
+	AuthorID     int64    `json:"author_id" gorm:"default:null"`
+	Author       Author   `json:"author" gorm:"foreignKey:author_id"`
+	Tags         []*Tag   `json:"tags" gorm:"many2many:post_tags"`
 	ShareCount   int      `json:"share_count"`
 	LikeCount    int      `json:"like_count"`
+	// ShareRedemptionCount counts visitors who read the post through a
+	// member's share link.
+	ShareRedemptionCount int `json:"share_redemption_count"`
+	InsightfulCount      int `json:"insightful_count" gorm:"not null;default:0"`
+	FunnyCount           int `json:"funny_count" gorm:"not null;default:0"`
+	SadCount             int `json:"sad_count" gorm:"not null;default:0"`
+	AngryCount           int `json:"angry_count" gorm:"not null;default:0"`
+	// ReactionCount is the total over every reaction and backs the
+	// most_reacted sort.
+	ReactionCount int `json:"reaction_count" gorm:"not null;default:0"`
 }
 
 type TrendingPost struct {
@@ -27,3 +39,10 @@ type TrendingPost struct {
 	ReaderCount int   `json:"reader_count"`
 	PostID      int64 `json:"post_id"`
 }
+
+// PostReadStats summarises how far members got through a post.
+type PostReadStats struct {
+	ReadCount       int64   `json:"read_count"`
+	CompletedCount  int64   `json:"completed_count"`
+	AverageProgress float64 `json:"average_progress"`
+}
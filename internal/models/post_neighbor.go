@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// PostNeighbor links a post to one of its most similar posts by readership,
+// rebuilt periodically by a background job.
+type PostNeighbor struct {
+	PostID      int64     `json:"post_id" gorm:"primaryKey"`
+	NeighborID  int64     `json:"neighbor_id" gorm:"primaryKey"`
+	Neighbor    Post      `json:"neighbor" gorm:"foreignKey:neighbor_id"`
+	Score       float64   `json:"score"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+}
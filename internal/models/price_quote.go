@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"final-project-backend/internal/constants"
+)
+
+// PriceQuote is the price of a plan as computed by the server for one member.
+// Invoices are only created from a quote, never from a client-sent price.
+type PriceQuote struct {
+	UserID         int64
+	SubscriptionID int64
+	VoucherCode    string
+	OriginalPrice  int
+	Discount       int
+	Total          int
+	ExpiresAt      time.Time
+	// ChangeFromID is set when the quote switches an existing subscription to
+	// this plan, in which case Credit is already taken off Total.
+	ChangeFromID   int64
+	Credit         int
+	QuotaCarryOver constants.QuotaCarryOverPolicy
+	Subscription   *Subscription
+	ChangeFrom     *UserSubscriptions
+	UserVoucher    *UserVoucher
+}
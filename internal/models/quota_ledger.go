@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// QuotaLedger is an append-only record of quota taken from a subscription to
+// unlock a post. A post paid from two subscriptions gets one entry for each.
+type QuotaLedger struct {
+	ID                 int64             `json:"id" gorm:"primaryKey"`
+	UserID             int64             `json:"user_id" gorm:"index"`
+	UserSubscriptionID int64             `json:"user_subscription_id"`
+	UserSubscription   UserSubscriptions `json:"user_subscription" gorm:"foreignKey:user_subscription_id"`
+	PostID             int64             `json:"post_id"`
+	Post               Post              `json:"post" gorm:"foreignKey:post_id"`
+	Amount             int               `json:"amount"`
+	CreatedAt          time.Time         `json:"created_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ShareLink struct {
+	gorm.Model
+	ID              int64     `json:"id" gorm:"primary_key"`
+	UserID          int64     `json:"user_id"`
+	PostID          int64     `json:"post_id"`
+	Post            Post      `json:"post" gorm:"foreignKey:post_id"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	RedemptionCount int       `json:"redemption_count"`
+}
+
+type ShareLinkRedemption struct {
+	ID          int64     `json:"id" gorm:"primary_key"`
+	ShareLinkID int64     `json:"share_link_id" gorm:"uniqueIndex:idx_share_link_redemptions_visitor"`
+	VisitorKey  string    `json:"visitor_key" gorm:"uniqueIndex:idx_share_link_redemptions_visitor"`
+	UserID      int64     `json:"user_id" gorm:"default:null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -1,11 +1,36 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
 
+	"final-project-backend/internal/constants"
+
+	"gorm.io/gorm"
+)
+
+// Subscription is one version of a plan. Versions are never edited once
+// sold: a price or quota change supersedes the row with a new version of the
+// same PlanID, so invoices and user subscriptions keep pointing at exactly
+// what was bought.
 type Subscription struct {
 	gorm.Model
-	ID    int64  `gorm:"primary_key"`
-	Name  string `json:"name"`
-	Price int    `json:"price"`
-	Quota int    `json:"quota"`
+	ID           int64              `gorm:"primary_key"`
+	PlanID       int64              `json:"plan_id" gorm:"index"`
+	Version      int                `json:"version" gorm:"not null;default:1"`
+	Name         string             `json:"name"`
+	Price        int                `json:"price"`
+	Quota        int                `json:"quota"`
+	SupersededAt *time.Time         `json:"superseded_at"`
+	RetiredAt    *time.Time         `json:"retired_at"`
+	Type         constants.PlanType `json:"type" gorm:"not null;default:'paid'"`
+}
+
+// IsPurchasable reports whether this is the current version of a plan that
+// is still on sale.
+func (s *Subscription) IsPurchasable() bool {
+	return s.SupersededAt == nil && s.RetiredAt == nil
+}
+
+func (s *Subscription) IsTrial() bool {
+	return s.Type == constants.PLAN_TRIAL
 }
@@ -0,0 +1,6 @@
+package models
+
+type Tag struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Name string `json:"name" gorm:"unique;not null"`
+}
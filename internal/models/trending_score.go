@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// TrendingScore is a snapshot of a post's time-decayed engagement, rebuilt
+// periodically by a background job so reads never scan histories.
+type TrendingScore struct {
+	PostID      int64     `json:"post_id" gorm:"primaryKey"`
+	Post        Post      `json:"post" gorm:"foreignKey:post_id"`
+	Score       float64   `json:"score" gorm:"index"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// TrialGrant records the one trial a member can ever get. Identity is the
+// member's normalized email address, so aliases of one mailbox share a trial
+// too.
+type TrialGrant struct {
+	ID                 int64      `json:"id" gorm:"primaryKey"`
+	UserID             int64      `json:"user_id" gorm:"uniqueIndex"`
+	Identity           string     `json:"-" gorm:"uniqueIndex"`
+	UserSubscriptionID *int64     `json:"user_subscription_id"`
+	ExpiresAt          time.Time  `json:"expires_at" gorm:"index"`
+	RemindedAt         *time.Time `json:"reminded_at"`
+	ExpiryNotifiedAt   *time.Time `json:"expiry_notified_at"`
+	// ConvertedAt is set when the member completes their first paid invoice.
+	ConvertedAt        *time.Time `json:"converted_at"`
+	ConvertedInvoiceID *int64     `json:"converted_invoice_id"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+type TrialStats struct {
+	Granted   int64
+	Active    int64
+	Converted int64
+	Expired   int64
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -17,6 +19,11 @@ type User struct {
 	ReferralCode   string         `json:"referral_code" gorm:"unique"`
 	ReferredUserID int64          `json:"referred_user_id" gorm:"default:null"`
 	UsersReferred  []User         `json:"users_referred" gorm:"foreignkey:referred_user_id"`
+	Timezone       string         `json:"timezone" gorm:"not null;default:'Asia/Jakarta'"`
+
+	// EmailVerifiedAt is set once the member opens the link sent on
+	// registration.
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
 }
 
 type Role string
@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"final-project-backend/internal/constants"
+
 	"gorm.io/gorm"
 )
 
@@ -14,8 +16,44 @@ type UserSubscriptions struct {
 	RemainingQuota int          `json:"remaining_quota"`
 	DateStarted    time.Time    `json:"date_started"`
 	DateEnded      time.Time    `json:"date_ended"`
+	AutoRenew      bool         `json:"auto_renew" gorm:"not null;default:false"`
+	IsTrial        bool         `json:"is_trial" gorm:"not null;default:false"`
+	// GraceEndsAt is set while a renewal invoice is open and keeps the
+	// subscription usable past DateEnded until then.
+	GraceEndsAt *time.Time `json:"grace_ends_at"`
+	// While paused the subscription can not be used. DateEnded is pushed back
+	// by the whole pause when it starts and pulled in again on an early resume.
+	PausedAt    *time.Time `json:"paused_at"`
+	PauseEndsAt *time.Time `json:"pause_ends_at"`
+	// PausedDays adds up the pauses of the current period, resume gives back
+	// the whole days left unused and a renewal starts it over.
+	PausedDays  int        `json:"paused_days" gorm:"not null;default:0"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+	// ReplacedByID is set once the member switched this subscription to
+	// another plan. It ended at the moment of the switch.
+	ReplacedByID *int64 `json:"replaced_by_id"`
 }
 
 func (UserSubscriptions) BeforeCreate(db *gorm.DB) error {
 	return nil
 }
+
+func (u *UserSubscriptions) IsPaused(now time.Time) bool {
+	return u.PauseEndsAt != nil && u.PauseEndsAt.After(now)
+}
+
+func (u *UserSubscriptions) Status(now time.Time) constants.SubscriptionStatus {
+	if !u.DateEnded.After(now) && (u.GraceEndsAt == nil || !u.GraceEndsAt.After(now)) {
+		return constants.SUBSCRIPTION_EXPIRED
+	}
+
+	if u.IsPaused(now) {
+		return constants.SUBSCRIPTION_PAUSED
+	}
+
+	if u.CancelledAt != nil {
+		return constants.SUBSCRIPTION_CANCELLED
+	}
+
+	return constants.SUBSCRIPTION_ACTIVE
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"final-project-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IAchievementRepository interface {
+	GetRules() ([]*models.AchievementRule, error)
+	GetRuleByID(id int64) (*models.AchievementRule, error)
+	InsertRule(rule *models.AchievementRule) (*models.AchievementRule, error)
+	UpdateRule(rule *models.AchievementRule) (*models.AchievementRule, int, error)
+	GetByUserID(userID int64) ([]*models.UserAchievement, error)
+	Award(userAchievement *models.UserAchievement, userVoucher *models.UserVoucher) (bool, error)
+}
+
+type achievementRepository struct {
+	db *gorm.DB
+}
+
+type AchievementRepositoryConfig struct {
+	db *gorm.DB
+}
+
+func NewAchievementRepository(c *AchievementRepositoryConfig) IAchievementRepository {
+	return &achievementRepository{
+		db: c.db,
+	}
+}
+
+func (r *achievementRepository) GetRules() ([]*models.AchievementRule, error) {
+	var rules []*models.AchievementRule
+
+	result := r.db.Preload("Category").Preload("Voucher").Order("id asc").Find(&rules)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return rules, nil
+}
+
+func (r *achievementRepository) GetRuleByID(id int64) (*models.AchievementRule, error) {
+	var rule *models.AchievementRule
+
+	result := r.db.Preload("Category").Preload("Voucher").Where("id = ?", id).First(&rule)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return rule, nil
+}
+
+func (r *achievementRepository) InsertRule(rule *models.AchievementRule) (*models.AchievementRule, error) {
+	result := r.db.Omit("Category", "Voucher").Create(rule)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return rule, nil
+}
+
+// UpdateRule replaces every editable column so optional references can be
+// cleared and rules switched off.
+func (r *achievementRepository) UpdateRule(rule *models.AchievementRule) (*models.AchievementRule, int, error) {
+	result := r.db.Model(&rule).
+		Select("name", "description", "kind", "category_id", "threshold", "voucher_id", "is_active").
+		Clauses(clause.Returning{}).
+		Updates(rule)
+
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	return rule, int(result.RowsAffected), nil
+}
+
+func (r *achievementRepository) GetByUserID(userID int64) ([]*models.UserAchievement, error) {
+	var userAchievements []*models.UserAchievement
+
+	result := r.db.Preload("UserVoucher.Voucher").Where("user_id = ?", userID).Find(&userAchievements)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return userAchievements, nil
+}
+
+// Award records the badge and, if the rule has one, its voucher in one
+// transaction. It reports whether this call was the one that awarded it, so
+// rewards are only granted once.
+func (r *achievementRepository) Award(userAchievement *models.UserAchievement, userVoucher *models.UserVoucher) (bool, error) {
+	isAwarded := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit("AchievementRule", "UserVoucher").
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(userAchievement)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		isAwarded = true
+		if userVoucher == nil {
+			return nil
+		}
+
+		result = tx.Create(userVoucher)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		userAchievement.UserVoucherID = &userVoucher.ID
+
+		return tx.Model(&models.UserAchievement{}).
+			Where("user_id = ? AND achievement_rule_id = ?", userAchievement.UserID, userAchievement.AchievementRuleID).
+			Update("user_voucher_id", userVoucher.ID).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return isAwarded, nil
+}
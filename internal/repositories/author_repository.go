@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"final-project-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IAuthorRepository interface {
+	Insert(author *models.Author) (*models.Author, error)
+	Update(author *models.Author) (*models.Author, int, error)
+	GetByID(id int64) (*models.Author, error)
+	GetAll() ([]*models.Author, error)
+	CountFollowers(authorID int64) (int64, error)
+	IsFollowing(userID int64, authorID int64) (bool, error)
+	Follow(follower *models.AuthorFollower) error
+	Unfollow(follower *models.AuthorFollower) error
+}
+
+type authorRepository struct {
+	db *gorm.DB
+}
+
+type AuthorRepositoryConfig struct {
+	db *gorm.DB
+}
+
+func NewAuthorRepository(c *AuthorRepositoryConfig) IAuthorRepository {
+	return &authorRepository{
+		db: c.db,
+	}
+}
+
+func (r *authorRepository) Insert(author *models.Author) (*models.Author, error) {
+	result := r.db.Create(&author)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return author, nil
+}
+
+func (r *authorRepository) Update(author *models.Author) (*models.Author, int, error) {
+	var rowsAffected int
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&author).Omit("SocialLinks").Clauses(clause.Returning{}).Updates(author)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		rowsAffected = int(result.RowsAffected)
+		if rowsAffected == 0 || author.SocialLinks == nil {
+			return nil
+		}
+
+		result = tx.Where("author_id = ?", author.ID).Delete(&models.AuthorSocialLink{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if len(author.SocialLinks) == 0 {
+			return nil
+		}
+
+		for _, link := range author.SocialLinks {
+			link.AuthorID = author.ID
+		}
+
+		return tx.Create(&author.SocialLinks).Error
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return author, rowsAffected, nil
+}
+
+func (r *authorRepository) GetByID(id int64) (*models.Author, error) {
+	var author *models.Author
+
+	result := r.db.
+		Where("authors.id = ?", id).
+		Preload("SocialLinks").
+		First(&author)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return author, nil
+}
+
+func (r *authorRepository) GetAll() ([]*models.Author, error) {
+	var authors []*models.Author
+
+	result := r.db.
+		Preload("SocialLinks").
+		Order("name asc").
+		Find(&authors)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return authors, nil
+}
+
+func (r *authorRepository) CountFollowers(authorID int64) (int64, error) {
+	var totalFollowers int64
+
+	result := r.db.Model(&models.AuthorFollower{}).
+		Where("author_id = ?", authorID).
+		Count(&totalFollowers)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return totalFollowers, nil
+}
+
+func (r *authorRepository) IsFollowing(userID int64, authorID int64) (bool, error) {
+	var total int64
+
+	result := r.db.Model(&models.AuthorFollower{}).
+		Where("user_id = ? AND author_id = ?", userID, authorID).
+		Count(&total)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return total > 0, nil
+}
+
+func (r *authorRepository) Follow(follower *models.AuthorFollower) error {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Omit("Author").Create(&follower)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *authorRepository) Unfollow(follower *models.AuthorFollower) error {
+	result := r.db.
+		Where("user_id = ? AND author_id = ?", follower.UserID, follower.AuthorID).
+		Delete(&models.AuthorFollower{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
@@ -1,8 +1,11 @@
 package repositories
 
 import (
+	"math"
 	"time"
 
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/dtos"
 	"final-project-backend/internal/models"
 
 	"gorm.io/gorm"
@@ -15,11 +18,23 @@ type IHistoryRepository interface {
 	UpdateSingleColumn(
 		key string, history *models.History,
 	) (*models.History, int, error)
-	GetByUserID(userID int64) ([]*models.History, error)
+	GetByQuery(query *dtos.HistoriesRequestQuery) ([]*models.History, error)
+	CountByQuery(query *dtos.HistoriesRequestQuery) (int64, error)
+	Hide(userID int64, postID int64) (int64, error)
+	HideAll(userID int64) (int64, error)
+	UpdateProgress(userID int64, postID int64, progress int) (*models.History, error)
+	GetReadStats(postID int64) (*models.PostReadStats, error)
+	GetReadingDays(userID int64, timezone string) ([]time.Time, error)
+	GetReadCountsByCategory(userID int64) ([]*models.PostCategoryReadCount, error)
 	GetByUserAndPostID(userID int64, postID int64) (*models.History, error)
 	GetCategoriesReadCountPastMonth(structConditions *models.History) ([]*models.PostCategoryReadCount, error)
 	GetCategoriesReadCountPastWeek(structConditions *models.History) ([]*models.PostCategoryReadCount, error)
-	GetTrendingPostsByCategory(categoryID int64) ([]*models.Post, error)
+	GetReadPostIDs(userID int64, postIDs []int64) ([]int64, error)
+	GetRecentPostIDs(userID int64, limit int) ([]int64, error)
+	GetCategoryInterests(userID int64, limit int) ([]*models.CategoryInterest, error)
+	SetLiked(userID int64, postID int64, isLiked bool) (*models.History, bool, error)
+	SetShared(userID int64, postID int64) (*models.History, bool, error)
+	SetReaction(userID int64, postID int64, reaction constants.Reaction) (*models.History, bool, error)
 }
 
 type historyRepository struct {
@@ -48,7 +63,9 @@ func (r *historyRepository) Insert(history *models.History) (*models.History, er
 func (r *historyRepository) Update(
 	history *models.History,
 ) (*models.History, int, error) {
-	result := r.db.Model(&history).Clauses(clause.Returning{}).Updates(history)
+	// Reading a post again brings it back into a history the member had
+	// cleared, so hidden_at is written even when nil.
+	result := r.db.Model(&history).Select("last_accessed", "hidden_at").Clauses(clause.Returning{}).Updates(history)
 	if result.Error != nil {
 		return nil, 0, result.Error
 	}
@@ -67,9 +84,19 @@ func (r *historyRepository) UpdateSingleColumn(
 	return history, int(result.RowsAffected), nil
 }
 
-func (r *historyRepository) GetByUserID(userID int64) ([]*models.History, error) {
+func (r *historyRepository) GetByQuery(query *dtos.HistoriesRequestQuery) ([]*models.History, error) {
 	var histories []*models.History
-	result := r.db.Preload("Post.Category").Preload("Post.Type").Where(&models.History{UserID: userID}).Joins("Post").Find(&histories)
+
+	order := "histories.last_accessed DESC"
+	if query.Sort == constants.SORT_OLDEST {
+		order = "histories.last_accessed ASC"
+	}
+
+	result := r.filterByQuery(r.db.Preload("Post.Category").Preload("Post.Type").Preload("Post.Author").Joins("Post"), query).
+		Order(order).
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&histories)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -78,6 +105,173 @@ func (r *historyRepository) GetByUserID(userID int64) ([]*models.History, error)
 	return histories, nil
 }
 
+func (r *historyRepository) CountByQuery(query *dtos.HistoriesRequestQuery) (int64, error) {
+	var totalRows int64
+
+	result := r.filterByQuery(r.db.Model(&models.History{}).Joins("Post"), query).
+		Count(&totalRows)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return totalRows, nil
+}
+
+func (r *historyRepository) Hide(userID int64, postID int64) (int64, error) {
+	result := r.db.Model(&models.History{}).
+		Where("user_id = ? AND post_id = ? AND hidden_at IS NULL", userID, postID).
+		UpdateColumn("hidden_at", time.Now())
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *historyRepository) HideAll(userID int64) (int64, error) {
+	result := r.db.Model(&models.History{}).
+		Where("user_id = ? AND hidden_at IS NULL", userID).
+		UpdateColumn("hidden_at", time.Now())
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// UpdateProgress records the latest read position. The completion time is
+// kept from the first time the post was finished.
+func (r *historyRepository) UpdateProgress(userID int64, postID int64, progress int) (*models.History, error) {
+	history := &models.History{UserID: userID, PostID: postID}
+	updates := map[string]interface{}{
+		"progress": progress,
+	}
+
+	if progress >= constants.READ_COMPLETION_PROGRESS {
+		updates["completed_at"] = gorm.Expr("COALESCE(completed_at, ?)", time.Now())
+	}
+
+	result := r.db.Model(history).Clauses(clause.Returning{}).Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return history, nil
+}
+
+func (r *historyRepository) GetReadStats(postID int64) (*models.PostReadStats, error) {
+	var stats *models.PostReadStats
+
+	result := r.db.Model(&models.History{}).
+		Select("COUNT(*) AS read_count, COUNT(completed_at) AS completed_count, COALESCE(AVG(progress), 0) AS average_progress").
+		Where("post_id = ?", postID).
+		Scan(&stats)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return stats, nil
+}
+
+// GetReadingDays returns the distinct days, in the given timezone, on which
+// the member first opened, revisited or finished a post, oldest first.
+func (r *historyRepository) GetReadingDays(userID int64, timezone string) ([]time.Time, error) {
+	var rows []struct {
+		Day time.Time
+	}
+
+	result := r.db.Raw(`
+		SELECT day FROM (
+			SELECT (created_at AT TIME ZONE @tz)::date AS day FROM histories
+			WHERE user_id = @user AND deleted_at IS NULL
+			UNION
+			SELECT (last_accessed AT TIME ZONE @tz)::date FROM histories
+			WHERE user_id = @user AND deleted_at IS NULL
+			UNION
+			SELECT (completed_at AT TIME ZONE @tz)::date FROM histories
+			WHERE user_id = @user AND deleted_at IS NULL AND completed_at IS NOT NULL
+		) days
+		ORDER BY day ASC`,
+		map[string]interface{}{"tz": timezone, "user": userID},
+	).Scan(&rows)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	days := make([]time.Time, 0, len(rows))
+	for _, row := range rows {
+		days = append(days, row.Day)
+	}
+
+	return days, nil
+}
+
+func (r *historyRepository) GetReadCountsByCategory(userID int64) ([]*models.PostCategoryReadCount, error) {
+	var readCounts []*models.PostCategoryReadCount
+
+	result := r.db.Model(&models.History{}).
+		Select("COUNT(histories.post_id) AS read_count, posts.category_id").
+		Joins("JOIN posts ON posts.id = histories.post_id").
+		Where("histories.user_id = ?", userID).
+		Group("posts.category_id").
+		Scan(&readCounts)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return readCounts, nil
+}
+
+func (r *historyRepository) filterByQuery(db *gorm.DB, query *dtos.HistoriesRequestQuery) *gorm.DB {
+	db = db.Where("histories.user_id = ? AND histories.hidden_at IS NULL", query.UserID)
+
+	if query.Search != "" || len(query.CategoryIDs) > 0 || len(query.TypeIDs) > 0 {
+		posts := r.db.Model(&models.Post{}).Select("posts.id").Where("posts.title ILIKE ?", "%"+query.Search+"%")
+
+		if len(query.CategoryIDs) > 0 {
+			posts = posts.Where("posts.category_id IN ?", query.CategoryIDs)
+		}
+
+		if len(query.TypeIDs) > 0 {
+			posts = posts.Where("posts.type_id IN ?", query.TypeIDs)
+		}
+
+		db = db.Where("histories.post_id IN (?)", posts)
+	}
+
+	if query.DateFrom != nil {
+		db = db.Where("histories.last_accessed >= ?", *query.DateFrom)
+	}
+
+	if query.DateTo != nil {
+		db = db.Where("histories.last_accessed < ?", *query.DateTo)
+	}
+
+	if query.IsLiked != nil {
+		db = db.Where("histories.is_liked = ?", *query.IsLiked)
+	}
+
+	if query.IsShared != nil {
+		db = db.Where("histories.is_shared = ?", *query.IsShared)
+	}
+
+	if query.IsUnfinished {
+		db = db.Where("histories.progress > 0 AND histories.completed_at IS NULL")
+	}
+
+	return db
+}
+
 func (r *historyRepository) GetByUserAndPostID(userID int64, postID int64) (*models.History, error) {
 	var history *models.History
 	result := r.db.Where(&models.History{UserID: userID, PostID: postID}).Joins("Post").First(&history)
@@ -129,17 +323,168 @@ func (r *historyRepository) GetCategoriesReadCountPastWeek(structConditions *mod
 	return readCounts, nil
 }
 
-func (r *historyRepository) GetTrendingPostsByCategory(categoryID int64) ([]*models.Post, error) {
-	var trendingPosts []*models.Post
+func (r *historyRepository) GetReadPostIDs(userID int64, postIDs []int64) ([]int64, error) {
+	var readPostIDs []int64
 
-	subQuery := r.db.Debug().Table("histories").Select("COUNT(user_id) AS reader_count, post_id").Joins("LEFT JOIN posts ON posts.id = histories.post_id").Where("posts.category_id = ?", categoryID).Group("post_id")
+	result := r.db.Model(&models.History{}).
+		Where("user_id = ? AND post_id IN ?", userID, postIDs).
+		Pluck("post_id", &readPostIDs)
 
-	result := r.db.Debug().Joins("JOIN (?) as t1 ON posts.id = t1.post_id", subQuery).Joins("Category").Joins("Type").Order("t1.reader_count DESC").Limit(5).Find(&trendingPosts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return readPostIDs, nil
+}
+
+func (r *historyRepository) GetRecentPostIDs(userID int64, limit int) ([]int64, error) {
+	var postIDs []int64
+
+	result := r.db.Model(&models.History{}).
+		Where("user_id = ?", userID).
+		Order("last_accessed DESC").
+		Limit(limit).
+		Pluck("post_id", &postIDs)
 
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	return trendingPosts, nil
+	return postIDs, nil
+}
+
+// GetCategoryInterests weighs every read, like and share of the member by
+// how recently they last interacted with the post and sums them per category.
+func (r *historyRepository) GetCategoryInterests(userID int64, limit int) ([]*models.CategoryInterest, error) {
+	var interests []*models.CategoryInterest
+	now := time.Now()
+	decayPerSecond := math.Ln2 / constants.PERSONAL_FEED_INTEREST_HALF_LIFE.Seconds()
+
+	result := r.db.Model(&models.History{}).
+		Select(`posts.category_id, categories.name AS category_name,
+			SUM((?::float8 + CASE WHEN histories.is_liked THEN ?::float8 ELSE 0 END + CASE WHEN histories.is_shared THEN ?::float8 ELSE 0 END)
+				* EXP(-?::float8 * EXTRACT(EPOCH FROM (?::timestamptz - histories.last_accessed)))) AS score`,
+			constants.PERSONAL_FEED_READ_WEIGHT,
+			constants.PERSONAL_FEED_LIKE_WEIGHT,
+			constants.PERSONAL_FEED_SHARE_WEIGHT,
+			decayPerSecond,
+			now,
+		).
+		Joins("JOIN posts ON posts.id = histories.post_id").
+		Joins("JOIN categories ON categories.id = posts.category_id").
+		Where("histories.user_id = ? AND histories.last_accessed >= ?", userID, now.Add(-constants.PERSONAL_FEED_INTEREST_WINDOW)).
+		Group("posts.category_id, categories.name").
+		Order("score DESC").
+		Limit(limit).
+		Scan(&interests)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return interests, nil
+}
+
+func (r *historyRepository) SetLiked(userID int64, postID int64, isLiked bool) (*models.History, bool, error) {
+	delta := 1
+	if !isLiked {
+		delta = -1
+	}
+
+	return r.setFlag(userID, postID, "is_liked", isLiked, "like_count", delta)
+}
+
+func (r *historyRepository) SetShared(userID int64, postID int64) (*models.History, bool, error) {
+	return r.setFlag(userID, postID, "is_shared", true, "share_count", 1)
+}
+
+// setFlag flips a reaction flag only when it differs from value and bumps
+// the matching post counter in the same transaction, so repeated or concurrent
+// requests never count twice. The returned bool reports whether anything changed.
+func (r *historyRepository) setFlag(
+	userID int64, postID int64, column string, value bool, counterColumn string, delta int,
+) (*models.History, bool, error) {
+	history := &models.History{}
+	isChanged := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(history).
+			Clauses(clause.Returning{}).
+			Where("user_id = ? AND post_id = ? AND "+column+" <> ?", userID, postID, value).
+			UpdateColumn(column, value)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return tx.Where(&models.History{UserID: userID, PostID: postID}).First(history).Error
+		}
+
+		isChanged = true
+
+		return tx.Model(&models.Post{}).
+			Where("id = ?", postID).
+			UpdateColumn(counterColumn, gorm.Expr(counterColumn+" + ?", delta)).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return history, isChanged, nil
+}
+
+// SetReaction replaces the member's single reaction on a post, moving the
+// post's per-reaction and total counters in the same transaction. The history
+// row is locked so concurrent requests from the same member serialize.
+func (r *historyRepository) SetReaction(
+	userID int64, postID int64, reaction constants.Reaction,
+) (*models.History, bool, error) {
+	history := &models.History{}
+	isChanged := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(&models.History{UserID: userID, PostID: postID}).
+			First(history)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		previous := history.Reaction
+		if previous == reaction {
+			return nil
+		}
+
+		result = tx.Model(&models.History{}).
+			Where("user_id = ? AND post_id = ?", userID, postID).
+			UpdateColumn("reaction", reaction)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		history.Reaction = reaction
+		isChanged = true
+
+		counters := map[string]interface{}{}
+		if previous != constants.REACTION_NONE {
+			counters[previous.CountColumn()] = gorm.Expr(previous.CountColumn() + " - 1")
+		}
+
+		if reaction != constants.REACTION_NONE {
+			counters[reaction.CountColumn()] = gorm.Expr(reaction.CountColumn() + " + 1")
+		}
+
+		if previous == constants.REACTION_NONE {
+			counters["reaction_count"] = gorm.Expr("reaction_count + 1")
+		} else if reaction == constants.REACTION_NONE {
+			counters["reaction_count"] = gorm.Expr("reaction_count - 1")
+		}
+
+		return tx.Model(&models.Post{}).Where("id = ?", postID).UpdateColumns(counters).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
 
+	return history, isChanged, nil
 }
@@ -18,6 +18,9 @@ type IInvoiceRepository interface {
 	GetByID(id int64) (*models.Invoice, error)
 	GetByCode(code string) (*models.Invoice, error)
 	Update(invoice *models.Invoice) (*models.Invoice, int, error)
+	UpdateIfStatus(invoice *models.Invoice, status models.InvoiceStatus) (*models.Invoice, int, error)
+	GetWaitingRenewals() ([]*models.Invoice, error)
+	CountOpenPlanChanges(userSubscriptionID int64) (int64, error)
 }
 
 type invoiceRepository struct {
@@ -124,3 +127,46 @@ func (r *invoiceRepository) Update(invoice *models.Invoice) (*models.Invoice, in
 
 	return invoice, int(result.RowsAffected), nil
 }
+
+// UpdateIfStatus only updates the invoice while it still has the given
+// status, so a payment that lands in between is not overwritten.
+func (r *invoiceRepository) UpdateIfStatus(invoice *models.Invoice, status models.InvoiceStatus) (*models.Invoice, int, error) {
+	result := r.db.Model(&invoice).Clauses(clause.Returning{}).Where("status = ?", status).Updates(invoice)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	return invoice, int(result.RowsAffected), nil
+}
+
+func (r *invoiceRepository) GetWaitingRenewals() ([]*models.Invoice, error) {
+	var invoices []*models.Invoice
+
+	result := r.db.
+		Where("invoices.renewal_of_id IS NOT NULL").
+		Where("invoices.status = ?", models.WAITING).
+		Joins("Subscription").
+		Order("invoices.due_at asc").
+		Find(&invoices)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return invoices, nil
+}
+
+func (r *invoiceRepository) CountOpenPlanChanges(userSubscriptionID int64) (int64, error) {
+	var totalRows int64
+
+	result := r.db.Model(&models.Invoice{}).
+		Where("change_of_id = ?", userSubscriptionID).
+		Where("status IN ?", []models.InvoiceStatus{models.WAITING, models.PROCESSED}).
+		Count(&totalRows)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return totalRows, nil
+}
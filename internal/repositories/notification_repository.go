@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"final-project-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type INotificationRepository interface {
+	Insert(notification *models.Notification) (*models.Notification, error)
+	GetByUserID(userID int64, limit int, page int) ([]*models.Notification, error)
+	CountByUserID(userID int64) (int64, error)
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+type NotificationRepositoryConfig struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(c *NotificationRepositoryConfig) INotificationRepository {
+	return &notificationRepository{db: c.db}
+}
+
+func (r *notificationRepository) Insert(notification *models.Notification) (*models.Notification, error) {
+	result := r.db.Create(&notification)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return notification, nil
+}
+
+func (r *notificationRepository) GetByUserID(userID int64, limit int, page int) ([]*models.Notification, error) {
+	var notifications []*models.Notification
+
+	result := r.db.
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&notifications)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountByUserID(userID int64) (int64, error) {
+	var totalRows int64
+
+	result := r.db.Model(&models.Notification{}).Where("user_id = ?", userID).Count(&totalRows)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return totalRows, nil
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"time"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type IPostNeighborRepository interface {
+	Refresh() (int64, error)
+	GetByPostIDs(postIDs []int64) ([]*models.PostNeighbor, error)
+}
+
+type postNeighborRepository struct {
+	db *gorm.DB
+}
+
+type PostNeighborRepositoryConfig struct {
+	db *gorm.DB
+}
+
+func NewPostNeighborRepository(c *PostNeighborRepositoryConfig) IPostNeighborRepository {
+	return &postNeighborRepository{
+		db: c.db,
+	}
+}
+
+// Refresh rebuilds the neighbours of every post from co-reading. Two posts
+// are as similar as the cosine of their reader sets, and each post keeps only
+// its best neighbours among pairs with enough shared readers.
+func (r *postNeighborRepository) Refresh() (int64, error) {
+	var rowsAffected int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("DELETE FROM post_neighbors")
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Exec(`WITH readers AS (
+				SELECT post_id, COUNT(*)::float8 AS reader_count
+				FROM histories
+				WHERE deleted_at IS NULL
+				GROUP BY post_id
+			), pairs AS (
+				SELECT h1.post_id, h2.post_id AS neighbor_id, COUNT(*)::float8 AS co_reader_count
+				FROM histories AS h1
+				JOIN histories AS h2 ON h1.user_id = h2.user_id AND h1.post_id <> h2.post_id
+				WHERE h1.deleted_at IS NULL AND h2.deleted_at IS NULL
+				GROUP BY h1.post_id, h2.post_id
+				HAVING COUNT(*) >= ?
+			), ranked AS (
+				SELECT pairs.post_id, pairs.neighbor_id,
+					pairs.co_reader_count / SQRT(r1.reader_count * r2.reader_count) AS score
+				FROM pairs
+				JOIN readers AS r1 ON r1.post_id = pairs.post_id
+				JOIN readers AS r2 ON r2.post_id = pairs.neighbor_id
+			)
+			INSERT INTO post_neighbors (post_id, neighbor_id, score, refreshed_at)
+			SELECT post_id, neighbor_id, score, ?
+			FROM (
+				SELECT *, ROW_NUMBER() OVER (PARTITION BY post_id ORDER BY score DESC, neighbor_id) AS rank
+				FROM ranked
+			) AS top
+			WHERE rank <= ?`,
+			constants.POST_NEIGHBORS_MIN_CO_READERS,
+			time.Now(),
+			constants.POST_NEIGHBORS_LIMIT,
+		)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		rowsAffected = result.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
+
+// GetByPostIDs returns the neighbours of the given posts, best scored first.
+func (r *postNeighborRepository) GetByPostIDs(postIDs []int64) ([]*models.PostNeighbor, error) {
+	var neighbors []*models.PostNeighbor
+
+	result := r.db.
+		Where("post_id IN ?", postIDs).
+		Order("score DESC, neighbor_id").
+		Preload("Neighbor", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, title, slug, summary, img_thumbnail, category_id, type_id, author_id, share_count, like_count, reaction_count, insightful_count, funny_count, sad_count, angry_count, created_at")
+		}).
+		Preload("Neighbor.Category").
+		Preload("Neighbor.Type").
+		Preload("Neighbor.Author").
+		Find(&neighbors)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return neighbors, nil
+}
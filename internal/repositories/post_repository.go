@@ -2,7 +2,9 @@ package repositories
 
 import (
 	"fmt"
+	"strings"
 
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/dtos"
 	"final-project-backend/internal/models"
 
@@ -22,7 +24,12 @@ type IPostRepository interface {
 	) (int64, error)
 	GetTypes() ([]*models.PostType, error)
 	GetCategories() ([]*models.Category, error)
+	InsertType(postType *models.PostType) (*models.PostType, error)
+	InsertCategory(category *models.Category) (*models.Category, error)
 	GetTopLikedAndSharedPost(structConditions *models.Post) ([]*models.Post, error)
+	GetSitemapEntries(offset int, limit int) ([]*models.Post, error)
+	GetRelatedPosts(post *models.Post, limit int) ([]*models.Post, error)
+	ReconcileCounters() (int64, error)
 }
 
 type postRepository struct {
@@ -40,9 +47,18 @@ func NewPostRepository(c *PostRepositoryConfig) IPostRepository {
 }
 
 func (r *postRepository) Insert(post *models.Post) (*models.Post, error) {
-	result := r.db.Create(&post)
-	if result.Error != nil {
-		return nil, result.Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, tag := range post.Tags {
+			result := tx.Where(models.Tag{Name: tag.Name}).FirstOrCreate(tag)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		return tx.Create(&post).Error
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return post, nil
@@ -73,15 +89,21 @@ func (r *postRepository) UpdateSingleColumn(
 func (r *postRepository) GetAll(query *dtos.PostsRequestQuery) ([]*models.Post, error) {
 	var posts []*models.Post
 
-	result := r.db.
-		Select("posts.id, title, slug, summary, img_thumbnail, author_name, share_count, like_count, created_at").
-		Where("posts.title ILIKE ?", "%"+query.Search+"%").
-		Where(&models.Post{CategoryID: query.CategoryID, TypeID: query.TypeID}).
-		Order(fmt.Sprintf("posts.created_at %s", query.Sort)).
-		Offset((query.Page - 1) * query.Limit).
-		Limit(query.Limit).
+	db := r.filterByQuery(r.db, query).
+		Select("posts.id, posts.title, posts.slug, posts.summary, posts.img_thumbnail, posts.author_id, posts.share_count, posts.like_count, posts.reaction_count, posts.insightful_count, posts.funny_count, posts.sad_count, posts.angry_count, posts.created_at")
+
+	if query.IsCursorMode {
+		db = r.paginateByCursor(db, query)
+	} else {
+		db = r.sortByQuery(db, query).
+			Offset((query.Page - 1) * query.Limit).
+			Limit(query.Limit)
+	}
+
+	result := db.
 		Joins("Category").
 		Joins("Type").
+		Joins("Author").
 		Find(&posts)
 
 	if result.Error != nil {
@@ -98,6 +120,8 @@ func (r *postRepository) GetByID(id int64) (*models.Post, error) {
 		Where("posts.id = ?", id).
 		Joins("Category").
 		Joins("Type").
+		Joins("Author").
+		Preload("Tags").
 		First(&post)
 
 	if result.Error != nil {
@@ -125,9 +149,7 @@ func (r *postRepository) CountByQuery(
 ) (int64, error) {
 	var totalRows int64
 
-	result := r.db.Model(&models.Post{}).
-		Where("posts.title ILIKE ?", "%"+query.Search+"%").
-		Where(&models.Post{CategoryID: query.CategoryID, TypeID: query.TypeID}).
+	result := r.filterByQuery(r.db.Model(&models.Post{}), query).
 		Count(&totalRows)
 
 	if result.Error != nil {
@@ -163,10 +185,78 @@ func (r *postRepository) GetCategories() ([]*models.Category, error) {
 	return Categories, nil
 }
 
+func (r *postRepository) InsertType(postType *models.PostType) (*models.PostType, error) {
+	result := r.db.Create(&postType)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return postType, nil
+}
+
+func (r *postRepository) InsertCategory(category *models.Category) (*models.Category, error) {
+	result := r.db.Create(&category)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return category, nil
+}
+
 func (r *postRepository) GetTopLikedAndSharedPost(structConditions *models.Post) ([]*models.Post, error) {
 	var posts []*models.Post
 
-	result := r.db.Select("DISTINCT ON (type_id) *").Where(structConditions).Joins("Category").Joins("Type").Order("type_id ASC, like_count + share_count DESC").
+	result := r.db.Select("DISTINCT ON (type_id) *").Where(structConditions).Joins("Category").Joins("Type").Joins("Author").Order("type_id ASC, like_count + share_count DESC").
+		Find(&posts)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return posts, nil
+}
+
+func (r *postRepository) GetSitemapEntries(offset int, limit int) ([]*models.Post, error) {
+	var posts []*models.Post
+
+	result := r.db.
+		Select("id, slug, created_at, updated_at").
+		Order("id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&posts)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return posts, nil
+}
+
+// GetRelatedPosts scores every other post against the given one by shared
+// category, shared tags, title/summary term overlap and co-readership, and
+// returns the best matches first. Posts with no signal at all are left out.
+func (r *postRepository) GetRelatedPosts(post *models.Post, limit int) ([]*models.Post, error) {
+	var posts []*models.Post
+
+	score := clause.Expr{
+		SQL: `(CASE WHEN posts.category_id = ? THEN 3 ELSE 0 END)
+			+ 2 * (SELECT COUNT(*) FROM post_tags AS pt1 JOIN post_tags AS pt2 ON pt1.tag_id = pt2.tag_id WHERE pt1.post_id = ? AND pt2.post_id = posts.id)
+			+ 5 * ts_rank(to_tsvector('simple', posts.title || ' ' || posts.summary), replace(plainto_tsquery('simple', ?)::text, '&', '|')::tsquery)
+			+ 2 * LN(1 + (SELECT COUNT(*) FROM histories AS h1 JOIN histories AS h2 ON h1.user_id = h2.user_id WHERE h1.post_id = ? AND h2.post_id = posts.id AND h1.deleted_at IS NULL AND h2.deleted_at IS NULL))`,
+		Vars: []interface{}{post.CategoryID, post.ID, post.Title + " " + post.Summary, post.ID},
+	}
+
+	result := r.db.
+		Select("posts.id, posts.title, posts.slug, posts.summary, posts.img_thumbnail, posts.author_id, posts.share_count, posts.like_count, posts.reaction_count, posts.insightful_count, posts.funny_count, posts.sad_count, posts.angry_count, posts.created_at").
+		Where("posts.id <> ?", post.ID).
+		Where("? > 0", score).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "? DESC", Vars: []interface{}{score}}}).
+		Order("posts.created_at DESC").
+		Limit(limit).
+		Joins("Category").
+		Joins("Type").
+		Joins("Author").
 		Find(&posts)
 
 	if result.Error != nil {
@@ -175,3 +265,129 @@ func (r *postRepository) GetTopLikedAndSharedPost(structConditions *models.Post)
 
 	return posts, nil
 }
+
+// ReconcileCounters recomputes like, share and reaction counts from
+// histories and rewrites only the posts whose stored counters have drifted.
+func (r *postRepository) ReconcileCounters() (int64, error) {
+	columns := []string{"like_count", "share_count", "reaction_count"}
+	counts := []string{
+		"COUNT(histories.post_id) FILTER (WHERE histories.is_liked) AS like_count",
+		"COUNT(histories.post_id) FILTER (WHERE histories.is_shared) AS share_count",
+		"COUNT(histories.post_id) FILTER (WHERE histories.reaction <> '') AS reaction_count",
+	}
+	for _, reaction := range constants.REACTIONS {
+		columns = append(columns, reaction.CountColumn())
+		counts = append(counts, fmt.Sprintf("COUNT(histories.post_id) FILTER (WHERE histories.reaction = '%s') AS %s", reaction, reaction.CountColumn()))
+	}
+
+	assignments := []string{}
+	drifts := []string{}
+	for _, column := range columns {
+		assignments = append(assignments, fmt.Sprintf("%s = counts.%s", column, column))
+		drifts = append(drifts, fmt.Sprintf("posts.%s IS DISTINCT FROM counts.%s", column, column))
+	}
+
+	result := r.db.Exec(fmt.Sprintf(`UPDATE posts SET %s
+		FROM (
+			SELECT posts.id, %s
+			FROM posts
+			LEFT JOIN histories ON histories.post_id = posts.id AND histories.deleted_at IS NULL
+			GROUP BY posts.id
+		) AS counts
+		WHERE posts.id = counts.id AND (%s)`,
+		strings.Join(assignments, ", "),
+		strings.Join(counts, ", "),
+		strings.Join(drifts, " OR "),
+	))
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *postRepository) filterByQuery(db *gorm.DB, query *dtos.PostsRequestQuery) *gorm.DB {
+	db = db.Where("posts.title ILIKE ?", "%"+query.Search+"%")
+
+	if len(query.CategoryIDs) > 0 {
+		db = db.Where("posts.category_id IN ?", query.CategoryIDs)
+	}
+
+	if len(query.TypeIDs) > 0 {
+		db = db.Where("posts.type_id IN ?", query.TypeIDs)
+	}
+
+	if len(query.ExcludedCategoryIDs) > 0 {
+		db = db.Where("posts.category_id NOT IN ?", query.ExcludedCategoryIDs)
+	}
+
+	if query.AuthorID != 0 {
+		db = db.Where("posts.author_id = ?", query.AuthorID)
+	}
+
+	if query.DateFrom != nil {
+		db = db.Where("posts.created_at >= ?", *query.DateFrom)
+	}
+
+	if query.DateTo != nil {
+		db = db.Where("posts.created_at < ?", *query.DateTo)
+	}
+
+	if query.FollowerID != 0 {
+		db = db.Where("posts.author_id IN (?)", r.db.Model(&models.AuthorFollower{}).Select("author_id").Where("user_id = ?", query.FollowerID))
+	}
+
+	if query.UnreadByUserID != 0 {
+		db = db.Where("posts.id NOT IN (?)", r.db.Model(&models.History{}).Select("post_id").Where("user_id = ?", query.UnreadByUserID))
+	}
+
+	return db
+}
+
+func (r *postRepository) sortByQuery(db *gorm.DB, query *dtos.PostsRequestQuery) *gorm.DB {
+	switch query.Sort {
+	case constants.SORT_OLDEST:
+		return db.Order("posts.created_at ASC, posts.id ASC")
+	case constants.SORT_MOST_LIKED:
+		return db.Order("posts.like_count DESC, posts.created_at DESC")
+	case constants.SORT_MOST_SHARED:
+		return db.Order("posts.share_count DESC, posts.created_at DESC")
+	case constants.SORT_MOST_REACTED:
+		return db.Order("posts.reaction_count DESC, posts.created_at DESC")
+	case constants.SORT_MOST_READ:
+		return db.Order("(SELECT COUNT(*) FROM histories WHERE histories.post_id = posts.id AND histories.deleted_at IS NULL) DESC, posts.created_at DESC")
+	case constants.SORT_RELEVANCE:
+		return db.
+			Order(clause.OrderBy{Expression: clause.Expr{
+				SQL:  "ts_rank(to_tsvector('simple', posts.title || ' ' || posts.summary), plainto_tsquery('simple', ?)) DESC",
+				Vars: []interface{}{query.Search},
+			}}).
+			Order("posts.created_at DESC")
+	default:
+		return db.Order("posts.created_at DESC, posts.id DESC")
+	}
+}
+
+// paginateByCursor fetches one row more than the limit so the caller can tell
+// whether another page exists. When paging backwards the rows come back in
+// reverse display order.
+func (r *postRepository) paginateByCursor(db *gorm.DB, query *dtos.PostsRequestQuery) *gorm.DB {
+	isAscending := query.Sort == constants.SORT_OLDEST
+	if query.Cursor != nil && query.Cursor.IsPrev {
+		isAscending = !isAscending
+	}
+
+	operator, direction := "<", "DESC"
+	if isAscending {
+		operator, direction = ">", "ASC"
+	}
+
+	if query.Cursor != nil {
+		db = db.Where(fmt.Sprintf("(posts.created_at, posts.id) %s (?, ?)", operator), query.Cursor.CreatedAt, query.Cursor.ID)
+	}
+
+	return db.
+		Order(fmt.Sprintf("posts.created_at %s, posts.id %s", direction, direction)).
+		Limit(query.Limit + 1)
+}
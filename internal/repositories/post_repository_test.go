@@ -246,10 +246,13 @@ func Test_postRepository_GetByID(t *testing.T) {
 				mock.ExpectQuery("SELECT (.+)").
 					WithArgs(mockID).
 					WillReturnRows(sqlmock.NewRows(([]string{"id"})).AddRow(mockID))
+				mock.ExpectQuery("SELECT (.+) FROM \"post_tags\"").
+					WithArgs(mockID).
+					WillReturnRows(sqlmock.NewRows(([]string{"post_id", "tag_id"})))
 
 				mock.ExpectExec("").WillReturnResult(sqlmock.NewResult(int64(mockID), 1))
 			},
-			want:        &models.Post{ID: int64(mockID)},
+			want:        &models.Post{ID: int64(mockID), Tags: []*models.Tag{}},
 			wantErr:     false,
 			expectedErr: nil,
 		},
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"final-project-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Entries are written by IUserSubscriptionRepository.UnlockPost together with
+// the debit itself and are never changed afterwards.
+type IQuotaLedgerRepository interface {
+	GetByUserID(userID int64, limit int, page int) ([]*models.QuotaLedger, error)
+	CountByUserID(userID int64) (int64, error)
+}
+
+type quotaLedgerRepository struct {
+	db *gorm.DB
+}
+
+type QuotaLedgerRepositoryConfig struct {
+	db *gorm.DB
+}
+
+func NewQuotaLedgerRepository(c *QuotaLedgerRepositoryConfig) IQuotaLedgerRepository {
+	return &quotaLedgerRepository{db: c.db}
+}
+
+func (r *quotaLedgerRepository) GetByUserID(userID int64, limit int, page int) ([]*models.QuotaLedger, error) {
+	var entries []*models.QuotaLedger
+
+	result := r.db.
+		Joins("Post").
+		Preload("UserSubscription.Subscription").
+		Where("quota_ledgers.user_id = ?", userID).
+		Order("quota_ledgers.created_at DESC, quota_ledgers.id DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&entries)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return entries, nil
+}
+
+func (r *quotaLedgerRepository) CountByUserID(userID int64) (int64, error) {
+	var totalRows int64
+
+	result := r.db.Model(&models.QuotaLedger{}).Where("user_id = ?", userID).Count(&totalRows)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return totalRows, nil
+}
@@ -15,6 +15,14 @@ type Repositories struct {
 	Vouchers          IVoucherRepository
 	UserVouchers      IUserVoucherRepository
 	UserSpendings     IUserSpendingRepository
+	Authors           IAuthorRepository
+	ShareLinks        IShareLinkRepository
+	Trending          ITrendingRepository
+	PostNeighbors     IPostNeighborRepository
+	Achievements      IAchievementRepository
+	QuotaLedgers      IQuotaLedgerRepository
+	Notifications     INotificationRepository
+	Trials            ITrialRepository
 }
 
 func New(db *gorm.DB) *Repositories {
@@ -47,5 +55,29 @@ func New(db *gorm.DB) *Repositories {
 		UserSpendings: NewUserSpendingRepository(&UserSpendingRepositoryConfig{
 			db: db,
 		}),
+		Authors: NewAuthorRepository(&AuthorRepositoryConfig{
+			db: db,
+		}),
+		ShareLinks: NewShareLinkRepository(&ShareLinkRepositoryConfig{
+			db: db,
+		}),
+		Trending: NewTrendingRepository(&TrendingRepositoryConfig{
+			db: db,
+		}),
+		PostNeighbors: NewPostNeighborRepository(&PostNeighborRepositoryConfig{
+			db: db,
+		}),
+		Achievements: NewAchievementRepository(&AchievementRepositoryConfig{
+			db: db,
+		}),
+		QuotaLedgers: NewQuotaLedgerRepository(&QuotaLedgerRepositoryConfig{
+			db: db,
+		}),
+		Notifications: NewNotificationRepository(&NotificationRepositoryConfig{
+			db: db,
+		}),
+		Trials: NewTrialRepository(&TrialRepositoryConfig{
+			db: db,
+		}),
 	}
 }
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"time"
+
+	"final-project-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IShareLinkRepository interface {
+	InsertWithinLimit(shareLink *models.ShareLink, since time.Time, limit int) (int64, bool, error)
+	GetByID(id int64) (*models.ShareLink, error)
+	InsertRedemption(shareLink *models.ShareLink, redemption *models.ShareLinkRedemption) (bool, error)
+}
+
+type shareLinkRepository struct {
+	db *gorm.DB
+}
+
+type ShareLinkRepositoryConfig struct {
+	db *gorm.DB
+}
+
+func NewShareLinkRepository(c *ShareLinkRepositoryConfig) IShareLinkRepository {
+	return &shareLinkRepository{
+		db: c.db,
+	}
+}
+
+// InsertWithinLimit creates the link unless the member already created limit
+// links since the given time. The member's row is locked so concurrent
+// requests are counted one after the other. It returns how many links the
+// member had before this one and whether it was created.
+func (r *shareLinkRepository) InsertWithinLimit(shareLink *models.ShareLink, since time.Time, limit int) (int64, bool, error) {
+	var total int64
+	isCreated := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", shareLink.UserID).
+			First(&user)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Model(&models.ShareLink{}).
+			Where("user_id = ? AND created_at >= ?", shareLink.UserID, since).
+			Count(&total)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if total >= int64(limit) {
+			return nil
+		}
+
+		result = tx.Omit("Post").Create(&shareLink)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		isCreated = true
+
+		return nil
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	return total, isCreated, nil
+}
+
+func (r *shareLinkRepository) GetByID(id int64) (*models.ShareLink, error) {
+	var shareLink *models.ShareLink
+
+	result := r.db.Where("id = ?", id).First(&shareLink)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return shareLink, nil
+}
+
+// InsertRedemption records the visitor once per share link and bumps the
+// counters on the link and the post in the same transaction. It reports
+// whether the redemption was new.
+func (r *shareLinkRepository) InsertRedemption(shareLink *models.ShareLink, redemption *models.ShareLinkRedemption) (bool, error) {
+	isNew := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&redemption)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		isNew = true
+
+		result = tx.Model(&models.ShareLink{}).
+			Where("id = ?", shareLink.ID).
+			Update("redemption_count", gorm.Expr("redemption_count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Model(&models.Post{}).
+			Where("id = ?", shareLink.PostID).
+			Update("share_redemption_count", gorm.Expr("share_redemption_count + 1")).
+			Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return isNew, nil
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"final-project-backend/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_shareLinkRepository_InsertWithinLimit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	dia := postgres.New(postgres.Config{
+		DriverName: "postgres",
+		Conn:       db,
+	})
+	DB, err := gorm.Open(dia)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		mock          func()
+		wantTotal     int64
+		wantIsCreated bool
+	}{
+		{
+			name: "SUCCESS | Limit reached",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \"id\" FROM \"users\" (.+) FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT count(.+) FROM \"share_links\"").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+				mock.ExpectCommit()
+			},
+			wantTotal:     5,
+			wantIsCreated: false,
+		},
+		{
+			name: "SUCCESS | Created under the limit",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \"id\" FROM \"users\" (.+) FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectQuery("SELECT count(.+) FROM \"share_links\"").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+				mock.ExpectQuery("INSERT INTO \"share_links\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectCommit()
+			},
+			wantTotal:     4,
+			wantIsCreated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &shareLinkRepository{
+				db: DB,
+			}
+
+			tt.mock()
+			total, isCreated, err := r.InsertWithinLimit(&models.ShareLink{
+				UserID:    1,
+				PostID:    2,
+				ExpiresAt: time.Now().Add(time.Hour),
+			}, time.Now().AddDate(0, -1, 0), 5)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, total)
+			assert.Equal(t, tt.wantIsCreated, isCreated)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,14 +1,25 @@
 package repositories
 
 import (
+	"time"
+
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ISubscriptionRepository interface {
 	GetAll() ([]*models.Subscription, error)
+	GetAllVersions() ([]*models.Subscription, error)
 	GetByID(id int64) (*models.Subscription, error)
+	GetCurrentByPlanID(planID int64) (*models.Subscription, error)
+	GetCurrentTrial() (*models.Subscription, error)
+	Insert(subscription *models.Subscription) (*models.Subscription, error)
+	InsertVersion(subscription *models.Subscription) (*models.Subscription, error)
+	Rename(id int64, name string) (*models.Subscription, int, error)
+	Retire(planID int64) (int, error)
 }
 
 type subscriptionRepository struct {
@@ -25,9 +36,25 @@ func NewSubscriptionRepository(c *SubscriptionRepositoryConfig) ISubscriptionRep
 	}
 }
 
+// GetAll returns the plans currently on sale.
 func (r *subscriptionRepository) GetAll() ([]*models.Subscription, error) {
 	var subscriptions []*models.Subscription
-	result := r.db.Find(&subscriptions)
+	result := r.db.
+		Where("superseded_at IS NULL AND retired_at IS NULL").
+		Where("type = ?", constants.PLAN_PAID).
+		Order("price asc, id asc").
+		Find(&subscriptions)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return subscriptions, nil
+}
+
+func (r *subscriptionRepository) GetAllVersions() ([]*models.Subscription, error) {
+	var subscriptions []*models.Subscription
+	result := r.db.Order("plan_id asc, version asc").Find(&subscriptions)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -49,3 +76,110 @@ func (r *subscriptionRepository) GetByID(id int64) (*models.Subscription, error)
 
 	return subscription, nil
 }
+
+func (r *subscriptionRepository) GetCurrentByPlanID(planID int64) (*models.Subscription, error) {
+	var subscription *models.Subscription
+
+	result := r.db.
+		Where("plan_id = ? AND superseded_at IS NULL", planID).
+		First(&subscription)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return subscription, nil
+}
+
+// GetCurrentTrial returns the trial plan new members get. Should there be more
+// than one, the newest wins.
+func (r *subscriptionRepository) GetCurrentTrial() (*models.Subscription, error) {
+	var subscription *models.Subscription
+
+	result := r.db.
+		Where("type = ? AND superseded_at IS NULL AND retired_at IS NULL", constants.PLAN_TRIAL).
+		Order("id desc").
+		First(&subscription)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return subscription, nil
+}
+
+// Insert creates the first version of a new plan.
+func (r *subscriptionRepository) Insert(subscription *models.Subscription) (*models.Subscription, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		subscription.Version = 1
+
+		result := tx.Create(subscription)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		subscription.PlanID = subscription.ID
+
+		return tx.Model(subscription).UpdateColumn("plan_id", subscription.PlanID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// InsertVersion supersedes the current version of subscription.PlanID with
+// subscription. Retired plans are reported as not found.
+func (r *subscriptionRepository) InsertVersion(subscription *models.Subscription) (*models.Subscription, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current *models.Subscription
+
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("plan_id = ? AND superseded_at IS NULL AND retired_at IS NULL", subscription.PlanID).
+			First(&current)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Model(current).UpdateColumn("superseded_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+
+		subscription.ID = 0
+		subscription.Version = current.Version + 1
+
+		return tx.Create(subscription).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Rename changes the name of a version in place, as it doesn't change what
+// was sold.
+func (r *subscriptionRepository) Rename(id int64, name string) (*models.Subscription, int, error) {
+	subscription := &models.Subscription{ID: id}
+
+	result := r.db.Model(subscription).Clauses(clause.Returning{}).Update("name", name)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	return subscription, int(result.RowsAffected), nil
+}
+
+func (r *subscriptionRepository) Retire(planID int64) (int, error) {
+	result := r.db.Model(&models.Subscription{}).
+		Where("plan_id = ? AND superseded_at IS NULL AND retired_at IS NULL", planID).
+		UpdateColumn("retired_at", time.Now())
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(result.RowsAffected), nil
+}
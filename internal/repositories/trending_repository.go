@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"math"
+	"time"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ITrendingRepository interface {
+	Refresh() (int64, error)
+	GetPosts(categoryID int64, limit int) ([]*models.Post, error)
+}
+
+type trendingRepository struct {
+	db *gorm.DB
+}
+
+type TrendingRepositoryConfig struct {
+	db *gorm.DB
+}
+
+func NewTrendingRepository(c *TrendingRepositoryConfig) ITrendingRepository {
+	return &trendingRepository{
+		db: c.db,
+	}
+}
+
+// Refresh rebuilds every score from the histories inside the trending window.
+// Each history contributes its read, like and share weights, decayed by the
+// age of the member's last interaction with the post.
+func (r *trendingRepository) Refresh() (int64, error) {
+	var rowsAffected int64
+	now := time.Now()
+	decayPerSecond := math.Ln2 / constants.TRENDING_HALF_LIFE.Seconds()
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("DELETE FROM trending_scores")
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Exec(`INSERT INTO trending_scores (post_id, score, refreshed_at)
+			SELECT histories.post_id,
+				SUM((?::float8 + CASE WHEN histories.is_liked THEN ?::float8 ELSE 0 END + CASE WHEN histories.is_shared THEN ?::float8 ELSE 0 END)
+					* EXP(-?::float8 * EXTRACT(EPOCH FROM (?::timestamptz - histories.last_accessed)))),
+				?
+			FROM histories
+			JOIN posts ON posts.id = histories.post_id AND posts.deleted_at IS NULL
+			WHERE histories.deleted_at IS NULL AND histories.last_accessed >= ?
+			GROUP BY histories.post_id`,
+			constants.TRENDING_READ_WEIGHT,
+			constants.TRENDING_LIKE_WEIGHT,
+			constants.TRENDING_SHARE_WEIGHT,
+			decayPerSecond,
+			now,
+			now,
+			now.Add(-constants.TRENDING_WINDOW),
+		)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		rowsAffected = result.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
+
+// GetPosts returns the top scored posts, across all categories when
+// categoryID is zero.
+func (r *trendingRepository) GetPosts(categoryID int64, limit int) ([]*models.Post, error) {
+	var posts []*models.Post
+
+	db := r.db.
+		Select("posts.id, posts.title, posts.slug, posts.summary, posts.img_thumbnail, posts.author_id, posts.share_count, posts.like_count, posts.reaction_count, posts.insightful_count, posts.funny_count, posts.sad_count, posts.angry_count, posts.created_at").
+		Joins("JOIN trending_scores ON trending_scores.post_id = posts.id")
+
+	if categoryID != 0 {
+		db = db.Where("posts.category_id = ?", categoryID)
+	}
+
+	result := db.
+		Order("trending_scores.score DESC, posts.created_at DESC").
+		Limit(limit).
+		Joins("Category").
+		Joins("Type").
+		Joins("Author").
+		Find(&posts)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return posts, nil
+}
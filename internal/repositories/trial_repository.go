@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"time"
+
+	"final-project-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ITrialRepository interface {
+	Grant(trialGrant *models.TrialGrant, userSubscription *models.UserSubscriptions) (bool, error)
+	GetEndingSoon(endsBefore time.Time) ([]*models.TrialGrant, error)
+	GetExpired() ([]*models.TrialGrant, error)
+	SetReminded(id int64) error
+	SetExpiryNotified(id int64) error
+	MarkConverted(userID int64, invoiceID int64) (int64, error)
+	GetStats() (*models.TrialStats, error)
+}
+
+type trialRepository struct {
+	db *gorm.DB
+}
+
+type TrialRepositoryConfig struct {
+	db *gorm.DB
+}
+
+func NewTrialRepository(c *TrialRepositoryConfig) ITrialRepository {
+	return &trialRepository{db: c.db}
+}
+
+// Grant records the trial and starts its subscription in one transaction. It
+// returns false without starting anything if the member or their identity
+// already had a trial.
+func (r *trialRepository) Grant(trialGrant *models.TrialGrant, userSubscription *models.UserSubscriptions) (bool, error) {
+	isGranted := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(trialGrant)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		result = tx.Create(userSubscription)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		userSubscriptionID := int64(userSubscription.ID)
+		trialGrant.UserSubscriptionID = &userSubscriptionID
+		isGranted = true
+
+		return tx.Model(trialGrant).UpdateColumn("user_subscription_id", userSubscriptionID).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return isGranted, nil
+}
+
+func (r *trialRepository) GetEndingSoon(endsBefore time.Time) ([]*models.TrialGrant, error) {
+	var trialGrants []*models.TrialGrant
+
+	result := r.db.
+		Where("converted_at IS NULL AND reminded_at IS NULL").
+		Where("expires_at > ? AND expires_at <= ?", time.Now(), endsBefore).
+		Order("expires_at asc").
+		Find(&trialGrants)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return trialGrants, nil
+}
+
+func (r *trialRepository) GetExpired() ([]*models.TrialGrant, error) {
+	var trialGrants []*models.TrialGrant
+
+	result := r.db.
+		Where("converted_at IS NULL AND expiry_notified_at IS NULL").
+		Where("expires_at <= ?", time.Now()).
+		Order("expires_at asc").
+		Find(&trialGrants)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return trialGrants, nil
+}
+
+func (r *trialRepository) SetReminded(id int64) error {
+	return r.db.Model(&models.TrialGrant{}).Where("id = ?", id).UpdateColumn("reminded_at", time.Now()).Error
+}
+
+func (r *trialRepository) SetExpiryNotified(id int64) error {
+	return r.db.Model(&models.TrialGrant{}).Where("id = ?", id).UpdateColumn("expiry_notified_at", time.Now()).Error
+}
+
+// MarkConverted attributes the member's first paid invoice to their trial.
+func (r *trialRepository) MarkConverted(userID int64, invoiceID int64) (int64, error) {
+	result := r.db.Model(&models.TrialGrant{}).
+		Where("user_id = ? AND converted_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"converted_at":         time.Now(),
+			"converted_invoice_id": invoiceID,
+		})
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *trialRepository) GetStats() (*models.TrialStats, error) {
+	var stats models.TrialStats
+
+	result := r.db.Model(&models.TrialGrant{}).
+		Select(`COUNT(*) AS granted,
+			COUNT(*) FILTER (WHERE converted_at IS NULL AND expires_at > @now) AS active,
+			COUNT(converted_at) AS converted,
+			COUNT(*) FILTER (WHERE converted_at IS NULL AND expires_at <= @now) AS expired`,
+			map[string]interface{}{"now": time.Now()}).
+		Scan(&stats)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &stats, nil
+}
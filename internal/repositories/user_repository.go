@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"final-project-backend/internal/models"
 
 	"gorm.io/gorm"
@@ -15,6 +17,7 @@ type IUserRepository interface {
 	GetUsersReferredByID(id int64) ([]*models.User, error)
 	GetUsersReferredTotalSpending(id int64) ([]*models.UserSpendingTotalAggregates, error)
 	Update(user *models.User) (*models.User, int, error)
+	MarkEmailVerified(id int64, email string) (*models.User, int, error)
 }
 
 type userRepository struct {
@@ -116,3 +119,19 @@ func (r *userRepository) Update(user *models.User) (*models.User, int, error) {
 
 	return user, int(result.RowsAffected), nil
 }
+
+// MarkEmailVerified only verifies the member if their email is still the one
+// the link was sent to and it has not been verified yet.
+func (r *userRepository) MarkEmailVerified(id int64, email string) (*models.User, int, error) {
+	var user *models.User
+
+	result := r.db.Model(&user).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND email = ? AND email_verified_at IS NULL", id, email).
+		Update("email_verified_at", time.Now())
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	return user, int(result.RowsAffected), nil
+}
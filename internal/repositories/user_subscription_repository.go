@@ -3,9 +3,12 @@ package repositories
 import (
 	"time"
 
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/dtos"
 	"final-project-backend/internal/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type IUserSubscriptionRepository interface {
@@ -13,7 +16,18 @@ type IUserSubscriptionRepository interface {
 	GetOngoingUserSubscriptions(userID int64) ([]*models.UserSubscriptions, error)
 	GetAllUserSubscriptions(userID int64) ([]*models.UserSubscriptions, error)
 	Update(userSubscription *models.UserSubscriptions) (*models.UserSubscriptions, error)
-	DecrementQuota(userSubscription *models.UserSubscriptions, quota int) (*models.UserSubscriptions, error)
+	UnlockPost(userID int64, postID int64, quota int) ([]*models.QuotaLedger, bool, error)
+	GetByID(id int64) (*models.UserSubscriptions, error)
+	GetDueForRenewal(endedAfter time.Time, endsBefore time.Time) ([]*models.UserSubscriptions, error)
+	SetAutoRenew(userID int64, id int64, autoRenew bool) (int64, error)
+	SetGraceEndsAt(id int64, graceEndsAt *time.Time) error
+	Renew(id int64, subscriptionID int64, dateEnded time.Time, quota int) error
+	Pause(id int64, days int) (int64, error)
+	Resume(id int64) (int64, error)
+	Cancel(id int64) (int64, error)
+	ChangePlan(id int64, userSubscription *models.UserSubscriptions, policy constants.QuotaCarryOverPolicy) (*models.UserSubscriptions, bool, error)
+	GetByQuery(query *dtos.UserSubscriptionsRequestQuery) ([]*models.UserSubscriptions, error)
+	CountByQuery(query *dtos.UserSubscriptionsRequestQuery) (int64, error)
 }
 
 type userSubscriptionRepository struct {
@@ -57,7 +71,8 @@ func (r *userSubscriptionRepository) GetOngoingUserSubscriptions(userID int64) (
 
 	result := r.db.
 		Where("user_id = ?", userID).
-		Where("date_ended > ?", time.Now()).
+		Where("(date_ended > ? OR grace_ends_at > ?)", time.Now(), time.Now()).
+		Where("(pause_ends_at IS NULL OR pause_ends_at <= ?)", time.Now()).
 		Where("remaining_quota > ?", 0).
 		Order("date_ended asc, remaining_quota desc").
 		Find(&userSubscriptions)
@@ -78,11 +93,362 @@ func (r *userSubscriptionRepository) Update(userSubscription *models.UserSubscri
 	return userSubscription, nil
 }
 
-func (r *userSubscriptionRepository) DecrementQuota(userSubscription *models.UserSubscriptions, quota int) (*models.UserSubscriptions, error) {
-	result := r.db.Model(userSubscription).Update("remaining_quota", userSubscription.RemainingQuota-quota)
+func (r *userSubscriptionRepository) GetByID(id int64) (*models.UserSubscriptions, error) {
+	var userSubscription *models.UserSubscriptions
+
+	result := r.db.Joins("Subscription").Where("user_subscriptions.id = ?", id).First(&userSubscription)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
 	return userSubscription, nil
 }
+
+// GetDueForRenewal returns auto-renewing subscriptions ending between the two
+// times that have no renewal invoice for their current period still open or paid.
+func (r *userSubscriptionRepository) GetDueForRenewal(endedAfter time.Time, endsBefore time.Time) ([]*models.UserSubscriptions, error) {
+	var userSubscriptions []*models.UserSubscriptions
+
+	result := r.db.
+		Joins("Subscription").
+		Where("user_subscriptions.auto_renew = ?", true).
+		Where("user_subscriptions.date_ended > ? AND user_subscriptions.date_ended <= ?", endedAfter, endsBefore).
+		Where("(user_subscriptions.pause_ends_at IS NULL OR user_subscriptions.pause_ends_at <= ?)", time.Now()).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM invoices
+			WHERE invoices.renewal_of_id = user_subscriptions.id
+			AND invoices.due_at = user_subscriptions.date_ended
+			AND invoices.status IN ?
+			AND invoices.deleted_at IS NULL
+		)`, []models.InvoiceStatus{models.WAITING, models.PROCESSED, models.COMPLETED}).
+		Order("user_subscriptions.date_ended asc").
+		Find(&userSubscriptions)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return userSubscriptions, nil
+}
+
+func (r *userSubscriptionRepository) SetAutoRenew(userID int64, id int64, autoRenew bool) (int64, error) {
+	result := r.db.Model(&models.UserSubscriptions{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Updates(map[string]interface{}{
+			"auto_renew": autoRenew,
+			// Turning auto-renew back on undoes a cancellation.
+			"cancelled_at": gorm.Expr("CASE WHEN ? THEN NULL ELSE cancelled_at END", autoRenew),
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *userSubscriptionRepository) SetGraceEndsAt(id int64, graceEndsAt *time.Time) error {
+	return r.db.Model(&models.UserSubscriptions{}).
+		Where("id = ?", id).
+		Update("grace_ends_at", graceEndsAt).
+		Error
+}
+
+// Renew moves the subscription to its next period at the given plan version.
+// Quota left over from the period just paid for is kept.
+func (r *userSubscriptionRepository) Renew(id int64, subscriptionID int64, dateEnded time.Time, quota int) error {
+	return r.db.Model(&models.UserSubscriptions{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"subscription_id": subscriptionID,
+			"date_ended":      dateEnded,
+			"remaining_quota": gorm.Expr("remaining_quota + ?", quota),
+			"grace_ends_at":   nil,
+			"paused_days":     0,
+		}).
+		Error
+}
+
+// Pause freezes a running subscription for the given number of days. It is
+// refused while the subscription is already paused, has an open renewal or
+// would go over SUBSCRIPTION_MAX_PAUSE_DAYS in this period.
+func (r *userSubscriptionRepository) Pause(id int64, days int) (int64, error) {
+	now := time.Now()
+
+	result := r.db.Model(&models.UserSubscriptions{}).
+		Where("id = ?", id).
+		Where("date_ended > ?", now).
+		Where("grace_ends_at IS NULL").
+		Where("(pause_ends_at IS NULL OR pause_ends_at <= ?)", now).
+		Where("paused_days + ? <= ?", days, constants.SUBSCRIPTION_MAX_PAUSE_DAYS).
+		Updates(map[string]interface{}{
+			"paused_at":     now,
+			"pause_ends_at": now.AddDate(0, 0, days),
+			"date_ended":    gorm.Expr("date_ended + make_interval(days => ?)", days),
+			"paused_days":   gorm.Expr("paused_days + ?", days),
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// Resume ends a pause early and gives back the days it did not use.
+func (r *userSubscriptionRepository) Resume(id int64) (int64, error) {
+	now := time.Now()
+
+	result := r.db.Model(&models.UserSubscriptions{}).
+		Where("id = ?", id).
+		Where("pause_ends_at > ?", now).
+		Updates(map[string]interface{}{
+			"date_ended":    gorm.Expr("date_ended - (pause_ends_at - ?::timestamptz)", now),
+			"paused_days":   gorm.Expr("paused_days - floor(extract(epoch FROM pause_ends_at - ?::timestamptz) / 86400)::int", now),
+			"paused_at":     nil,
+			"pause_ends_at": nil,
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// Cancel turns auto-renew off for good and expires a renewal invoice that is
+// still waiting for payment, so the subscription simply runs out at DateEnded.
+func (r *userSubscriptionRepository) Cancel(id int64) (int64, error) {
+	var rowsAffected int64
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.UserSubscriptions{}).
+			Where("id = ? AND cancelled_at IS NULL", id).
+			Updates(map[string]interface{}{
+				"auto_renew":    false,
+				"cancelled_at":  time.Now(),
+				"grace_ends_at": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		rowsAffected = result.RowsAffected
+		if rowsAffected == 0 {
+			return nil
+		}
+
+		return tx.Model(&models.Invoice{}).
+			Where("renewal_of_id = ? AND status = ?", id, models.WAITING).
+			Update("status", models.EXPIRED).
+			Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
+
+// ChangePlan ends the subscription with the given id and starts the new one in
+// its place, carrying remaining quota over according to the policy. It returns
+// false if the subscription was already replaced.
+func (r *userSubscriptionRepository) ChangePlan(id int64, userSubscription *models.UserSubscriptions, policy constants.QuotaCarryOverPolicy) (*models.UserSubscriptions, bool, error) {
+	isChanged := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current models.UserSubscriptions
+
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			First(&current)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if current.ReplacedByID != nil {
+			return nil
+		}
+
+		switch policy {
+		case constants.QUOTA_CARRY_OVER_FULL:
+			userSubscription.RemainingQuota += current.RemainingQuota
+		case constants.QUOTA_CARRY_OVER_CAPPED:
+			// At most one new plan's worth of quota is carried over.
+			carriedQuota := current.RemainingQuota
+			if carriedQuota > userSubscription.RemainingQuota {
+				carriedQuota = userSubscription.RemainingQuota
+			}
+			userSubscription.RemainingQuota += carriedQuota
+		}
+		userSubscription.AutoRenew = current.AutoRenew
+
+		result = tx.Create(userSubscription)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		replacedByID := int64(userSubscription.ID)
+		result = tx.Model(&models.UserSubscriptions{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"date_ended":      gorm.Expr("LEAST(date_ended, ?)", userSubscription.DateStarted),
+				"remaining_quota": 0,
+				"auto_renew":      false,
+				"grace_ends_at":   nil,
+				"paused_at":       nil,
+				"pause_ends_at":   nil,
+				"replaced_by_id":  replacedByID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		isChanged = true
+
+		return tx.Model(&models.Invoice{}).
+			Where("renewal_of_id = ? AND status = ?", id, models.WAITING).
+			Update("status", models.EXPIRED).
+			Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return userSubscription, isChanged, nil
+}
+
+func (r *userSubscriptionRepository) GetByQuery(query *dtos.UserSubscriptionsRequestQuery) ([]*models.UserSubscriptions, error) {
+	var userSubscriptions []*models.UserSubscriptions
+
+	result := r.filterByQuery(r.db.Joins("Subscription"), query).
+		Order("user_subscriptions.date_ended asc, user_subscriptions.id asc").
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&userSubscriptions)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return userSubscriptions, nil
+}
+
+func (r *userSubscriptionRepository) CountByQuery(query *dtos.UserSubscriptionsRequestQuery) (int64, error) {
+	var totalRows int64
+
+	result := r.filterByQuery(r.db.Model(&models.UserSubscriptions{}), query).Count(&totalRows)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return totalRows, nil
+}
+
+// filterByQuery mirrors models.UserSubscriptions.Status in SQL.
+func (r *userSubscriptionRepository) filterByQuery(db *gorm.DB, query *dtos.UserSubscriptionsRequestQuery) *gorm.DB {
+	now := time.Now()
+	expired := "user_subscriptions.date_ended <= @now AND (user_subscriptions.grace_ends_at IS NULL OR user_subscriptions.grace_ends_at <= @now)"
+	paused := "user_subscriptions.pause_ends_at > @now"
+	args := map[string]interface{}{"now": now}
+
+	if query.UserID != 0 {
+		db = db.Where("user_subscriptions.user_id = ?", query.UserID)
+	}
+
+	switch query.Status {
+	case constants.SUBSCRIPTION_EXPIRED:
+		db = db.Where(expired, args)
+	case constants.SUBSCRIPTION_PAUSED:
+		db = db.Where("NOT ("+expired+") AND "+paused, args)
+	case constants.SUBSCRIPTION_CANCELLED:
+		db = db.Where("NOT ("+expired+") AND NOT COALESCE("+paused+", false) AND user_subscriptions.cancelled_at IS NOT NULL", args)
+	case constants.SUBSCRIPTION_ACTIVE:
+		db = db.Where("NOT ("+expired+") AND NOT COALESCE("+paused+", false) AND user_subscriptions.cancelled_at IS NULL", args)
+	}
+
+	return db
+}
+
+// UnlockPost charges quota for a post the member has not unlocked yet, taking
+// it from the subscriptions ending first, and writes the debits to the quota
+// ledger and the unlock to the history in the same transaction. The ongoing
+// subscriptions are locked first, so concurrent unlocks by the same member run
+// one after another. The returned bool is false when there is not enough quota.
+func (r *userSubscriptionRepository) UnlockPost(userID int64, postID int64, quota int) ([]*models.QuotaLedger, bool, error) {
+	debits := []*models.QuotaLedger{}
+	isUnlocked := true
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var userSubscriptions []*models.UserSubscriptions
+
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", userID).
+			Where("(date_ended > ? OR grace_ends_at > ?)", time.Now(), time.Now()).
+			Where("(pause_ends_at IS NULL OR pause_ends_at <= ?)", time.Now()).
+			Where("remaining_quota > ?", 0).
+			Order("date_ended asc, remaining_quota desc").
+			Find(&userSubscriptions)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		var unlockCount int64
+		result = tx.Model(&models.History{}).Where("user_id = ? AND post_id = ?", userID, postID).Count(&unlockCount)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if unlockCount > 0 {
+			return nil
+		}
+
+		totalQuota := 0
+		for _, userSubscription := range userSubscriptions {
+			totalQuota += userSubscription.RemainingQuota
+		}
+
+		if totalQuota < quota {
+			isUnlocked = false
+			return nil
+		}
+
+		quotaNeeded := quota
+		for _, userSubscription := range userSubscriptions {
+			if quotaNeeded == 0 {
+				break
+			}
+
+			amount := userSubscription.RemainingQuota
+			if quotaNeeded < amount {
+				amount = quotaNeeded
+			}
+
+			result = tx.Model(&models.UserSubscriptions{}).
+				Where("id = ?", userSubscription.ID).
+				UpdateColumn("remaining_quota", gorm.Expr("remaining_quota - ?", amount))
+			if result.Error != nil {
+				return result.Error
+			}
+
+			debits = append(debits, &models.QuotaLedger{
+				UserID:             userID,
+				UserSubscriptionID: int64(userSubscription.ID),
+				PostID:             postID,
+				Amount:             amount,
+			})
+			quotaNeeded -= amount
+		}
+
+		if len(debits) > 0 {
+			result = tx.Create(&debits)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		return tx.Create(&models.History{UserID: userID, PostID: postID}).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return debits, isUnlocked, nil
+}
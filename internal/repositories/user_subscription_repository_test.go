@@ -2,6 +2,10 @@ package repositories
 
 import (
 	"testing"
+	"time"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/models"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
@@ -24,3 +28,180 @@ func TestNewUserSubscriptionRepository(t *testing.T) {
 		db: DB,
 	})
 }
+
+func Test_userSubscriptionRepository_UnlockPost(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	dia := postgres.New(postgres.Config{
+		DriverName: "postgres",
+		Conn:       db,
+	})
+	DB, err := gorm.Open(dia)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		mock           func()
+		want           []*models.QuotaLedger
+		wantIsUnlocked bool
+	}{
+		{
+			name: "SUCCESS | Already unlocked is not charged",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM \"user_subscriptions\" (.+) FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "remaining_quota"}).AddRow(1, 5))
+				mock.ExpectQuery("SELECT count(.+) FROM \"histories\"").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+			want:           []*models.QuotaLedger{},
+			wantIsUnlocked: true,
+		},
+		{
+			name: "SUCCESS | Not enough quota",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM \"user_subscriptions\" (.+) FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "remaining_quota"}).AddRow(1, 1))
+				mock.ExpectQuery("SELECT count(.+) FROM \"histories\"").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectCommit()
+			},
+			want:           []*models.QuotaLedger{},
+			wantIsUnlocked: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &userSubscriptionRepository{
+				db: DB,
+			}
+
+			tt.mock()
+			got, isUnlocked, err := r.UnlockPost(1, 2, 2)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantIsUnlocked, isUnlocked)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_userSubscriptionRepository_ChangePlan(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	dia := postgres.New(postgres.Config{
+		DriverName: "postgres",
+		Conn:       db,
+	})
+	DB, err := gorm.Open(dia)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		mock          func()
+		policy        constants.QuotaCarryOverPolicy
+		wantQuota     int
+		wantIsChanged bool
+	}{
+		{
+			name: "SUCCESS | Already replaced is not changed again",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM \"user_subscriptions\" (.+) FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "remaining_quota", "replaced_by_id"}).AddRow(1, 8, 2))
+				mock.ExpectCommit()
+			},
+			policy:        constants.QUOTA_CARRY_OVER_FULL,
+			wantQuota:     5,
+			wantIsChanged: false,
+		},
+		{
+			name: "SUCCESS | Carried over quota is capped at the new plan quota",
+			mock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM \"user_subscriptions\" (.+) FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "remaining_quota", "replaced_by_id"}).AddRow(1, 8, nil))
+				mock.ExpectQuery("INSERT INTO \"user_subscriptions\"").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectExec("UPDATE \"user_subscriptions\" SET (.+)").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE \"invoices\" SET \"status\"(.+)").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			policy:        constants.QUOTA_CARRY_OVER_CAPPED,
+			wantQuota:     10,
+			wantIsChanged: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &userSubscriptionRepository{
+				db: DB,
+			}
+
+			tt.mock()
+			got, isChanged, err := r.ChangePlan(1, &models.UserSubscriptions{
+				UserID:         1,
+				SubscriptionID: 3,
+				RemainingQuota: 5,
+				DateStarted:    time.Now(),
+				DateEnded:      time.Now().AddDate(0, 1, 0),
+			}, tt.policy)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantQuota, got.RemainingQuota)
+			assert.Equal(t, tt.wantIsChanged, isChanged)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_userSubscriptionRepository_PauseResumePause(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	dia := postgres.New(postgres.Config{
+		DriverName: "postgres",
+		Conn:       db,
+	})
+	DB, err := gorm.Open(dia)
+	assert.NoError(t, err)
+
+	r := &userSubscriptionRepository{
+		db: DB,
+	}
+
+	pause := func(rowsAffected int64) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE \"user_subscriptions\" SET (.+)\"paused_days\"=paused_days \\+ (.+) WHERE (.+) AND paused_days \\+ (.+) <= (.+)").
+			WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+		mock.ExpectCommit()
+	}
+
+	pause(1)
+	rowsAffected, err := r.Pause(1, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"user_subscriptions\" SET (.+)\"paused_days\"=paused_days - floor(.+) WHERE (.+)").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	rowsAffected, err = r.Resume(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	// The days used by the first pause still count against the period.
+	pause(0)
+	rowsAffected, err = r.Pause(1, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
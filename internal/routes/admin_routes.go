@@ -16,6 +16,15 @@ func InitAdminRoutes(r *gin.RouterGroup, h *handlers.Handler) {
 		{
 			posts.POST("", h.CreatePost)
 			posts.DELETE("/:id", h.DeletePost)
+			posts.GET("/:id/analytics", h.GetPostAnalytics)
+			posts.POST("/types", h.CreateType)
+			posts.POST("/categories", h.CreateCategory)
+		}
+
+		authors := admin.Group("/authors")
+		{
+			authors.POST("", h.CreateAuthor)
+			authors.PATCH("/:id", h.UpdateAuthor)
 		}
 
 		invoices := admin.Group("invoices")
@@ -37,6 +46,27 @@ func InitAdminRoutes(r *gin.RouterGroup, h *handlers.Handler) {
 			gifts.PATCH("/stock/:id", h.UpdateGiftStock)
 		}
 
+		subscriptions := admin.Group("/subscriptions")
+		{
+			subscriptions.GET("", h.GetAllSubscriptionVersions)
+			subscriptions.POST("", h.CreateSubscription)
+			subscriptions.PATCH("/:plan_id", h.UpdateSubscription)
+			subscriptions.DELETE("/:plan_id", h.RetireSubscription)
+		}
+
+		admin.GET("/user-subscriptions", h.GetAllUserSubscriptions)
+		admin.POST("/user-subscriptions", h.AddUserSubscription)
+		admin.GET("/trials/stats", h.GetTrialStats)
+
+		achievements := admin.Group("/achievements")
+		{
+			achievements.GET("", h.GetAllAchievementRules)
+			achievements.POST("", h.CreateAchievementRule)
+			achievements.PUT("/:id", h.UpdateAchievementRule)
+		}
+
+		admin.GET("/cache/stats", h.GetCacheStats)
+
 		admin.GET("/ping-admin", func(c *gin.Context) {
 			c.JSON(http.StatusOK, gin.H{
 				"message": "pong admin",
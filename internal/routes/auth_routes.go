@@ -11,4 +11,6 @@ func InitAuthRoutes(r *gin.RouterGroup, h *handlers.Handler) {
 	r.POST("/register", h.Register)
 	r.POST("/login", h.Login)
 	r.GET("/refresh", middlewares.AuthorizeRefreshToken, h.RefreshToken)
+	r.POST("/verify-email", h.VerifyEmail)
+	r.POST("/verify-email/resend", middlewares.AuthorizeJWT, h.ResendVerificationEmail)
 }
@@ -25,6 +25,19 @@ func InitRoutes(r *gin.Engine, h *handlers.Handler) {
 			})
 		})
 
+		api.GET("/feeds/rss", h.GetRSSFeed)
+		api.GET("/feeds/atom", h.GetAtomFeed)
+		api.GET("/sitemap.xml", h.GetSitemapIndex)
+		api.GET("/sitemap-posts.xml", h.GetSitemap)
+
+		publicPosts := api.Group("/posts", middlewares.OptionalAuthorizeJWT)
+		{
+			publicPosts.GET("", h.GetAllPosts)
+			publicPosts.GET("/:id", h.GetPostByID)
+		}
+
+		api.GET("/share-links/:token", middlewares.OptionalAuthorizeJWT, h.RedeemShareLink)
+
 		api.GET("/invoices/:code", h.GetWaitingInvoiceByCode)
 		api.PATCH("/invoices/:code", h.UpdateWaitingInvoiceToProcessed)
 
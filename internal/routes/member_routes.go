@@ -11,31 +11,54 @@ func InitMemberRoutes(r *gin.RouterGroup, h *handlers.Handler) {
 	{
 		users.GET("/profile", h.GetUserProfile)
 		users.GET("/histories", h.GetUserHistories)
+		users.DELETE("/histories", h.ClearUserHistories)
+		users.DELETE("/histories/:post_id", h.RemoveUserHistory)
 		users.GET("/subscriptions", h.GetUserSubscriptions)
+		users.PATCH("/subscriptions/:id/auto-renew", h.SetSubscriptionAutoRenew)
+		users.POST("/subscriptions/:id/cancel", h.CancelUserSubscription)
+		users.POST("/subscriptions/:id/pause", h.PauseUserSubscription)
+		users.POST("/subscriptions/:id/resume", h.ResumeUserSubscription)
+		users.GET("/quota-ledger", h.GetUserQuotaLedger)
+		users.GET("/notifications", h.GetUserNotifications)
 		users.GET("/invoices", h.GetUserInvoices)
 		users.GET("/gifts", h.GetUserGifts)
 		users.GET("/referrals", h.GetUserReferrals)
 		users.GET("/vouchers", h.GetUserVouchers)
+		users.GET("/achievements", h.GetUserAchievements)
 		users.PATCH("/profile", h.UpdateUser)
 	}
 	posts := r.Group("/posts")
 	{
-		posts.GET("", h.GetAllPosts)
-		posts.GET("/:id", h.GetPostByID)
+		posts.GET("/:id/related", h.GetRelatedPosts)
+		posts.POST("/:id/unlock", h.UnlockPost)
+		posts.POST("/:id/share-links", h.CreateShareLink)
 		posts.GET("/recommendations", h.GetRecommendedPost)
+		posts.GET("/feed", h.GetFeed)
 		posts.GET("/trending", h.GetTrendingPosts)
+		posts.GET("/also-read", h.GetAlsoReadPosts)
+		posts.GET("/continue-reading", h.GetContinueReading)
+		posts.GET("/following", h.GetFollowingPosts)
 		posts.GET("/types", h.GetAllTypes)
 		posts.GET("/categories", h.GetAllCategories)
 		posts.PATCH("/like/:id", h.LikePost)
 		posts.PATCH("/share/:id", h.SharePost)
+		posts.PATCH("/react/:id", h.ReactPost)
+		posts.PATCH("/:id/progress", h.UpdateReadingProgress)
+	}
+	authors := r.Group("/authors")
+	{
+		authors.GET("", h.GetAllAuthors)
+		authors.GET("/:id", h.GetAuthorByID)
+		authors.POST("/:id/follow", h.FollowAuthor)
+		authors.DELETE("/:id/follow", h.UnfollowAuthor)
 	}
 	subscriptions := r.Group("/subscriptions")
 	{
 		subscriptions.GET("", h.GetAllSubscriptions)
-		subscriptions.POST("", h.AddUserSubscription)
 	}
 	invoices := r.Group("/invoices")
 	{
+		invoices.POST("/quote", h.QuoteInvoice)
 		invoices.POST("", h.CreateInvoice)
 		invoices.GET("/user/:code", h.GetWaitingInvoiceByCodeProtected)
 	}
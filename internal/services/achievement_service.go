@@ -0,0 +1,260 @@
+package services
+
+import (
+	"time"
+
+	"final-project-backend/internal/constants"
+	errn "final-project-backend/internal/errors"
+	"final-project-backend/internal/helpers"
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+type IAchievementService interface {
+	GetRules() ([]*models.AchievementRule, error)
+	CreateRule(rule *models.AchievementRule) (*models.AchievementRule, error)
+	UpdateRule(rule *models.AchievementRule) (*models.AchievementRule, error)
+	GetUserAchievements(userID int64) (*models.AchievementSummary, error)
+}
+
+type achievementService struct {
+	achievementRepository repositories.IAchievementRepository
+	historyRepository     repositories.IHistoryRepository
+	userRepository        repositories.IUserRepository
+}
+
+type AchievementServiceConfig struct {
+	achievementRepository repositories.IAchievementRepository
+	historyRepository     repositories.IHistoryRepository
+	userRepository        repositories.IUserRepository
+}
+
+func NewAchievementService(c *AchievementServiceConfig) IAchievementService {
+	return &achievementService{
+		achievementRepository: c.achievementRepository,
+		historyRepository:     c.historyRepository,
+		userRepository:        c.userRepository,
+	}
+}
+
+func (s *achievementService) GetRules() ([]*models.AchievementRule, error) {
+	rules, err := s.achievementRepository.GetRules()
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (s *achievementService) CreateRule(rule *models.AchievementRule) (*models.AchievementRule, error) {
+	if !isValidAchievementRule(rule) {
+		return nil, errn.ErrInvalidAchievementRule
+	}
+
+	createdRule, err := s.achievementRepository.InsertRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.achievementRepository.GetRuleByID(createdRule.ID)
+}
+
+func (s *achievementService) UpdateRule(rule *models.AchievementRule) (*models.AchievementRule, error) {
+	if !isValidAchievementRule(rule) {
+		return nil, errn.ErrInvalidAchievementRule
+	}
+
+	_, rowsAffected, err := s.achievementRepository.UpdateRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return s.achievementRepository.GetRuleByID(rule.ID)
+}
+
+// GetUserAchievements evaluates every rule against the member's reading
+// history and awards the badges reached since the last call. Badges already
+// awarded are kept even if their rule is later switched off or made harder.
+func (s *achievementService) GetUserAchievements(userID int64) (*models.AchievementSummary, error) {
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	timezone := user.Timezone
+	location, err := helpers.LoadTimezone(timezone)
+	if err != nil {
+		timezone = constants.DEFAULT_TIMEZONE
+		location, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	days, err := s.historyRepository.GetReadingDays(userID, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	currentStreak, longestStreak := computeStreaks(days, time.Now().In(location))
+
+	readCounts, err := s.historyRepository.GetReadCountsByCategory(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	totalReads := 0
+	categoryReads := map[int64]int{}
+	for _, readCount := range readCounts {
+		totalReads += readCount.ReadCount
+		categoryReads[readCount.CategoryID] = readCount.ReadCount
+	}
+
+	rules, err := s.achievementRepository.GetRules()
+	if err != nil {
+		return nil, err
+	}
+
+	userAchievements, err := s.achievementRepository.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	awarded := map[int64]*models.UserAchievement{}
+	for _, userAchievement := range userAchievements {
+		awarded[userAchievement.AchievementRuleID] = userAchievement
+	}
+
+	summary := &models.AchievementSummary{
+		Timezone:      timezone,
+		CurrentStreak: currentStreak,
+		LongestStreak: longestStreak,
+		Badges:        []*models.AchievementProgress{},
+	}
+
+	for _, rule := range rules {
+		var progress int
+		switch rule.Kind {
+		case constants.ACHIEVEMENT_STREAK:
+			progress = longestStreak
+		case constants.ACHIEVEMENT_CATEGORY_READS:
+			if rule.CategoryID != nil {
+				progress = categoryReads[*rule.CategoryID]
+			}
+		case constants.ACHIEVEMENT_TOTAL_READS:
+			progress = totalReads
+		}
+
+		achievement := awarded[rule.ID]
+		if achievement == nil && !rule.IsActive {
+			continue
+		}
+
+		if achievement == nil && progress >= rule.Threshold {
+			achievement, err = s.award(userID, rule)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		summary.Badges = append(summary.Badges, &models.AchievementProgress{
+			Rule:        rule,
+			Progress:    progress,
+			Achievement: achievement,
+		})
+	}
+
+	return summary, nil
+}
+
+func (s *achievementService) award(userID int64, rule *models.AchievementRule) (*models.UserAchievement, error) {
+	currentTime := time.Now()
+
+	userAchievement := &models.UserAchievement{
+		UserID:            userID,
+		AchievementRuleID: rule.ID,
+		AchievedAt:        currentTime,
+	}
+
+	// Achievement vouchers are earned by the member themselves.
+	var userVoucher *models.UserVoucher
+	if rule.VoucherID != nil {
+		userVoucher = &models.UserVoucher{
+			UserID:             userID,
+			VoucherID:          *rule.VoucherID,
+			Code:               helpers.RandSeq(6),
+			ReceivedFromUserID: userID,
+			DateReceived:       currentTime,
+			ValidUntil:         currentTime.AddDate(0, constants.ACHIEVEMENT_VOUCHER_VALID_MONTHS, 0),
+			Status:             constants.AVAILABLE,
+		}
+	}
+
+	isAwarded, err := s.achievementRepository.Award(userAchievement, userVoucher)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAwarded || userVoucher == nil {
+		return userAchievement, nil
+	}
+
+	userAchievement.UserVoucher = userVoucher
+	if rule.Voucher != nil {
+		userAchievement.UserVoucher.Voucher = *rule.Voucher
+	}
+
+	return userAchievement, nil
+}
+
+func isValidAchievementRule(rule *models.AchievementRule) bool {
+	if rule.Threshold < 1 {
+		return false
+	}
+
+	if rule.Kind == constants.ACHIEVEMENT_CATEGORY_READS {
+		return rule.CategoryID != nil
+	}
+
+	return true
+}
+
+// computeStreaks takes distinct reading days in ascending order and returns
+// the streak still running as of today (a streak survives until the end of
+// the day after its last read) and the longest streak ever.
+func computeStreaks(days []time.Time, today time.Time) (int, int) {
+	var run, longest int
+	var previous time.Time
+
+	for i, day := range days {
+		if i > 0 && daysBetween(previous, day) == 1 {
+			run++
+		} else {
+			run = 1
+		}
+
+		if run > longest {
+			longest = run
+		}
+		previous = day
+	}
+
+	if len(days) == 0 || daysBetween(previous, today) > 1 {
+		return 0, longest
+	}
+
+	return run, longest
+}
+
+func daysBetween(from time.Time, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/models"
+	"final-project-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewAchievementService(t *testing.T) {
+	NewAchievementService(&AchievementServiceConfig{
+		achievementRepository: mocks.NewIAchievementRepository(t),
+		historyRepository:     mocks.NewIHistoryRepository(t),
+		userRepository:        mocks.NewIUserRepository(t),
+	})
+}
+
+func Test_computeStreaks(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2022, 11, d, 0, 0, 0, 0, time.UTC)
+	}
+	today := time.Date(2022, 11, 10, 21, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+
+	tests := []struct {
+		name        string
+		days        []time.Time
+		wantCurrent int
+		wantLongest int
+	}{
+		{
+			name:        "SUCCESS | No reading days",
+			days:        []time.Time{},
+			wantCurrent: 0,
+			wantLongest: 0,
+		},
+		{
+			name:        "SUCCESS | Streak running until today",
+			days:        []time.Time{day(1), day(2), day(3), day(8), day(9), day(10)},
+			wantCurrent: 3,
+			wantLongest: 3,
+		},
+		{
+			name:        "SUCCESS | Streak last read yesterday is still running",
+			days:        []time.Time{day(6), day(7), day(8), day(9)},
+			wantCurrent: 4,
+			wantLongest: 4,
+		},
+		{
+			name:        "SUCCESS | Streak broken before yesterday",
+			days:        []time.Time{day(1), day(2), day(3), day(4), day(7), day(8)},
+			wantCurrent: 0,
+			wantLongest: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCurrent, gotLongest := computeStreaks(tt.days, today)
+
+			assert.Equal(t, tt.wantCurrent, gotCurrent)
+			assert.Equal(t, tt.wantLongest, gotLongest)
+		})
+	}
+}
+
+func Test_achievementService_GetUserAchievements(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockUserID := int64(1)
+	mockCategoryID := int64(2)
+	mockVoucherID := int64(3)
+	mockUser := &models.User{ID: mockUserID, Timezone: "Asia/Jakarta"}
+	mockRules := []*models.AchievementRule{
+		{ID: 1, Name: "Streak", Kind: constants.ACHIEVEMENT_STREAK, Threshold: 7, IsActive: true},
+		{ID: 2, Name: "Tech", Kind: constants.ACHIEVEMENT_CATEGORY_READS, CategoryID: &mockCategoryID, Threshold: 2, VoucherID: &mockVoucherID, IsActive: true},
+		{ID: 3, Name: "Retired", Kind: constants.ACHIEVEMENT_TOTAL_READS, Threshold: 1, IsActive: false},
+	}
+	mockReadCounts := []*models.PostCategoryReadCount{
+		{CategoryID: mockCategoryID, ReadCount: 2},
+		{CategoryID: 5, ReadCount: 1},
+	}
+
+	type fields struct {
+		achievementRepository *mocks.IAchievementRepository
+		historyRepository     *mocks.IHistoryRepository
+		userRepository        *mocks.IUserRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(fields)
+		wantErr     bool
+		expectedErr error
+		check       func(*testing.T, *models.AchievementSummary)
+	}{
+		{
+			name: "ERROR | Error from HistoryRepository.GetReadingDays",
+			fields: fields{
+				achievementRepository: mocks.NewIAchievementRepository(t),
+				historyRepository:     mocks.NewIHistoryRepository(t),
+				userRepository:        mocks.NewIUserRepository(t),
+			},
+			mock: func(f fields) {
+				f.userRepository.On("GetByID", mockUserID).Return(mockUser, nil)
+				f.historyRepository.On("GetReadingDays", mockUserID, "Asia/Jakarta").Return(nil, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS | Awards reached badge once and grants its voucher",
+			fields: fields{
+				achievementRepository: mocks.NewIAchievementRepository(t),
+				historyRepository:     mocks.NewIHistoryRepository(t),
+				userRepository:        mocks.NewIUserRepository(t),
+			},
+			mock: func(f fields) {
+				f.userRepository.On("GetByID", mockUserID).Return(mockUser, nil)
+				f.historyRepository.On("GetReadingDays", mockUserID, "Asia/Jakarta").Return([]time.Time{}, nil)
+				f.historyRepository.On("GetReadCountsByCategory", mockUserID).Return(mockReadCounts, nil)
+				f.achievementRepository.On("GetRules").Return(mockRules, nil)
+				f.achievementRepository.On("GetByUserID", mockUserID).Return([]*models.UserAchievement{}, nil)
+				f.achievementRepository.On("Award", mock.MatchedBy(func(a *models.UserAchievement) bool {
+					return a.UserID == mockUserID && a.AchievementRuleID == 2
+				}), mock.MatchedBy(func(v *models.UserVoucher) bool {
+					return v.UserID == mockUserID && v.VoucherID == mockVoucherID && v.Status == constants.AVAILABLE
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.UserVoucher).Code = "ABCDEF"
+				}).Return(true, nil)
+			},
+			check: func(t *testing.T, got *models.AchievementSummary) {
+				assert.Equal(t, "Asia/Jakarta", got.Timezone)
+				assert.Len(t, got.Badges, 2)
+				assert.Nil(t, got.Badges[0].Achievement)
+				assert.Equal(t, 2, got.Badges[1].Progress)
+				assert.Equal(t, "ABCDEF", got.Badges[1].Achievement.UserVoucher.Code)
+			},
+		},
+		{
+			name: "SUCCESS | Keeps badge of retired rule already awarded",
+			fields: fields{
+				achievementRepository: mocks.NewIAchievementRepository(t),
+				historyRepository:     mocks.NewIHistoryRepository(t),
+				userRepository:        mocks.NewIUserRepository(t),
+			},
+			mock: func(f fields) {
+				f.userRepository.On("GetByID", mockUserID).Return(&models.User{ID: mockUserID}, nil)
+				f.historyRepository.On("GetReadingDays", mockUserID, constants.DEFAULT_TIMEZONE).Return([]time.Time{}, nil)
+				f.historyRepository.On("GetReadCountsByCategory", mockUserID).Return(mockReadCounts, nil)
+				f.achievementRepository.On("GetRules").Return(mockRules, nil)
+				f.achievementRepository.On("GetByUserID", mockUserID).Return([]*models.UserAchievement{
+					{UserID: mockUserID, AchievementRuleID: 2},
+					{UserID: mockUserID, AchievementRuleID: 3},
+				}, nil)
+			},
+			check: func(t *testing.T, got *models.AchievementSummary) {
+				assert.Len(t, got.Badges, 3)
+				assert.Equal(t, 3, got.Badges[2].Progress)
+				assert.NotNil(t, got.Badges[2].Achievement)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &achievementService{
+				achievementRepository: tt.fields.achievementRepository,
+				historyRepository:     tt.fields.historyRepository,
+				userRepository:        tt.fields.userRepository,
+			}
+
+			tt.mock(tt.fields)
+			got, err := s.GetUserAchievements(mockUserID)
+
+			if tt.wantErr {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			tt.check(t, got)
+		})
+	}
+}
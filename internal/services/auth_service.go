@@ -2,7 +2,9 @@ package services
 
 import (
 	"errors"
+	"fmt"
 
+	"final-project-backend/internal/constants"
 	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/helpers"
 	"final-project-backend/internal/models"
@@ -15,18 +17,22 @@ type IAuthService interface {
 	Register(user *models.User, referredCode *string) (*models.User, *string, *string, error)
 	Login(email, password string) (*models.User, *string, *string, error)
 	RefreshAccessToken(userID int64, token *string) (*string, error)
+	SendVerificationEmail(user *models.User, link string) error
+	VerifyEmail(userID int64, email string) (*models.User, error)
 }
 
 type authService struct {
 	userRepository      repositories.IUserRepository
 	userTokenRepository repositories.IUserTokenRepository
 	hasher              helpers.Hasher
+	mailer              helpers.Mailer
 }
 
 type AuthServiceConfig struct {
 	userRepository      repositories.IUserRepository
 	userTokenRepository repositories.IUserTokenRepository
 	hasher              helpers.Hasher
+	mailer              helpers.Mailer
 }
 
 func NewAuthService(c *AuthServiceConfig) IAuthService {
@@ -34,6 +40,7 @@ func NewAuthService(c *AuthServiceConfig) IAuthService {
 		userRepository:      c.userRepository,
 		userTokenRepository: c.userTokenRepository,
 		hasher:              c.hasher,
+		mailer:              c.mailer,
 	}
 }
 
@@ -171,3 +178,41 @@ func (s *authService) RefreshAccessToken(userID int64, token *string) (*string,
 
 	return accessToken, nil
 }
+
+func (s *authService) SendVerificationEmail(user *models.User, link string) error {
+	if user.EmailVerifiedAt != nil {
+		return errn.ErrEmailAlreadyVerified
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nOpen the link below to verify your email address. It expires in %d hours.\n\n%s\n", user.Fullname, int(constants.EMAIL_VERIFICATION_TTL.Hours()), link)
+
+	return s.mailer.Send(user.Email, "Verify your email address", body)
+}
+
+// VerifyEmail marks the member's email verified. A link sent to an address
+// the member has since changed is invalid.
+func (s *authService) VerifyEmail(userID int64, email string) (*models.User, error) {
+	user, rowsAffected, err := s.userRepository.MarkEmailVerified(userID, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected > 0 {
+		return user, nil
+	}
+
+	user, err = s.userRepository.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errn.ErrInvalidEmailVerification
+		}
+
+		return nil, err
+	}
+
+	if user.Email != email {
+		return nil, errn.ErrInvalidEmailVerification
+	}
+
+	return nil, errn.ErrEmailAlreadyVerified
+}
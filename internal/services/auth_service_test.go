@@ -2,12 +2,16 @@ package services
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/models"
 	mocks "final-project-backend/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
@@ -173,3 +177,99 @@ func Test_authService_Login(t *testing.T) {
 		})
 	}
 }
+
+func Test_authService_VerifyEmail(t *testing.T) {
+	verifiedAt := time.Now()
+	mockUser := &models.User{
+		ID:              1,
+		Email:           "email@email.com",
+		EmailVerifiedAt: &verifiedAt,
+	}
+	mockError := fmt.Errorf("error")
+
+	tests := []struct {
+		name        string
+		email       string
+		mock        func(*mocks.IUserRepository)
+		wantUser    *models.User
+		expectedErr error
+	}{
+		{
+			name:  "ERROR | Error from UserRepository.MarkEmailVerified",
+			email: mockUser.Email,
+			mock: func(ir *mocks.IUserRepository) {
+				ir.On("MarkEmailVerified", mockUser.ID, mockUser.Email).Return(nil, 0, mockError)
+			},
+			expectedErr: mockError,
+		},
+		{
+			name:  "ERROR | Email changed since the link was sent",
+			email: "old@email.com",
+			mock: func(ir *mocks.IUserRepository) {
+				ir.On("MarkEmailVerified", mockUser.ID, "old@email.com").Return(nil, 0, nil)
+				ir.On("GetByID", mockUser.ID).Return(mockUser, nil)
+			},
+			expectedErr: errn.ErrInvalidEmailVerification,
+		},
+		{
+			name:  "ERROR | Already verified",
+			email: mockUser.Email,
+			mock: func(ir *mocks.IUserRepository) {
+				ir.On("MarkEmailVerified", mockUser.ID, mockUser.Email).Return(nil, 0, nil)
+				ir.On("GetByID", mockUser.ID).Return(mockUser, nil)
+			},
+			expectedErr: errn.ErrEmailAlreadyVerified,
+		},
+		{
+			name:  "SUCCESS",
+			email: mockUser.Email,
+			mock: func(ir *mocks.IUserRepository) {
+				ir.On("MarkEmailVerified", mockUser.ID, mockUser.Email).Return(mockUser, 1, nil)
+			},
+			wantUser: mockUser,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepository := mocks.NewIUserRepository(t)
+			s := &authService{
+				userRepository: userRepository,
+			}
+
+			tt.mock(userRepository)
+			gotUser, err := s.VerifyEmail(mockUser.ID, tt.email)
+
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			}
+			assert.Equal(t, tt.wantUser, gotUser)
+		})
+	}
+}
+
+func Test_authService_SendVerificationEmail(t *testing.T) {
+	verifiedAt := time.Now()
+	link := "https://example.com/verify-email?token=token"
+
+	t.Run("ERROR | Already verified", func(t *testing.T) {
+		s := &authService{mailer: mocks.NewMailer(t)}
+
+		err := s.SendVerificationEmail(&models.User{Email: "email@email.com", EmailVerifiedAt: &verifiedAt}, link)
+
+		assert.ErrorIs(t, err, errn.ErrEmailAlreadyVerified)
+	})
+
+	t.Run("SUCCESS", func(t *testing.T) {
+		mailer := mocks.NewMailer(t)
+		s := &authService{mailer: mailer}
+		mailer.On("Send", "email@email.com", mock.Anything, mock.MatchedBy(func(body string) bool {
+			return strings.Contains(body, link)
+		})).Return(nil)
+
+		err := s.SendVerificationEmail(&models.User{Email: "email@email.com"}, link)
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+type IAuthorService interface {
+	Create(author *models.Author) (*models.Author, error)
+	Update(author *models.Author) (*models.Author, error)
+	GetAll() ([]*models.Author, error)
+	GetByID(id int64) (*models.Author, error)
+	GetProfile(authorID int64, userID int64) (*models.AuthorProfile, error)
+	Follow(userID int64, authorID int64) error
+	Unfollow(userID int64, authorID int64) error
+}
+
+type authorService struct {
+	authorRepository repositories.IAuthorRepository
+}
+
+type AuthorServiceConfig struct {
+	authorRepository repositories.IAuthorRepository
+}
+
+func NewAuthorService(c *AuthorServiceConfig) IAuthorService {
+	return &authorService{
+		authorRepository: c.authorRepository,
+	}
+}
+
+func (s *authorService) Create(author *models.Author) (*models.Author, error) {
+	result, err := s.authorRepository.Insert(author)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *authorService) Update(author *models.Author) (*models.Author, error) {
+	_, rowsAffected, err := s.authorRepository.Update(author)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	result, err := s.authorRepository.GetByID(author.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *authorService) GetAll() ([]*models.Author, error) {
+	result, err := s.authorRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *authorService) GetByID(id int64) (*models.Author, error) {
+	result, err := s.authorRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *authorService) GetProfile(authorID int64, userID int64) (*models.AuthorProfile, error) {
+	author, err := s.authorRepository.GetByID(authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	followerCount, err := s.authorRepository.CountFollowers(authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	isFollowing, err := s.authorRepository.IsFollowing(userID, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthorProfile{
+		Author:        *author,
+		FollowerCount: followerCount,
+		IsFollowing:   isFollowing,
+	}, nil
+}
+
+func (s *authorService) Follow(userID int64, authorID int64) error {
+	_, err := s.authorRepository.GetByID(authorID)
+	if err != nil {
+		return err
+	}
+
+	err = s.authorRepository.Follow(&models.AuthorFollower{
+		UserID:   userID,
+		AuthorID: authorID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *authorService) Unfollow(userID int64, authorID int64) error {
+	_, err := s.authorRepository.GetByID(authorID)
+	if err != nil {
+		return err
+	}
+
+	err = s.authorRepository.Unfollow(&models.AuthorFollower{
+		UserID:   userID,
+		AuthorID: authorID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
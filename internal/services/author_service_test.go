@@ -0,0 +1,168 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"final-project-backend/internal/models"
+	mocks "final-project-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestNewAuthorService(t *testing.T) {
+	NewAuthorService(&AuthorServiceConfig{
+		authorRepository: mocks.NewIAuthorRepository(t),
+	})
+}
+
+func Test_authorService_GetProfile(t *testing.T) {
+	mockAuthorID := int64(1)
+	mockUserID := int64(2)
+	mockAuthor := &models.Author{ID: mockAuthorID, Name: "Author"}
+	mockError := fmt.Errorf("error")
+	type fields struct {
+		authorRepository *mocks.IAuthorRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(*mocks.IAuthorRepository)
+		want        *models.AuthorProfile
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from authorRepository.GetByID",
+			fields: fields{
+				authorRepository: mocks.NewIAuthorRepository(t),
+			},
+			mock: func(r *mocks.IAuthorRepository) {
+				r.On("GetByID", mockAuthorID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "ERROR | Error from authorRepository.CountFollowers",
+			fields: fields{
+				authorRepository: mocks.NewIAuthorRepository(t),
+			},
+			mock: func(r *mocks.IAuthorRepository) {
+				r.On("GetByID", mockAuthorID).Return(mockAuthor, nil)
+				r.On("CountFollowers", mockAuthorID).Return(int64(0), mockError)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				authorRepository: mocks.NewIAuthorRepository(t),
+			},
+			mock: func(r *mocks.IAuthorRepository) {
+				r.On("GetByID", mockAuthorID).Return(mockAuthor, nil)
+				r.On("CountFollowers", mockAuthorID).Return(int64(3), nil)
+				r.On("IsFollowing", mockUserID, mockAuthorID).Return(true, nil)
+			},
+			want: &models.AuthorProfile{
+				Author:        *mockAuthor,
+				FollowerCount: 3,
+				IsFollowing:   true,
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &authorService{
+				authorRepository: tt.fields.authorRepository,
+			}
+
+			tt.mock(tt.fields.authorRepository)
+			got, err := s.GetProfile(mockAuthorID, mockUserID)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_authorService_Follow(t *testing.T) {
+	mockAuthorID := int64(1)
+	mockUserID := int64(2)
+	mockError := fmt.Errorf("error")
+	type fields struct {
+		authorRepository *mocks.IAuthorRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(*mocks.IAuthorRepository)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Author not found",
+			fields: fields{
+				authorRepository: mocks.NewIAuthorRepository(t),
+			},
+			mock: func(r *mocks.IAuthorRepository) {
+				r.On("GetByID", mockAuthorID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "ERROR | Error from authorRepository.Follow",
+			fields: fields{
+				authorRepository: mocks.NewIAuthorRepository(t),
+			},
+			mock: func(r *mocks.IAuthorRepository) {
+				r.On("GetByID", mockAuthorID).Return(&models.Author{ID: mockAuthorID}, nil)
+				r.On("Follow", &models.AuthorFollower{UserID: mockUserID, AuthorID: mockAuthorID}).Return(mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				authorRepository: mocks.NewIAuthorRepository(t),
+			},
+			mock: func(r *mocks.IAuthorRepository) {
+				r.On("GetByID", mockAuthorID).Return(&models.Author{ID: mockAuthorID}, nil)
+				r.On("Follow", &models.AuthorFollower{UserID: mockUserID, AuthorID: mockAuthorID}).Return(nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &authorService{
+				authorRepository: tt.fields.authorRepository,
+			}
+
+			tt.mock(tt.fields.authorRepository)
+			err := s.Follow(mockUserID, mockAuthorID)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -1,14 +1,25 @@
 package services
 
 import (
+	"math"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/dtos"
 	"final-project-backend/internal/models"
 	"final-project-backend/internal/repositories"
+
+	"gorm.io/gorm"
 )
 
 type IHistoryService interface {
 	UpdateOrInsert(history *models.History) (*models.History, error)
 	GetByUserAndPostID(userID int64, postID int64) (*models.History, error)
-	GetByUserID(userID int64) ([]*models.History, error)
+	GetByQuery(query *dtos.HistoriesRequestQuery) ([]*models.History, error)
+	CountByQuery(query *dtos.HistoriesRequestQuery) (int64, int64, error)
+	Remove(userID int64, postID int64) error
+	Clear(userID int64) (int64, error)
+	UpdateProgress(userID int64, postID int64, progress int) (*models.History, error)
+	GetContinueReading(userID int64) ([]*models.History, error)
 }
 
 type historyService struct {
@@ -45,8 +56,8 @@ func (s *historyService) UpdateOrInsert(history *models.History) (*models.Histor
 	return newHistory, nil
 }
 
-func (s *historyService) GetByUserID(userID int64) ([]*models.History, error) {
-	histories, err := s.historyRepository.GetByUserID(userID)
+func (s *historyService) GetByQuery(query *dtos.HistoriesRequestQuery) ([]*models.History, error) {
+	histories, err := s.historyRepository.GetByQuery(query)
 	if err != nil {
 		return nil, err
 	}
@@ -54,6 +65,41 @@ func (s *historyService) GetByUserID(userID int64) ([]*models.History, error) {
 	return histories, nil
 }
 
+func (s *historyService) CountByQuery(query *dtos.HistoriesRequestQuery) (int64, int64, error) {
+	totalRows, err := s.historyRepository.CountByQuery(query)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	totalPages := int64(math.Ceil(float64(totalRows) / float64(query.Limit)))
+
+	return totalRows, totalPages, nil
+}
+
+// Remove hides a post from the member's reading history. The post stays
+// unlocked, so no quota is refunded now or charged again on the next read.
+func (s *historyService) Remove(userID int64, postID int64) error {
+	rowsAffected, err := s.historyRepository.Hide(userID, postID)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (s *historyService) Clear(userID int64) (int64, error) {
+	removedCount, err := s.historyRepository.HideAll(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return removedCount, nil
+}
+
 func (s *historyService) GetByUserAndPostID(userID int64, postID int64) (*models.History, error) {
 	history, err := s.historyRepository.GetByUserAndPostID(userID, postID)
 	if err != nil {
@@ -62,3 +108,27 @@ func (s *historyService) GetByUserAndPostID(userID int64, postID int64) (*models
 
 	return history, nil
 }
+
+func (s *historyService) UpdateProgress(userID int64, postID int64, progress int) (*models.History, error) {
+	history, err := s.historyRepository.UpdateProgress(userID, postID, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func (s *historyService) GetContinueReading(userID int64) ([]*models.History, error) {
+	histories, err := s.historyRepository.GetByQuery(&dtos.HistoriesRequestQuery{
+		UserID:       userID,
+		IsUnfinished: true,
+		Sort:         constants.SORT_NEWEST,
+		Limit:        constants.CONTINUE_READING_LIMIT,
+		Page:         1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"testing"
 
+	"final-project-backend/internal/dtos"
 	"final-project-backend/internal/models"
 	"final-project-backend/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestNewHistoryService(t *testing.T) {
@@ -118,8 +120,8 @@ func Test_historyService_UpdateOrInsert(t *testing.T) {
 	}
 }
 
-func Test_historyService_GetByUserID(t *testing.T) {
-	mockUserID := int64(1)
+func Test_historyService_GetByQuery(t *testing.T) {
+	mockQuery := &dtos.HistoriesRequestQuery{UserID: 1, Limit: 10, Page: 1}
 	mockError := fmt.Errorf("error")
 
 	mockHistories := []*models.History{}
@@ -127,7 +129,7 @@ func Test_historyService_GetByUserID(t *testing.T) {
 		historyRepository *mocks.IHistoryRepository
 	}
 	type args struct {
-		userID int64
+		query *dtos.HistoriesRequestQuery
 	}
 	tests := []struct {
 		name        string
@@ -139,15 +141,15 @@ func Test_historyService_GetByUserID(t *testing.T) {
 		expectedErr error
 	}{
 		{
-			name: "ERROR | Error from GetByUserID.Update",
+			name: "ERROR | Error from HistoryRepository.GetByQuery",
 			fields: fields{
 				historyRepository: mocks.NewIHistoryRepository(t),
 			},
 			args: args{
-				userID: mockUserID,
+				query: mockQuery,
 			},
 			mock: func(r *mocks.IHistoryRepository) {
-				r.On("GetByUserID", mockUserID).Return(nil, mockError)
+				r.On("GetByQuery", mockQuery).Return(nil, mockError)
 			},
 			want:        nil,
 			wantErr:     true,
@@ -159,10 +161,10 @@ func Test_historyService_GetByUserID(t *testing.T) {
 				historyRepository: mocks.NewIHistoryRepository(t),
 			},
 			args: args{
-				userID: mockUserID,
+				query: mockQuery,
 			},
 			mock: func(r *mocks.IHistoryRepository) {
-				r.On("GetByUserID", mockUserID).Return(mockHistories, nil)
+				r.On("GetByQuery", mockQuery).Return(mockHistories, nil)
 			},
 			want:        mockHistories,
 			wantErr:     false,
@@ -176,14 +178,81 @@ func Test_historyService_GetByUserID(t *testing.T) {
 			}
 
 			tt.mock(tt.fields.historyRepository)
-			got, err := s.GetByUserID(tt.args.userID)
+			got, err := s.GetByQuery(tt.args.query)
 
 			if !tt.wantErr {
 				assert.NoError(t, err)
 			} else {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tt.expectedErr.Error())
-				assert.Equal(t, tt.want, got)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_historyService_Remove(t *testing.T) {
+	mockUserID := int64(1)
+	mockPostID := int64(2)
+	mockError := fmt.Errorf("error")
+
+	type fields struct {
+		historyRepository *mocks.IHistoryRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(*mocks.IHistoryRepository)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from HistoryRepository.Hide",
+			fields: fields{
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IHistoryRepository) {
+				r.On("Hide", mockUserID, mockPostID).Return(int64(0), mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "ERROR | History not found or already removed",
+			fields: fields{
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IHistoryRepository) {
+				r.On("Hide", mockUserID, mockPostID).Return(int64(0), nil)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IHistoryRepository) {
+				r.On("Hide", mockUserID, mockPostID).Return(int64(1), nil)
+			},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &historyService{
+				historyRepository: tt.fields.historyRepository,
+			}
+
+			tt.mock(tt.fields.historyRepository)
+			err := s.Remove(mockUserID, mockPostID)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
 			}
 		})
 	}
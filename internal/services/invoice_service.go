@@ -2,6 +2,9 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"math"
 	"time"
 
 	"final-project-backend/internal/constants"
@@ -19,7 +22,8 @@ type IInvoiceService interface {
 	GetByID(id int64) (*models.Invoice, error)
 	GetByCode(code string) (*models.Invoice, error)
 	GetUserInvoiceByCode(code string, userID int64) (*models.Invoice, error)
-	Create(invoice *models.Invoice) (*models.Invoice, error)
+	Quote(request *models.PriceQuote) (*models.PriceQuote, error)
+	Create(quote *models.PriceQuote) (*models.Invoice, error)
 	UpdateStatus(code string, status models.InvoiceStatus) (*models.Invoice, []*models.Gift, []*models.Voucher, error)
 }
 
@@ -33,6 +37,8 @@ type invoiceService struct {
 	voucherRepository          repositories.IVoucherRepository
 	userVoucherRepository      repositories.IUserVoucherRepository
 	userSpendingRepository     repositories.IUserSpendingRepository
+	notificationRepository     repositories.INotificationRepository
+	trialRepository            repositories.ITrialRepository
 }
 
 type InvoiceServiceConfig struct {
@@ -45,6 +51,8 @@ type InvoiceServiceConfig struct {
 	voucherRepository          repositories.IVoucherRepository
 	userVoucherRepository      repositories.IUserVoucherRepository
 	userSpendingRepository     repositories.IUserSpendingRepository
+	notificationRepository     repositories.INotificationRepository
+	trialRepository            repositories.ITrialRepository
 }
 
 func NewInvoiceService(c *InvoiceServiceConfig) IInvoiceService {
@@ -58,6 +66,8 @@ func NewInvoiceService(c *InvoiceServiceConfig) IInvoiceService {
 		voucherRepository:          c.voucherRepository,
 		userVoucherRepository:      c.userVoucherRepository,
 		userSpendingRepository:     c.userSpendingRepository,
+		notificationRepository:     c.notificationRepository,
+		trialRepository:            c.trialRepository,
 	}
 }
 
@@ -119,27 +129,46 @@ func (s *invoiceService) GetUserInvoiceByCode(code string, userID int64) (*model
 	return invoice, nil
 }
 
-func (s *invoiceService) Create(invoice *models.Invoice) (*models.Invoice, error) {
-	var userVoucherID int64
-	if invoice.VoucherCode != "" {
-		userVoucher, err := s.userVoucherRepository.GetByCode(invoice.VoucherCode)
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errn.ErrInvalidVoucher
-		}
+func (s *invoiceService) Quote(request *models.PriceQuote) (*models.PriceQuote, error) {
+	quote, err := s.price(request)
+	if err != nil {
+		return nil, err
+	}
 
-		if err != nil {
-			return nil, err
-		}
+	quote.ExpiresAt = time.Now().Add(constants.PRICE_QUOTE_TTL)
 
-		if userVoucher.Status != constants.AVAILABLE || time.Now().After(userVoucher.ValidUntil) {
-			return nil, errn.ErrVoucherExpired
-		}
+	return quote, nil
+}
 
-		invoice.Total = invoice.OriginalPrice - userVoucher.Voucher.Discount
-		if invoice.Total < 0 {
-			invoice.Total = 0
-		}
-		userVoucherID = userVoucher.ID
+// Create prices the quote again and only creates the invoice if the plan and
+// voucher still add up to the quoted total.
+func (s *invoiceService) Create(quote *models.PriceQuote) (*models.Invoice, error) {
+	if time.Now().After(quote.ExpiresAt) {
+		return nil, errn.ErrPriceQuoteExpired
+	}
+
+	currentQuote, err := s.price(quote)
+	if err != nil {
+		return nil, err
+	}
+
+	if currentQuote.OriginalPrice != quote.OriginalPrice || currentQuote.Credit != quote.Credit || currentQuote.Total != quote.Total {
+		return nil, errn.ErrPriceQuoteStale
+	}
+
+	invoice := &models.Invoice{
+		UserID:         currentQuote.UserID,
+		OriginalPrice:  currentQuote.OriginalPrice,
+		Total:          currentQuote.Total,
+		Status:         models.WAITING,
+		SubscriptionID: currentQuote.SubscriptionID,
+		VoucherCode:    currentQuote.VoucherCode,
+	}
+
+	if currentQuote.ChangeFromID != 0 {
+		invoice.ChangeOfID = &currentQuote.ChangeFromID
+		invoice.Credit = currentQuote.Credit
+		invoice.QuotaCarryOver = currentQuote.QuotaCarryOver
 	}
 
 	createdInvoice, err := s.invoiceRepository.Insert(invoice)
@@ -147,9 +176,9 @@ func (s *invoiceService) Create(invoice *models.Invoice) (*models.Invoice, error
 		return nil, err
 	}
 
-	if userVoucherID != 0 {
+	if currentQuote.UserVoucher != nil {
 		s.userVoucherRepository.Update(&models.UserVoucher{
-			ID:     userVoucherID,
+			ID:     currentQuote.UserVoucher.ID,
 			Status: constants.PENDING,
 		})
 	}
@@ -157,6 +186,145 @@ func (s *invoiceService) Create(invoice *models.Invoice) (*models.Invoice, error
 	return createdInvoice, nil
 }
 
+func (s *invoiceService) price(request *models.PriceQuote) (*models.PriceQuote, error) {
+	subscription, err := s.subscriptionRepository.GetByID(request.SubscriptionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errn.ErrSubscriptionNotAvailable
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !subscription.IsPurchasable() || subscription.IsTrial() {
+		return nil, errn.ErrSubscriptionNotAvailable
+	}
+
+	quote := &models.PriceQuote{
+		UserID:         request.UserID,
+		SubscriptionID: subscription.ID,
+		OriginalPrice:  subscription.Price,
+		Total:          subscription.Price,
+		Subscription:   subscription,
+	}
+
+	if request.VoucherCode != "" {
+		err = s.applyVoucher(quote, request.VoucherCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if request.ChangeFromID != 0 {
+		err = s.applyPlanChangeCredit(quote, request.ChangeFromID, request.QuotaCarryOver)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	quote.Total = quote.OriginalPrice - quote.Discount - quote.Credit
+
+	return quote, nil
+}
+
+func (s *invoiceService) applyVoucher(quote *models.PriceQuote, voucherCode string) error {
+	userVoucher, err := s.userVoucherRepository.GetByCode(voucherCode)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errn.ErrInvalidVoucher
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if userVoucher.UserID != quote.UserID {
+		return errn.ErrInvalidVoucher
+	}
+
+	if userVoucher.Status != constants.AVAILABLE || time.Now().After(userVoucher.ValidUntil) {
+		return errn.ErrVoucherExpired
+	}
+
+	quote.VoucherCode = voucherCode
+	quote.UserVoucher = userVoucher
+	quote.Discount = userVoucher.Voucher.Discount
+	if quote.Discount > quote.OriginalPrice {
+		quote.Discount = quote.OriginalPrice
+	}
+
+	return nil
+}
+
+// applyPlanChangeCredit credits the member for the part of their current
+// subscription they have not used yet. A downgrade worth less than the credit
+// costs nothing, the rest of the credit is not paid out.
+func (s *invoiceService) applyPlanChangeCredit(quote *models.PriceQuote, userSubscriptionID int64, policy constants.QuotaCarryOverPolicy) error {
+	userSubscription, err := s.userSubscriptionRepository.GetByID(userSubscriptionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errn.ErrInvalidPlanChange
+	}
+
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	status := userSubscription.Status(now)
+	if userSubscription.UserID != quote.UserID ||
+		userSubscription.ReplacedByID != nil ||
+		status == constants.SUBSCRIPTION_EXPIRED ||
+		status == constants.SUBSCRIPTION_PAUSED ||
+		userSubscription.Subscription.PlanID == quote.Subscription.PlanID {
+		return errn.ErrInvalidPlanChange
+	}
+
+	openChanges, err := s.invoiceRepository.CountOpenPlanChanges(userSubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	if openChanges > 0 {
+		return errn.ErrPlanChangePending
+	}
+
+	quote.ChangeFromID = userSubscriptionID
+	quote.ChangeFrom = userSubscription
+	quote.QuotaCarryOver = policy
+	quote.Credit = proratedCredit(userSubscription, now)
+	if quote.Credit > quote.OriginalPrice-quote.Discount {
+		quote.Credit = quote.OriginalPrice - quote.Discount
+	}
+
+	return nil
+}
+
+// proratedCredit is the price of the current period scaled by the smaller of
+// its unused days and unused quota, so spending the quota first and then
+// downgrading does not earn credit for the days left. Days are whole calendar
+// days to keep a quote stable until it expires.
+func proratedCredit(userSubscription *models.UserSubscriptions, now time.Time) int {
+	periodStart := userSubscription.DateEnded.AddDate(0, -constants.SUBSCRIPTION_DURATION_MONTHS, 0)
+	if userSubscription.DateStarted.After(periodStart) {
+		periodStart = userSubscription.DateStarted
+	}
+
+	periodDays := daysBetween(periodStart, userSubscription.DateEnded)
+	unusedDays := daysBetween(now, userSubscription.DateEnded)
+	if periodDays <= 0 || unusedDays <= 0 {
+		return 0
+	}
+
+	unusedShare := math.Min(float64(unusedDays)/float64(periodDays), 1)
+
+	planQuota := userSubscription.Subscription.Quota
+	if planQuota > 0 {
+		unusedQuotaShare := math.Min(float64(userSubscription.RemainingQuota)/float64(planQuota), 1)
+		unusedShare = math.Min(unusedShare, unusedQuotaShare)
+	}
+
+	return int(math.Floor(float64(userSubscription.Subscription.Price) * unusedShare))
+}
+
 func (s *invoiceService) UpdateStatus(code string, status models.InvoiceStatus) (*models.Invoice, []*models.Gift, []*models.Voucher, error) {
 	giftsSent := []*models.Gift{}
 	vouchersSent := []*models.Voucher{}
@@ -208,19 +376,31 @@ func (s *invoiceService) UpdateStatus(code string, status models.InvoiceStatus)
 			return nil, nil, nil, err
 		}
 
-		userSubscription := &models.UserSubscriptions{
-			UserID:         updatedInvoice.UserID,
-			SubscriptionID: updatedInvoice.SubscriptionID,
-			RemainingQuota: subscription.Quota,
-			DateStarted:    time.Now(),
-			DateEnded:      time.Now().AddDate(0, 1, 0),
+		if updatedInvoice.RenewalOfID != nil {
+			err = s.renew(updatedInvoice, subscription)
+		} else if updatedInvoice.ChangeOfID != nil {
+			err = s.changePlan(updatedInvoice, subscription)
+		} else {
+			_, err = s.userSubscriptionRepository.Insert(&models.UserSubscriptions{
+				UserID:         updatedInvoice.UserID,
+				SubscriptionID: updatedInvoice.SubscriptionID,
+				RemainingQuota: subscription.Quota,
+				DateStarted:    time.Now(),
+				DateEnded:      time.Now().AddDate(0, constants.SUBSCRIPTION_DURATION_MONTHS, 0),
+			})
 		}
 
-		_, err = s.userSubscriptionRepository.Insert(userSubscription)
 		if err != nil {
 			return nil, nil, nil, err
 		}
 
+		// The plan is already granted, a failed conversion mark must not undo
+		// the rest of the completion.
+		_, err = s.trialRepository.MarkConverted(updatedInvoice.UserID, int64(updatedInvoice.ID))
+		if err != nil {
+			log.Printf("failed to mark the trial of user %d converted by invoice %d: %v", updatedInvoice.UserID, updatedInvoice.ID, err)
+		}
+
 		currentTime := time.Now()
 		currentMonthNum := int(currentTime.Month())
 		currentYearNum := int(currentTime.Year())
@@ -247,6 +427,71 @@ func (s *invoiceService) UpdateStatus(code string, status models.InvoiceStatus)
 	return updatedInvoice, giftsSent, vouchersSent, nil
 }
 
+// renew extends the subscription a renewal invoice was issued for by one
+// period. Renewals paid within the grace period continue from the old end
+// date, later ones start from now.
+func (s *invoiceService) renew(invoice *models.Invoice, subscription *models.Subscription) error {
+	userSubscription, err := s.userSubscriptionRepository.GetByID(*invoice.RenewalOfID)
+	if err != nil {
+		return err
+	}
+
+	periodStart := userSubscription.DateEnded
+	if time.Now().After(periodStart.Add(constants.RENEWAL_GRACE_PERIOD)) {
+		periodStart = time.Now()
+	}
+
+	dateEnded := periodStart.AddDate(0, constants.SUBSCRIPTION_DURATION_MONTHS, 0)
+	err = s.userSubscriptionRepository.Renew(int64(userSubscription.ID), subscription.ID, dateEnded, subscription.Quota)
+	if err != nil {
+		return err
+	}
+
+	invoiceID := int64(invoice.ID)
+	_, err = s.notificationRepository.Insert(&models.Notification{
+		UserID:    invoice.UserID,
+		Kind:      models.NOTIFICATION_RENEWAL_SUCCESS,
+		Message:   fmt.Sprintf("Your %s subscription has been renewed until %s.", subscription.Name, formatRenewalDate(dateEnded)),
+		InvoiceID: &invoiceID,
+	})
+
+	return err
+}
+
+// changePlan replaces the subscription a plan change invoice was issued for.
+// Should it already have been replaced, the member still gets the plan they
+// paid for as a new subscription.
+func (s *invoiceService) changePlan(invoice *models.Invoice, subscription *models.Subscription) error {
+	now := time.Now()
+	userSubscription := &models.UserSubscriptions{
+		UserID:         invoice.UserID,
+		SubscriptionID: subscription.ID,
+		RemainingQuota: subscription.Quota,
+		DateStarted:    now,
+		DateEnded:      now.AddDate(0, constants.SUBSCRIPTION_DURATION_MONTHS, 0),
+	}
+
+	_, isChanged, err := s.userSubscriptionRepository.ChangePlan(*invoice.ChangeOfID, userSubscription, invoice.QuotaCarryOver)
+	if err != nil {
+		return err
+	}
+
+	if !isChanged {
+		_, err = s.userSubscriptionRepository.Insert(userSubscription)
+		return err
+	}
+
+	invoiceID := int64(invoice.ID)
+	_, err = s.notificationRepository.Insert(&models.Notification{
+		UserID:    invoice.UserID,
+		Kind:      models.NOTIFICATION_PLAN_CHANGED,
+		Message:   fmt.Sprintf("Your subscription has been switched to %s with %d quota until %s.", subscription.Name, userSubscription.RemainingQuota, formatRenewalDate(userSubscription.DateEnded)),
+		InvoiceID: &invoiceID,
+	})
+
+	return err
+}
+
 func (s *invoiceService) SendVoucherIfAble(userID int64, latestInvoice *models.Invoice, userSpending *models.UserSpending) ([]*models.Voucher, error) {
 	vouchersSent := []*models.Voucher{}
 
@@ -3,7 +3,9 @@ package services
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"final-project-backend/internal/constants"
 	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/models"
 	"final-project-backend/mocks"
@@ -78,34 +80,306 @@ func Test_invoiceService_GetAll(t *testing.T) {
 	}
 }
 
+func Test_invoiceService_Quote(t *testing.T) {
+	mockSubscription := &models.Subscription{ID: 1, PlanID: 1, Version: 1, Name: "gold", Price: 90000}
+	mockUserVoucher := &models.UserVoucher{
+		ID:         2,
+		UserID:     1,
+		Code:       "ABCDEF",
+		ValidUntil: time.Now().Add(time.Hour),
+		Status:     constants.AVAILABLE,
+		Voucher:    models.Voucher{Discount: 10000},
+	}
+	mockUserSubscription := &models.UserSubscriptions{
+		UserID:         1,
+		Subscription:   models.Subscription{ID: 3, PlanID: 2, Name: "silver", Price: 60000, Quota: 10},
+		RemainingQuota: 5,
+		DateStarted:    time.Now().AddDate(0, 0, -10),
+		DateEnded:      time.Now().AddDate(0, 0, 20),
+	}
+
+	type fields struct {
+		invoiceRepository          *mocks.IInvoiceRepository
+		subscriptionRepository     *mocks.ISubscriptionRepository
+		userSubscriptionRepository *mocks.IUserSubscriptionRepository
+		userVoucherRepository      *mocks.IUserVoucherRepository
+	}
+	newFields := func() fields {
+		return fields{
+			invoiceRepository:          mocks.NewIInvoiceRepository(t),
+			subscriptionRepository:     mocks.NewISubscriptionRepository(t),
+			userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
+			userVoucherRepository:      mocks.NewIUserVoucherRepository(t),
+		}
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		voucherCode  string
+		changeFromID int64
+		mock         func(fields)
+		wantTotal    int
+		wantErr      bool
+		expectedErr  error
+	}{
+		{
+			name: "ERROR | Error from voucher of another member",
+			fields: fields{
+				subscriptionRepository: mocks.NewISubscriptionRepository(t),
+				userVoucherRepository:  mocks.NewIUserVoucherRepository(t),
+			},
+			voucherCode: "ABCDEF",
+			mock: func(f fields) {
+				f.subscriptionRepository.On("GetByID", int64(1)).Return(mockSubscription, nil)
+				f.userVoucherRepository.On("GetByCode", "ABCDEF").Return(&models.UserVoucher{UserID: 2}, nil)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrInvalidVoucher,
+		},
+		{
+			name: "SUCCESS | Plan price without voucher",
+			fields: fields{
+				subscriptionRepository: mocks.NewISubscriptionRepository(t),
+				userVoucherRepository:  mocks.NewIUserVoucherRepository(t),
+			},
+			mock: func(f fields) {
+				f.subscriptionRepository.On("GetByID", int64(1)).Return(mockSubscription, nil)
+			},
+			wantTotal: 90000,
+		},
+		{
+			name: "SUCCESS | Plan price with voucher discount",
+			fields: fields{
+				subscriptionRepository: mocks.NewISubscriptionRepository(t),
+				userVoucherRepository:  mocks.NewIUserVoucherRepository(t),
+			},
+			voucherCode: "ABCDEF",
+			mock: func(f fields) {
+				f.subscriptionRepository.On("GetByID", int64(1)).Return(mockSubscription, nil)
+				f.userVoucherRepository.On("GetByCode", "ABCDEF").Return(mockUserVoucher, nil)
+			},
+			wantTotal: 80000,
+		},
+		{
+			name:         "ERROR | Switching to the plan already subscribed to",
+			fields:       newFields(),
+			changeFromID: 4,
+			mock: func(f fields) {
+				f.subscriptionRepository.On("GetByID", int64(1)).Return(mockSubscription, nil)
+				f.userSubscriptionRepository.On("GetByID", int64(4)).Return(&models.UserSubscriptions{
+					UserID:       1,
+					Subscription: *mockSubscription,
+					DateEnded:    time.Now().AddDate(0, 0, 20),
+				}, nil)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrInvalidPlanChange,
+		},
+		{
+			name:         "ERROR | Plan change already waiting for payment",
+			fields:       newFields(),
+			changeFromID: 4,
+			mock: func(f fields) {
+				f.subscriptionRepository.On("GetByID", int64(1)).Return(mockSubscription, nil)
+				f.userSubscriptionRepository.On("GetByID", int64(4)).Return(mockUserSubscription, nil)
+				f.invoiceRepository.On("CountOpenPlanChanges", int64(4)).Return(int64(1), nil)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrPlanChangePending,
+		},
+		{
+			name:         "SUCCESS | Plan change credits the unused part of the current plan",
+			fields:       newFields(),
+			changeFromID: 4,
+			mock: func(f fields) {
+				f.subscriptionRepository.On("GetByID", int64(1)).Return(mockSubscription, nil)
+				f.userSubscriptionRepository.On("GetByID", int64(4)).Return(mockUserSubscription, nil)
+				f.invoiceRepository.On("CountOpenPlanChanges", int64(4)).Return(int64(0), nil)
+			},
+			wantTotal: 60000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &invoiceService{
+				invoiceRepository:          tt.fields.invoiceRepository,
+				subscriptionRepository:     tt.fields.subscriptionRepository,
+				userSubscriptionRepository: tt.fields.userSubscriptionRepository,
+				userVoucherRepository:      tt.fields.userVoucherRepository,
+			}
+
+			tt.mock(tt.fields)
+			got, err := s.Quote(&models.PriceQuote{
+				UserID:         1,
+				SubscriptionID: 1,
+				VoucherCode:    tt.voucherCode,
+				ChangeFromID:   tt.changeFromID,
+			})
+
+			if tt.wantErr {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, 90000, got.OriginalPrice)
+			assert.Equal(t, tt.wantTotal, got.Total)
+			assert.True(t, got.ExpiresAt.After(time.Now()))
+		})
+	}
+}
+
+func Test_proratedCredit(t *testing.T) {
+	now := time.Date(2022, 11, 11, 12, 0, 0, 0, time.UTC)
+	mockSubscription := models.Subscription{Price: 30000, Quota: 10}
+
+	tests := []struct {
+		name             string
+		userSubscription *models.UserSubscriptions
+		want             int
+	}{
+		{
+			name: "Unused days are the smaller share",
+			userSubscription: &models.UserSubscriptions{
+				Subscription:   mockSubscription,
+				RemainingQuota: 10,
+				DateStarted:    time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC),
+				DateEnded:      time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC),
+			},
+			want: 20000,
+		},
+		{
+			name: "Unused quota is the smaller share",
+			userSubscription: &models.UserSubscriptions{
+				Subscription:   mockSubscription,
+				RemainingQuota: 2,
+				DateStarted:    time.Date(2022, 11, 1, 12, 0, 0, 0, time.UTC),
+				DateEnded:      time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC),
+			},
+			want: 6000,
+		},
+		{
+			name: "Renewed subscription only counts the current period",
+			userSubscription: &models.UserSubscriptions{
+				Subscription:   mockSubscription,
+				RemainingQuota: 25,
+				DateStarted:    time.Date(2022, 9, 1, 12, 0, 0, 0, time.UTC),
+				DateEnded:      time.Date(2022, 12, 1, 12, 0, 0, 0, time.UTC),
+			},
+			want: 20000,
+		},
+		{
+			name: "Subscription in its grace period",
+			userSubscription: &models.UserSubscriptions{
+				Subscription:   mockSubscription,
+				RemainingQuota: 10,
+				DateStarted:    time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC),
+				DateEnded:      time.Date(2022, 11, 10, 12, 0, 0, 0, time.UTC),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, proratedCredit(tt.userSubscription, now))
+		})
+	}
+}
+
 func Test_invoiceService_Create(t *testing.T) {
-	mockInvoice := &models.Invoice{}
+	mockQuote := &models.PriceQuote{
+		UserID:         1,
+		SubscriptionID: 1,
+		OriginalPrice:  90000,
+		Total:          90000,
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+	mockInvoice := &models.Invoice{
+		UserID:         1,
+		OriginalPrice:  90000,
+		Total:          90000,
+		Status:         models.WAITING,
+		SubscriptionID: 1,
+	}
+	mockSubscription := &models.Subscription{ID: 1, PlanID: 1, Version: 1, Price: 90000}
+	mockSupersededAt := time.Now()
 
 	mockError := fmt.Errorf("error")
 	type fields struct {
-		invoiceRepository *mocks.IInvoiceRepository
+		invoiceRepository      *mocks.IInvoiceRepository
+		subscriptionRepository *mocks.ISubscriptionRepository
 	}
 	type args struct {
-		invoice *models.Invoice
+		quote *models.PriceQuote
 	}
 	tests := []struct {
 		name        string
 		fields      fields
 		args        args
-		mock        func(*mocks.IInvoiceRepository)
+		mock        func(*mocks.IInvoiceRepository, *mocks.ISubscriptionRepository)
 		want        *models.Invoice
 		wantErr     bool
 		expectedErr error
 	}{
+		{
+			name: "ERROR | Error from expired quote",
+			fields: fields{
+				invoiceRepository:      mocks.NewIInvoiceRepository(t),
+				subscriptionRepository: mocks.NewISubscriptionRepository(t),
+			},
+			args: args{
+				quote: &models.PriceQuote{SubscriptionID: 1, ExpiresAt: time.Now().Add(-time.Minute)},
+			},
+			mock: func(r *mocks.IInvoiceRepository, sr *mocks.ISubscriptionRepository) {
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: errn.ErrPriceQuoteExpired,
+		},
+		{
+			name: "ERROR | Error from superseded subscription version",
+			fields: fields{
+				invoiceRepository:      mocks.NewIInvoiceRepository(t),
+				subscriptionRepository: mocks.NewISubscriptionRepository(t),
+			},
+			args: args{
+				quote: mockQuote,
+			},
+			mock: func(r *mocks.IInvoiceRepository, sr *mocks.ISubscriptionRepository) {
+				sr.On("GetByID", int64(1)).Return(&models.Subscription{ID: 1, SupersededAt: &mockSupersededAt}, nil)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: errn.ErrSubscriptionNotAvailable,
+		},
+		{
+			name: "ERROR | Error from quote not matching the server price",
+			fields: fields{
+				invoiceRepository:      mocks.NewIInvoiceRepository(t),
+				subscriptionRepository: mocks.NewISubscriptionRepository(t),
+			},
+			args: args{
+				quote: &models.PriceQuote{UserID: 1, SubscriptionID: 1, OriginalPrice: 1, Total: 1, ExpiresAt: time.Now().Add(time.Hour)},
+			},
+			mock: func(r *mocks.IInvoiceRepository, sr *mocks.ISubscriptionRepository) {
+				sr.On("GetByID", int64(1)).Return(mockSubscription, nil)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: errn.ErrPriceQuoteStale,
+		},
 		{
 			name: "ERROR | Error from invoiceRepository.Insert",
 			fields: fields{
-				invoiceRepository: mocks.NewIInvoiceRepository(t),
+				invoiceRepository:      mocks.NewIInvoiceRepository(t),
+				subscriptionRepository: mocks.NewISubscriptionRepository(t),
 			},
 			args: args{
-				invoice: mockInvoice,
+				quote: mockQuote,
 			},
-			mock: func(r *mocks.IInvoiceRepository) {
+			mock: func(r *mocks.IInvoiceRepository, sr *mocks.ISubscriptionRepository) {
+				sr.On("GetByID", int64(1)).Return(mockSubscription, nil)
 				r.On("Insert", mockInvoice).Return(nil, mockError)
 			},
 			want:        nil,
@@ -115,12 +389,14 @@ func Test_invoiceService_Create(t *testing.T) {
 		{
 			name: "SUCCESS",
 			fields: fields{
-				invoiceRepository: mocks.NewIInvoiceRepository(t),
+				invoiceRepository:      mocks.NewIInvoiceRepository(t),
+				subscriptionRepository: mocks.NewISubscriptionRepository(t),
 			},
 			args: args{
-				invoice: mockInvoice,
+				quote: mockQuote,
 			},
-			mock: func(r *mocks.IInvoiceRepository) {
+			mock: func(r *mocks.IInvoiceRepository, sr *mocks.ISubscriptionRepository) {
+				sr.On("GetByID", int64(1)).Return(mockSubscription, nil)
 				r.On("Insert", mockInvoice).Return(mockInvoice, nil)
 			},
 			want:        mockInvoice,
@@ -131,14 +407,16 @@ func Test_invoiceService_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &invoiceService{
-				invoiceRepository: tt.fields.invoiceRepository,
+				invoiceRepository:      tt.fields.invoiceRepository,
+				subscriptionRepository: tt.fields.subscriptionRepository,
 			}
 
-			tt.mock(tt.fields.invoiceRepository)
-			got, err := s.Create(tt.args.invoice)
+			tt.mock(tt.fields.invoiceRepository, tt.fields.subscriptionRepository)
+			got, err := s.Create(tt.args.quote)
 
 			if !tt.wantErr {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
 			} else {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tt.expectedErr.Error())
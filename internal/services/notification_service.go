@@ -0,0 +1,42 @@
+package services
+
+import (
+	"math"
+
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/repositories"
+)
+
+type INotificationService interface {
+	GetByUserID(userID int64, limit int, page int) ([]*models.Notification, int64, int64, error)
+}
+
+type notificationService struct {
+	notificationRepository repositories.INotificationRepository
+}
+
+type NotificationServiceConfig struct {
+	notificationRepository repositories.INotificationRepository
+}
+
+func NewNotificationService(c *NotificationServiceConfig) INotificationService {
+	return &notificationService{
+		notificationRepository: c.notificationRepository,
+	}
+}
+
+func (s *notificationService) GetByUserID(userID int64, limit int, page int) ([]*models.Notification, int64, int64, error) {
+	notifications, err := s.notificationRepository.GetByUserID(userID, limit, page)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	totalRows, err := s.notificationRepository.CountByUserID(userID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	totalPages := int64(math.Ceil(float64(totalRows) / float64(limit)))
+
+	return notifications, totalRows, totalPages, nil
+}
@@ -1,45 +1,84 @@
 package services
 
 import (
+	"fmt"
 	"math"
+	"sort"
 
+	"final-project-backend/internal/cache"
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/dtos"
+	"final-project-backend/internal/helpers"
 	"final-project-backend/internal/models"
 	"final-project-backend/internal/repositories"
-
-	"gorm.io/gorm"
 )
 
 type IPostService interface {
 	Create(post *models.Post) (*models.Post, error)
 	GetByID(id int64) (*models.Post, error)
 	GetAll(query *dtos.PostsRequestQuery) ([]*models.Post, error)
+	GetAllByCursor(query *dtos.PostsRequestQuery) ([]*models.Post, string, string, error)
 	Delete(id int64) error
 	CountByQuery(
 		query *dtos.PostsRequestQuery,
 	) (int64, int64, error)
 	GetRecommendedPosts(userID int64) ([]*models.Post, error)
-	GetTrendingPosts(userID int64) ([]*models.Post, error)
+	GetFeed(userID int64, cursor *dtos.FeedCursor, limit int) ([]*dtos.FeedItem, *dtos.FeedCursor, error)
+	GetAlsoReadPosts(userID int64) ([]*models.Post, error)
+	RefreshPostNeighbors() (int64, error)
+	GetTrendingPosts(query *dtos.TrendingPostsQuery) ([]*models.Post, error)
+	RefreshTrending() (int64, error)
 	GetTypes() ([]*models.PostType, error)
 	GetCategories() ([]*models.Category, error)
+	CreateType(postType *models.PostType) (*models.PostType, error)
+	CreateCategory(category *models.Category) (*models.Category, error)
+	GetCacheStats() map[string]cache.Stats
+	InvalidateCaches()
 	Like(postID int64, userID int64, isLike bool) (*models.Post, *models.History, error)
 	Share(postID int64, userID int64) (*models.Post, *models.History, error)
+	React(postID int64, userID int64, reaction constants.Reaction) (*models.Post, *models.History, error)
+	GetAnalytics(postID int64) (*models.Post, *models.PostReadStats, error)
+	CountSitemapPages() (int64, error)
+	GetSitemapPage(page int) ([]*models.Post, error)
+	GetRelatedPosts(postID int64, userID int64) ([]*models.Post, error)
+	ReconcileCounters() (int64, error)
 }
 
 type postService struct {
-	postRepository    repositories.IPostRepository
-	historyRepository repositories.IHistoryRepository
+	postRepository         repositories.IPostRepository
+	historyRepository      repositories.IHistoryRepository
+	trendingRepository     repositories.ITrendingRepository
+	postNeighborRepository repositories.IPostNeighborRepository
+	relatedPostsCache      cache.Cache[int64, []*models.Post]
+	postNeighborsCache     cache.Cache[int64, []*models.PostNeighbor]
+	postListCache          cache.Cache[string, []*models.Post]
+	typesCache             cache.Cache[string, []*models.PostType]
+	categoriesCache        cache.Cache[string, []*models.Category]
 }
 
 type PostServiceConfig struct {
-	postRepository    repositories.IPostRepository
-	historyRepository repositories.IHistoryRepository
+	postRepository         repositories.IPostRepository
+	historyRepository      repositories.IHistoryRepository
+	trendingRepository     repositories.ITrendingRepository
+	postNeighborRepository repositories.IPostNeighborRepository
+	relatedPostsCache      cache.Cache[int64, []*models.Post]
+	postNeighborsCache     cache.Cache[int64, []*models.PostNeighbor]
+	postListCache          cache.Cache[string, []*models.Post]
+	typesCache             cache.Cache[string, []*models.PostType]
+	categoriesCache        cache.Cache[string, []*models.Category]
 }
 
 func NewPostService(c *PostServiceConfig) IPostService {
 	return &postService{
-		postRepository:    c.postRepository,
-		historyRepository: c.historyRepository,
+		postRepository:         c.postRepository,
+		historyRepository:      c.historyRepository,
+		trendingRepository:     c.trendingRepository,
+		postNeighborRepository: c.postNeighborRepository,
+		relatedPostsCache:      c.relatedPostsCache,
+		postNeighborsCache:     c.postNeighborsCache,
+		postListCache:          c.postListCache,
+		typesCache:             c.typesCache,
+		categoriesCache:        c.categoriesCache,
 	}
 }
 
@@ -49,6 +88,8 @@ func (s *postService) Create(post *models.Post) (*models.Post, error) {
 		return nil, err
 	}
 
+	s.InvalidateCaches()
+
 	return result, nil
 }
 
@@ -70,12 +111,52 @@ func (s *postService) GetAll(query *dtos.PostsRequestQuery) ([]*models.Post, err
 	return result, nil
 }
 
+func (s *postService) GetAllByCursor(query *dtos.PostsRequestQuery) ([]*models.Post, string, string, error) {
+	query.IsCursorMode = true
+
+	posts, err := s.postRepository.GetAll(query)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	hasMore := len(posts) > query.Limit
+	if hasMore {
+		posts = posts[:query.Limit]
+	}
+
+	isPrev := query.Cursor != nil && query.Cursor.IsPrev
+	if isPrev {
+		for i, j := 0, len(posts)-1; i < j; i, j = i+1, j-1 {
+			posts[i], posts[j] = posts[j], posts[i]
+		}
+	}
+
+	if len(posts) == 0 {
+		return posts, "", "", nil
+	}
+
+	var nextCursor, prevCursor string
+	first, last := posts[0], posts[len(posts)-1]
+
+	if hasMore || isPrev {
+		nextCursor = helpers.EncodeCursor(&dtos.PostCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	if (hasMore && isPrev) || (!isPrev && query.Cursor != nil) {
+		prevCursor = helpers.EncodeCursor(&dtos.PostCursor{CreatedAt: first.CreatedAt, ID: first.ID, IsPrev: true})
+	}
+
+	return posts, nextCursor, prevCursor, nil
+}
+
 func (s *postService) Delete(id int64) error {
 	err := s.postRepository.Delete(id)
 	if err != nil {
 		return err
 	}
 
+	s.InvalidateCaches()
+
 	return nil
 }
 
@@ -93,23 +174,75 @@ func (s *postService) CountByQuery(
 }
 
 func (s *postService) GetTypes() ([]*models.PostType, error) {
+	if cached, ok := s.typesCache.Get(constants.CACHE_KEY_ALL); ok {
+		return cached, nil
+	}
+
 	result, err := s.postRepository.GetTypes()
 	if err != nil {
 		return nil, err
 	}
 
+	s.typesCache.Set(constants.CACHE_KEY_ALL, result)
+
 	return result, nil
 }
 
 func (s *postService) GetCategories() ([]*models.Category, error) {
+	if cached, ok := s.categoriesCache.Get(constants.CACHE_KEY_ALL); ok {
+		return cached, nil
+	}
+
 	result, err := s.postRepository.GetCategories()
 	if err != nil {
 		return nil, err
 	}
 
+	s.categoriesCache.Set(constants.CACHE_KEY_ALL, result)
+
+	return result, nil
+}
+
+func (s *postService) CreateType(postType *models.PostType) (*models.PostType, error) {
+	result, err := s.postRepository.InsertType(postType)
+	if err != nil {
+		return nil, err
+	}
+
+	s.typesCache.Clear()
+
+	return result, nil
+}
+
+func (s *postService) CreateCategory(category *models.Category) (*models.Category, error) {
+	result, err := s.postRepository.InsertCategory(category)
+	if err != nil {
+		return nil, err
+	}
+
+	s.categoriesCache.Clear()
+
 	return result, nil
 }
 
+func (s *postService) GetCacheStats() map[string]cache.Stats {
+	return map[string]cache.Stats{
+		"related_posts":  s.relatedPostsCache.Stats(),
+		"post_neighbors": s.postNeighborsCache.Stats(),
+		"post_lists":     s.postListCache.Stats(),
+		"types":          s.typesCache.Stats(),
+		"categories":     s.categoriesCache.Stats(),
+	}
+}
+
+// InvalidateCaches drops every cached post list, since any of them may
+// include a post that was just added or removed, or an author that changed.
+func (s *postService) InvalidateCaches() {
+	s.relatedPostsCache.Clear()
+	s.postNeighborsCache.Clear()
+	s.postListCache.Clear()
+}
+
 func (s *postService) GetRecommendedPosts(userID int64) ([]*models.Post, error) {
 	readCounts, err := s.historyRepository.GetCategoriesReadCountPastMonth(&models.History{UserID: userID})
 
@@ -129,75 +262,359 @@ func (s *postService) GetRecommendedPosts(userID int64) ([]*models.Post, error)
 	}
 
 	topCategoryID := readCounts[0].CategoryID
+	cacheKey := fmt.Sprintf(constants.CACHE_KEY_RECOMMENDED, topCategoryID)
+	if cached, ok := s.postListCache.Get(cacheKey); ok {
+		return cached, nil
+	}
+
 	posts, err := s.postRepository.GetTopLikedAndSharedPost(&models.Post{CategoryID: topCategoryID})
 	if err != nil {
 		return nil, err
 	}
 
+	s.postListCache.Set(cacheKey, posts)
+
 	return posts, nil
 }
 
-func (s *postService) GetTrendingPosts(userID int64) ([]*models.Post, error) {
-	readCounts, err := s.historyRepository.GetCategoriesReadCountPastWeek(&models.History{UserID: userID})
+// GetFeed fills every page partly from the member's top interest categories,
+// in proportion to how strong each interest is, and partly from new posts
+// elsewhere so the feed does not narrow over time. The interests are picked on
+// the first page and kept in the cursor, and each source pages on its own
+// keyset, so posts read in between never shift a source and make later pages
+// skip or repeat posts. The returned cursor is nil once no source has more.
+func (s *postService) GetFeed(userID int64, cursor *dtos.FeedCursor, limit int) ([]*dtos.FeedItem, *dtos.FeedCursor, error) {
+	if cursor == nil {
+		interests, err := s.historyRepository.GetCategoryInterests(userID, constants.PERSONAL_FEED_MAX_INTERESTS)
+		if err != nil {
+			return nil, nil, err
+		}
 
+		cursor = &dtos.FeedCursor{
+			Interests: []*dtos.FeedSourceCursor{},
+			Explore:   &dtos.FeedSourceCursor{},
+		}
+		for _, interest := range interests {
+			cursor.Interests = append(cursor.Interests, &dtos.FeedSourceCursor{
+				CategoryID: interest.CategoryID,
+				Score:      interest.Score,
+			})
+		}
+	}
+
+	interests := []*models.CategoryInterest{}
+	interestCategoryIDs := []int64{}
+	for _, source := range cursor.Interests {
+		interests = append(interests, &models.CategoryInterest{CategoryID: source.CategoryID, Score: source.Score})
+		interestCategoryIDs = append(interestCategoryIDs, source.CategoryID)
+	}
+
+	exploreSlots := limit
+	if len(interests) > 0 {
+		exploreSlots = int(math.Max(1, math.Round(float64(limit)*constants.PERSONAL_FEED_EXPLORATION_RATIO)))
+	}
+
+	interestSlots := allocateFeedSlots(interests, limit-exploreSlots)
+	nextCursor := &dtos.FeedCursor{Interests: []*dtos.FeedSourceCursor{}}
+	buckets := [][]*dtos.FeedItem{}
+	hasMore := false
+
+	for i, source := range cursor.Interests {
+		posts, nextSource, err := s.getFeedSource(source, interestSlots[i], &dtos.PostsRequestQuery{
+			CategoryIDs:    []int64{source.CategoryID},
+			UnreadByUserID: userID,
+			Sort:           constants.SORT_NEWEST,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		nextCursor.Interests = append(nextCursor.Interests, nextSource)
+		hasMore = hasMore || (interestSlots[i] > 0 && !nextSource.IsDone)
+		bucket := []*dtos.FeedItem{}
+		for _, post := range posts {
+			bucket = append(bucket, &dtos.FeedItem{
+				Post:   post,
+				Reason: fmt.Sprintf(constants.PERSONAL_FEED_REASON_INTEREST, post.Category.Name),
+			})
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	posts, nextSource, err := s.getFeedSource(cursor.Explore, exploreSlots, &dtos.PostsRequestQuery{
+		ExcludedCategoryIDs: interestCategoryIDs,
+		UnreadByUserID:      userID,
+		Sort:                constants.SORT_NEWEST,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nextCursor.Explore = nextSource
+	hasMore = hasMore || (exploreSlots > 0 && !nextSource.IsDone)
+	bucket := []*dtos.FeedItem{}
+	for _, post := range posts {
+		bucket = append(bucket, &dtos.FeedItem{
+			Post:   post,
+			Reason: fmt.Sprintf(constants.PERSONAL_FEED_REASON_EXPLORE, post.Category.Name),
+		})
+	}
+	buckets = append(buckets, bucket)
+
+	if !hasMore {
+		nextCursor = nil
+	}
+
+	return interleaveFeedItems(buckets), nextCursor, nil
+}
+
+// getFeedSource reads the next slots posts of one feed source after where its
+// cursor left off and returns the cursor for the page after.
+func (s *postService) getFeedSource(source *dtos.FeedSourceCursor, slots int, query *dtos.PostsRequestQuery) ([]*models.Post, *dtos.FeedSourceCursor, error) {
+	nextSource := *source
+	if source.IsDone || slots == 0 {
+		return []*models.Post{}, &nextSource, nil
+	}
+
+	query.IsCursorMode = true
+	query.Cursor = source.After
+	query.Limit = slots
+
+	posts, err := s.postRepository.GetAll(query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(posts) > slots {
+		posts = posts[:slots]
+	} else {
+		nextSource.IsDone = true
+	}
+
+	if len(posts) > 0 {
+		last := posts[len(posts)-1]
+		nextSource.After = &dtos.PostCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	return posts, &nextSource, nil
+}
+
+// allocateFeedSlots splits slots across interests by score, handing the
+// rounding remainder to the strongest interests first.
+func allocateFeedSlots(interests []*models.CategoryInterest, slots int) []int {
+	allocated := make([]int, len(interests))
+
+	totalScore := 0.0
+	for _, interest := range interests {
+		totalScore += interest.Score
+	}
+
+	if totalScore <= 0 {
+		return allocated
+	}
+
+	remaining := slots
+	for i, interest := range interests {
+		allocated[i] = int(math.Floor(float64(slots) * interest.Score / totalScore))
+		remaining -= allocated[i]
+	}
+
+	for i := 0; remaining > 0; i = (i + 1) % len(interests) {
+		allocated[i]++
+		remaining--
+	}
+
+	return allocated
+}
+
+func interleaveFeedItems(buckets [][]*dtos.FeedItem) []*dtos.FeedItem {
+	items := []*dtos.FeedItem{}
+	for i := 0; ; i++ {
+		isExhausted := true
+		for _, bucket := range buckets {
+			if i < len(bucket) {
+				items = append(items, bucket[i])
+				isExhausted = false
+			}
+		}
+
+		if isExhausted {
+			return items
+		}
+	}
+}
+
+// GetAlsoReadPosts recommends what readers of the member's recent posts also
+// read, summing the neighbour scores of those posts. Neighbours are cached per
+// post, so posts the member already read are filtered out after the cache
+// lookup. Members without enough reading overlap get global trending instead.
+func (s *postService) GetAlsoReadPosts(userID int64) ([]*models.Post, error) {
+	recentPostIDs, err := s.historyRepository.GetRecentPostIDs(userID, constants.ALSO_READ_RECENT_READS)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(readCounts) == 0 {
-		readCounts, err = s.historyRepository.GetCategoriesReadCountPastWeek(&models.History{})
+	neighbors, err := s.getPostNeighbors(recentPostIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := map[int64]float64{}
+	candidates := []*models.Post{}
+	candidateIDs := []int64{}
+	for _, neighbor := range neighbors {
+		if _, ok := scores[neighbor.NeighborID]; !ok {
+			candidates = append(candidates, &neighbor.Neighbor)
+			candidateIDs = append(candidateIDs, neighbor.NeighborID)
+		}
+		scores[neighbor.NeighborID] += neighbor.Score
+	}
+
+	posts := []*models.Post{}
+	if len(candidates) > 0 {
+		readPostIDs, err := s.historyRepository.GetReadPostIDs(userID, candidateIDs)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(readCounts) == 0 {
-			return []*models.Post{}, nil
+		isRead := map[int64]bool{}
+		for _, readPostID := range readPostIDs {
+			isRead[readPostID] = true
+		}
+
+		for _, candidate := range candidates {
+			if !isRead[candidate.ID] {
+				posts = append(posts, candidate)
+			}
+		}
+
+		sort.SliceStable(posts, func(i, j int) bool {
+			if scores[posts[i].ID] != scores[posts[j].ID] {
+				return scores[posts[i].ID] > scores[posts[j].ID]
+			}
+
+			return posts[i].CreatedAt.After(posts[j].CreatedAt)
+		})
+
+		if len(posts) > constants.ALSO_READ_LIMIT {
+			posts = posts[:constants.ALSO_READ_LIMIT]
 		}
 	}
 
-	topCategoryID := readCounts[0].CategoryID
-	posts, err := s.historyRepository.GetTrendingPostsByCategory(topCategoryID)
+	if len(posts) == 0 {
+		return s.trendingRepository.GetPosts(0, constants.ALSO_READ_LIMIT)
+	}
+
+	return posts, nil
+}
+
+func (s *postService) getPostNeighbors(postIDs []int64) ([]*models.PostNeighbor, error) {
+	neighbors := []*models.PostNeighbor{}
+	missingPostIDs := []int64{}
+	for _, postID := range postIDs {
+		cached, ok := s.postNeighborsCache.Get(postID)
+		if !ok {
+			missingPostIDs = append(missingPostIDs, postID)
+			continue
+		}
+
+		neighbors = append(neighbors, cached...)
+	}
+
+	if len(missingPostIDs) == 0 {
+		return neighbors, nil
+	}
+
+	fetched, err := s.postNeighborRepository.GetByPostIDs(missingPostIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	return posts, nil
+	neighborsByPostID := map[int64][]*models.PostNeighbor{}
+	for _, neighbor := range fetched {
+		neighborsByPostID[neighbor.PostID] = append(neighborsByPostID[neighbor.PostID], neighbor)
+	}
+
+	for _, postID := range missingPostIDs {
+		postNeighbors, ok := neighborsByPostID[postID]
+		if !ok {
+			postNeighbors = []*models.PostNeighbor{}
+		}
+
+		s.postNeighborsCache.Set(postID, postNeighbors)
+		neighbors = append(neighbors, postNeighbors...)
+	}
+
+	return neighbors, nil
 }
 
-func (s *postService) Like(postID int64, userID int64, isLike bool) (*models.Post, *models.History, error) {
-	post, err := s.postRepository.GetByID(postID)
+func (s *postService) RefreshPostNeighbors() (int64, error) {
+	refreshed, err := s.postNeighborRepository.Refresh()
 	if err != nil {
-		return nil, nil, err
+		return 0, err
+	}
+
+	s.postNeighborsCache.Clear()
+
+	return refreshed, nil
+}
+
+func (s *postService) GetTrendingPosts(query *dtos.TrendingPostsQuery) ([]*models.Post, error) {
+	categoryID := query.CategoryID
+
+	if categoryID == 0 && !query.IsGlobal {
+		readCounts, err := s.historyRepository.GetCategoriesReadCountPastWeek(&models.History{UserID: query.UserID})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(readCounts) > 0 {
+			categoryID = readCounts[0].CategoryID
+		}
 	}
 
-	incrementValue := 1
-	if !isLike {
-		incrementValue = -1
+	cacheKey := fmt.Sprintf(constants.CACHE_KEY_TRENDING, categoryID)
+	if cached, ok := s.postListCache.Get(cacheKey); ok {
+		return cached, nil
 	}
 
-	post, rowsAffected, err := s.postRepository.UpdateSingleColumn("LikeCount", &models.Post{ID: postID, LikeCount: post.LikeCount + incrementValue})
+	posts, err := s.trendingRepository.GetPosts(categoryID, constants.TRENDING_POSTS_LIMIT)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	if rowsAffected == 0 {
-		return nil, nil, gorm.ErrRecordNotFound
+	s.postListCache.Set(cacheKey, posts)
+
+	return posts, nil
+}
+
+func (s *postService) RefreshTrending() (int64, error) {
+	refreshed, err := s.trendingRepository.Refresh()
+	if err != nil {
+		return 0, err
 	}
 
-	updatedHistory, rowsAffected, err := s.historyRepository.UpdateSingleColumn("IsLiked", &models.History{UserID: userID, PostID: postID, IsLiked: isLike})
+	s.postListCache.Clear()
+
+	return refreshed, nil
+}
+
+func (s *postService) Like(postID int64, userID int64, isLike bool) (*models.Post, *models.History, error) {
+	history, _, err := s.historyRepository.SetLiked(userID, postID, isLike)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	if rowsAffected == 0 {
-		return nil, nil, gorm.ErrRecordNotFound
+	post, err := s.postRepository.GetByID(postID)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return post, updatedHistory, err
+	return post, history, nil
 }
 
 func (s *postService) Share(postID int64, userID int64) (*models.Post, *models.History, error) {
-	history, err := s.historyRepository.GetByUserAndPostID(userID, postID)
+	history, _, err := s.historyRepository.SetShared(userID, postID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -207,27 +624,112 @@ func (s *postService) Share(postID int64, userID int64) (*models.Post, *models.H
 		return nil, nil, err
 	}
 
-	if history.IsShared {
-		return post, history, nil
+	return post, history, nil
+}
+
+func (s *postService) React(postID int64, userID int64, reaction constants.Reaction) (*models.Post, *models.History, error) {
+	history, _, err := s.historyRepository.SetReaction(userID, postID, reaction)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	updatedHistory, rowsAffected, err := s.historyRepository.UpdateSingleColumn("IsShared", &models.History{UserID: userID, PostID: postID, IsShared: true})
+	post, err := s.postRepository.GetByID(postID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	if rowsAffected == 0 {
-		return nil, nil, gorm.ErrRecordNotFound
+	return post, history, nil
+}
+
+func (s *postService) GetAnalytics(postID int64) (*models.Post, *models.PostReadStats, error) {
+	post, err := s.postRepository.GetByID(postID)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	post, rowsAffected, err = s.postRepository.UpdateSingleColumn("ShareCount", &models.Post{ID: postID, ShareCount: post.ShareCount + 1})
+	stats, err := s.historyRepository.GetReadStats(postID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	if rowsAffected == 0 {
-		return nil, nil, gorm.ErrRecordNotFound
+	return post, stats, nil
+}
+
+func (s *postService) ReconcileCounters() (int64, error) {
+	return s.postRepository.ReconcileCounters()
+}
+
+func (s *postService) CountSitemapPages() (int64, error) {
+	totalPosts, err := s.postRepository.CountByQuery(&dtos.PostsRequestQuery{})
+	if err != nil {
+		return 0, err
+	}
+
+	totalPages := int64(math.Ceil(float64(totalPosts) / float64(constants.SITEMAP_PAGE_SIZE)))
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	return totalPages, nil
+}
+
+func (s *postService) GetSitemapPage(page int) ([]*models.Post, error) {
+	result, err := s.postRepository.GetSitemapEntries((page-1)*constants.SITEMAP_PAGE_SIZE, constants.SITEMAP_PAGE_SIZE)
+	if err != nil {
+		return nil, err
 	}
 
-	return post, updatedHistory, err
+	return result, nil
+}
+
+// GetRelatedPosts caches the ranked candidates per post, so the filtering of
+// posts the user already read happens after the cache lookup.
+func (s *postService) GetRelatedPosts(postID int64, userID int64) ([]*models.Post, error) {
+	candidates, ok := s.relatedPostsCache.Get(postID)
+	if !ok {
+		post, err := s.postRepository.GetByID(postID)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates, err = s.postRepository.GetRelatedPosts(post, constants.RELATED_POSTS_CANDIDATE_LIMIT)
+		if err != nil {
+			return nil, err
+		}
+
+		s.relatedPostsCache.Set(postID, candidates)
+	}
+
+	if len(candidates) == 0 {
+		return []*models.Post{}, nil
+	}
+
+	candidateIDs := []int64{}
+	for _, candidate := range candidates {
+		candidateIDs = append(candidateIDs, candidate.ID)
+	}
+
+	readPostIDs, err := s.historyRepository.GetReadPostIDs(userID, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	isRead := map[int64]bool{}
+	for _, readPostID := range readPostIDs {
+		isRead[readPostID] = true
+	}
+
+	posts := []*models.Post{}
+	for _, candidate := range candidates {
+		if isRead[candidate.ID] {
+			continue
+		}
+
+		posts = append(posts, candidate)
+		if len(posts) == constants.RELATED_POSTS_LIMIT {
+			break
+		}
+	}
+
+	return posts, nil
 }
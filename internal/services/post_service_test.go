@@ -3,12 +3,17 @@ package services
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"final-project-backend/internal/cache"
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/dtos"
+	"final-project-backend/internal/helpers"
 	"final-project-backend/internal/models"
 	mocks "final-project-backend/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestNewPostService(t *testing.T) {
@@ -71,7 +76,10 @@ func Test_postService_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &postService{
-				postRepository: tt.fields.postRepository,
+				postRepository:     tt.fields.postRepository,
+				relatedPostsCache:  cache.NewLRUCache[int64, []*models.Post](10, time.Minute),
+				postNeighborsCache: cache.NewLRUCache[int64, []*models.PostNeighbor](10, time.Minute),
+				postListCache:      cache.NewLRUCache[string, []*models.Post](10, time.Minute),
 			}
 
 			tt.mock(tt.fields.postRepository)
@@ -221,6 +229,105 @@ func Test_postService_GetAll(t *testing.T) {
 	}
 }
 
+func Test_postService_GetAllByCursor(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockCursor := &dtos.PostCursor{ID: 10}
+	mockPosts := func() []*models.Post {
+		return []*models.Post{{ID: 3}, {ID: 2}, {ID: 1}}
+	}
+	type fields struct {
+		postRepository *mocks.IPostRepository
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		query          *dtos.PostsRequestQuery
+		mock           func(*mocks.IPostRepository, *dtos.PostsRequestQuery)
+		wantIDs        []int64
+		wantNextCursor string
+		wantPrevCursor string
+		wantErr        bool
+		expectedErr    error
+	}{
+		{
+			name: "ERROR | Error from PostRepository.GetAll",
+			fields: fields{
+				postRepository: mocks.NewIPostRepository(t),
+			},
+			query: &dtos.PostsRequestQuery{Limit: 2},
+			mock: func(r *mocks.IPostRepository, q *dtos.PostsRequestQuery) {
+				r.On("GetAll", q).Return(nil, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS | First page with more rows",
+			fields: fields{
+				postRepository: mocks.NewIPostRepository(t),
+			},
+			query: &dtos.PostsRequestQuery{Limit: 2},
+			mock: func(r *mocks.IPostRepository, q *dtos.PostsRequestQuery) {
+				r.On("GetAll", q).Return(mockPosts(), nil)
+			},
+			wantIDs:        []int64{3, 2},
+			wantNextCursor: helpers.EncodeCursor(&dtos.PostCursor{ID: 2}),
+			wantPrevCursor: "",
+		},
+		{
+			name: "SUCCESS | Last page after a cursor",
+			fields: fields{
+				postRepository: mocks.NewIPostRepository(t),
+			},
+			query: &dtos.PostsRequestQuery{Limit: 3, Cursor: mockCursor},
+			mock: func(r *mocks.IPostRepository, q *dtos.PostsRequestQuery) {
+				r.On("GetAll", q).Return(mockPosts(), nil)
+			},
+			wantIDs:        []int64{3, 2, 1},
+			wantNextCursor: "",
+			wantPrevCursor: helpers.EncodeCursor(&dtos.PostCursor{ID: 3, IsPrev: true}),
+		},
+		{
+			name: "SUCCESS | Paging backwards reverses rows",
+			fields: fields{
+				postRepository: mocks.NewIPostRepository(t),
+			},
+			query: &dtos.PostsRequestQuery{Limit: 2, Cursor: &dtos.PostCursor{ID: 0, IsPrev: true}},
+			mock: func(r *mocks.IPostRepository, q *dtos.PostsRequestQuery) {
+				r.On("GetAll", q).Return(mockPosts(), nil)
+			},
+			wantIDs:        []int64{2, 3},
+			wantNextCursor: helpers.EncodeCursor(&dtos.PostCursor{ID: 3}),
+			wantPrevCursor: helpers.EncodeCursor(&dtos.PostCursor{ID: 2, IsPrev: true}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &postService{
+				postRepository: tt.fields.postRepository,
+			}
+
+			tt.mock(tt.fields.postRepository, tt.query)
+			got, nextCursor, prevCursor, err := s.GetAllByCursor(tt.query)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				gotIDs := []int64{}
+				for _, post := range got {
+					gotIDs = append(gotIDs, post.ID)
+				}
+				assert.True(t, tt.query.IsCursorMode)
+				assert.Equal(t, tt.wantIDs, gotIDs)
+				assert.Equal(t, tt.wantNextCursor, nextCursor)
+				assert.Equal(t, tt.wantPrevCursor, prevCursor)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
+
 func Test_postService_Delete(t *testing.T) {
 	mockPost := &models.Post{
 		ID: 1,
@@ -272,7 +379,10 @@ func Test_postService_Delete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &postService{
-				postRepository: tt.fields.postRepository,
+				postRepository:     tt.fields.postRepository,
+				relatedPostsCache:  cache.NewLRUCache[int64, []*models.Post](10, time.Minute),
+				postNeighborsCache: cache.NewLRUCache[int64, []*models.PostNeighbor](10, time.Minute),
+				postListCache:      cache.NewLRUCache[string, []*models.Post](10, time.Minute),
 			}
 
 			tt.mock(tt.fields.postRepository)
@@ -351,6 +461,23 @@ func Test_postService_CountByQuery(t *testing.T) {
 	}
 }
 
+func Test_postService_InvalidateCaches(t *testing.T) {
+	s := &postService{
+		relatedPostsCache:  cache.NewLRUCache[int64, []*models.Post](10, time.Minute),
+		postNeighborsCache: cache.NewLRUCache[int64, []*models.PostNeighbor](10, time.Minute),
+		postListCache:      cache.NewLRUCache[string, []*models.Post](10, time.Minute),
+	}
+	s.relatedPostsCache.Set(1, []*models.Post{{ID: 2}})
+	s.postNeighborsCache.Set(1, []*models.PostNeighbor{{PostID: 1, NeighborID: 2}})
+	s.postListCache.Set(constants.CACHE_KEY_ALL, []*models.Post{{ID: 2}})
+
+	s.InvalidateCaches()
+
+	assert.Equal(t, 0, s.relatedPostsCache.Stats().Size)
+	assert.Equal(t, 0, s.postNeighborsCache.Stats().Size)
+	assert.Equal(t, 0, s.postListCache.Stats().Size)
+}
+
 func Test_postService_GetTypes(t *testing.T) {
 	mockError := fmt.Errorf("error")
 	type fields struct {
@@ -393,6 +520,7 @@ func Test_postService_GetTypes(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &postService{
 				postRepository: tt.fields.postRepository,
+				typesCache:     cache.NewLRUCache[string, []*models.PostType](1, time.Minute),
 			}
 
 			tt.mock(tt.fields.postRepository)
@@ -450,7 +578,8 @@ func Test_postService_GetCategories(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &postService{
-				postRepository: tt.fields.postRepository,
+				postRepository:  tt.fields.postRepository,
+				categoriesCache: cache.NewLRUCache[string, []*models.Category](1, time.Minute),
 			}
 
 			tt.mock(tt.fields.postRepository)
@@ -466,3 +595,885 @@ func Test_postService_GetCategories(t *testing.T) {
 		})
 	}
 }
+
+func Test_postService_CountSitemapPages(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	type fields struct {
+		postRepository *mocks.IPostRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(*mocks.IPostRepository)
+		want        int64
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from PostRepository.CountByQuery",
+			fields: fields{
+				postRepository: mocks.NewIPostRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository) {
+				r.On("CountByQuery", &dtos.PostsRequestQuery{}).Return(int64(0), mockError)
+			},
+			want:        0,
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS | No posts still has one page",
+			fields: fields{
+				postRepository: mocks.NewIPostRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository) {
+				r.On("CountByQuery", &dtos.PostsRequestQuery{}).Return(int64(0), nil)
+			},
+			want:        1,
+			wantErr:     false,
+			expectedErr: nil,
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				postRepository: mocks.NewIPostRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository) {
+				r.On("CountByQuery", &dtos.PostsRequestQuery{}).Return(int64(constants.SITEMAP_PAGE_SIZE+1), nil)
+			},
+			want:        2,
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &postService{
+				postRepository: tt.fields.postRepository,
+			}
+
+			tt.mock(tt.fields.postRepository)
+			got, err := s.CountSitemapPages()
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_postService_GetSitemapPage(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	type fields struct {
+		postRepository *mocks.IPostRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(*mocks.IPostRepository)
+		want        []*models.Post
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from PostRepository.GetSitemapEntries",
+			fields: fields{
+				postRepository: mocks.NewIPostRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository) {
+				r.On("GetSitemapEntries", constants.SITEMAP_PAGE_SIZE, constants.SITEMAP_PAGE_SIZE).Return(nil, mockError)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				postRepository: mocks.NewIPostRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository) {
+				r.On("GetSitemapEntries", constants.SITEMAP_PAGE_SIZE, constants.SITEMAP_PAGE_SIZE).Return([]*models.Post{{ID: 1}}, nil)
+			},
+			want:        []*models.Post{{ID: 1}},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &postService{
+				postRepository: tt.fields.postRepository,
+			}
+
+			tt.mock(tt.fields.postRepository)
+			got, err := s.GetSitemapPage(2)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_postService_GetRelatedPosts(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockPost := &models.Post{ID: 1}
+	mockCandidates := []*models.Post{{ID: 2}, {ID: 3}, {ID: 4}}
+	type fields struct {
+		postRepository    *mocks.IPostRepository
+		historyRepository *mocks.IHistoryRepository
+		relatedPostsCache cache.Cache[int64, []*models.Post]
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(*mocks.IPostRepository, *mocks.IHistoryRepository)
+		want        []*models.Post
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from PostRepository.GetByID",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+				relatedPostsCache: cache.NewLRUCache[int64, []*models.Post](10, time.Minute),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				r.On("GetByID", mockPost.ID).Return(nil, mockError)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "ERROR | Error from PostRepository.GetRelatedPosts",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+				relatedPostsCache: cache.NewLRUCache[int64, []*models.Post](10, time.Minute),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				r.On("GetByID", mockPost.ID).Return(mockPost, nil)
+				r.On("GetRelatedPosts", mockPost, constants.RELATED_POSTS_CANDIDATE_LIMIT).Return(nil, mockError)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "ERROR | Error from HistoryRepository.GetReadPostIDs",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+				relatedPostsCache: cache.NewLRUCache[int64, []*models.Post](10, time.Minute),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				r.On("GetByID", mockPost.ID).Return(mockPost, nil)
+				r.On("GetRelatedPosts", mockPost, constants.RELATED_POSTS_CANDIDATE_LIMIT).Return(mockCandidates, nil)
+				h.On("GetReadPostIDs", int64(1), []int64{2, 3, 4}).Return(nil, mockError)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS | Cache miss excludes read posts",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+				relatedPostsCache: cache.NewLRUCache[int64, []*models.Post](10, time.Minute),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				r.On("GetByID", mockPost.ID).Return(mockPost, nil)
+				r.On("GetRelatedPosts", mockPost, constants.RELATED_POSTS_CANDIDATE_LIMIT).Return(mockCandidates, nil)
+				h.On("GetReadPostIDs", int64(1), []int64{2, 3, 4}).Return([]int64{3}, nil)
+			},
+			want:        []*models.Post{{ID: 2}, {ID: 4}},
+			wantErr:     false,
+			expectedErr: nil,
+		},
+		{
+			name: "SUCCESS | Cache hit skips PostRepository",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+				relatedPostsCache: func() cache.Cache[int64, []*models.Post] {
+					c := cache.NewLRUCache[int64, []*models.Post](10, time.Minute)
+					c.Set(mockPost.ID, mockCandidates)
+					return c
+				}(),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				h.On("GetReadPostIDs", int64(1), []int64{2, 3, 4}).Return([]int64{}, nil)
+			},
+			want:        mockCandidates,
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &postService{
+				postRepository:    tt.fields.postRepository,
+				historyRepository: tt.fields.historyRepository,
+				relatedPostsCache: tt.fields.relatedPostsCache,
+			}
+
+			tt.mock(tt.fields.postRepository, tt.fields.historyRepository)
+			got, err := s.GetRelatedPosts(mockPost.ID, 1)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_postService_Like(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	type fields struct {
+		postRepository    *mocks.IPostRepository
+		historyRepository *mocks.IHistoryRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(*mocks.IPostRepository, *mocks.IHistoryRepository)
+		wantPost    *models.Post
+		wantHistory *models.History
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from HistoryRepository.SetLiked",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				h.On("SetLiked", int64(2), int64(1), true).Return(nil, false, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "ERROR | Error from PostRepository.GetByID",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				h.On("SetLiked", int64(2), int64(1), true).Return(&models.History{UserID: 2, PostID: 1, IsLiked: true}, true, nil)
+				r.On("GetByID", int64(1)).Return(nil, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS | Already liked",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				h.On("SetLiked", int64(2), int64(1), true).Return(&models.History{UserID: 2, PostID: 1, IsLiked: true}, false, nil)
+				r.On("GetByID", int64(1)).Return(&models.Post{ID: 1, LikeCount: 1}, nil)
+			},
+			wantPost:    &models.Post{ID: 1, LikeCount: 1},
+			wantHistory: &models.History{UserID: 2, PostID: 1, IsLiked: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &postService{
+				postRepository:    tt.fields.postRepository,
+				historyRepository: tt.fields.historyRepository,
+			}
+
+			tt.mock(tt.fields.postRepository, tt.fields.historyRepository)
+			gotPost, gotHistory, err := s.Like(1, 2, true)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.wantPost, gotPost)
+			assert.Equal(t, tt.wantHistory, gotHistory)
+		})
+	}
+}
+
+func Test_postService_Share(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	type fields struct {
+		postRepository    *mocks.IPostRepository
+		historyRepository *mocks.IHistoryRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(*mocks.IPostRepository, *mocks.IHistoryRepository)
+		wantPost    *models.Post
+		wantHistory *models.History
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from HistoryRepository.SetShared",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				h.On("SetShared", int64(2), int64(1)).Return(nil, false, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				h.On("SetShared", int64(2), int64(1)).Return(&models.History{UserID: 2, PostID: 1, IsShared: true}, true, nil)
+				r.On("GetByID", int64(1)).Return(&models.Post{ID: 1, ShareCount: 1}, nil)
+			},
+			wantPost:    &models.Post{ID: 1, ShareCount: 1},
+			wantHistory: &models.History{UserID: 2, PostID: 1, IsShared: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &postService{
+				postRepository:    tt.fields.postRepository,
+				historyRepository: tt.fields.historyRepository,
+			}
+
+			tt.mock(tt.fields.postRepository, tt.fields.historyRepository)
+			gotPost, gotHistory, err := s.Share(1, 2)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.wantPost, gotPost)
+			assert.Equal(t, tt.wantHistory, gotHistory)
+		})
+	}
+}
+
+func Test_postService_React(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	type fields struct {
+		postRepository    *mocks.IPostRepository
+		historyRepository *mocks.IHistoryRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(*mocks.IPostRepository, *mocks.IHistoryRepository)
+		wantPost    *models.Post
+		wantHistory *models.History
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from HistoryRepository.SetReaction",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				h.On("SetReaction", int64(2), int64(1), constants.REACTION_SAD).Return(nil, false, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				h.On("SetReaction", int64(2), int64(1), constants.REACTION_SAD).Return(&models.History{UserID: 2, PostID: 1, Reaction: constants.REACTION_SAD}, true, nil)
+				r.On("GetByID", int64(1)).Return(&models.Post{ID: 1, SadCount: 1, ReactionCount: 1}, nil)
+			},
+			wantPost:    &models.Post{ID: 1, SadCount: 1, ReactionCount: 1},
+			wantHistory: &models.History{UserID: 2, PostID: 1, Reaction: constants.REACTION_SAD},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &postService{
+				postRepository:    tt.fields.postRepository,
+				historyRepository: tt.fields.historyRepository,
+			}
+
+			tt.mock(tt.fields.postRepository, tt.fields.historyRepository)
+			gotPost, gotHistory, err := s.React(1, 2, constants.REACTION_SAD)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.wantPost, gotPost)
+			assert.Equal(t, tt.wantHistory, gotHistory)
+		})
+	}
+}
+
+func Test_postService_GetAnalytics(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockStats := &models.PostReadStats{ReadCount: 4, CompletedCount: 1, AverageProgress: 62.5}
+	type fields struct {
+		postRepository    *mocks.IPostRepository
+		historyRepository *mocks.IHistoryRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(*mocks.IPostRepository, *mocks.IHistoryRepository)
+		wantPost    *models.Post
+		wantStats   *models.PostReadStats
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from PostRepository.GetByID",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				r.On("GetByID", int64(1)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "ERROR | Error from HistoryRepository.GetReadStats",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				r.On("GetByID", int64(1)).Return(&models.Post{ID: 1}, nil)
+				h.On("GetReadStats", int64(1)).Return(nil, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				r.On("GetByID", int64(1)).Return(&models.Post{ID: 1}, nil)
+				h.On("GetReadStats", int64(1)).Return(mockStats, nil)
+			},
+			wantPost:  &models.Post{ID: 1},
+			wantStats: mockStats,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &postService{
+				postRepository:    tt.fields.postRepository,
+				historyRepository: tt.fields.historyRepository,
+			}
+
+			tt.mock(tt.fields.postRepository, tt.fields.historyRepository)
+			gotPost, gotStats, err := s.GetAnalytics(1)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.wantPost, gotPost)
+			assert.Equal(t, tt.wantStats, gotStats)
+		})
+	}
+}
+
+func Test_postService_GetTrendingPosts(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockPosts := []*models.Post{{ID: 1}, {ID: 2}}
+	type fields struct {
+		historyRepository  *mocks.IHistoryRepository
+		trendingRepository *mocks.ITrendingRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		query       *dtos.TrendingPostsQuery
+		mock        func(*mocks.IHistoryRepository, *mocks.ITrendingRepository)
+		want        []*models.Post
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from HistoryRepository.GetCategoriesReadCountPastWeek",
+			fields: fields{
+				historyRepository:  mocks.NewIHistoryRepository(t),
+				trendingRepository: mocks.NewITrendingRepository(t),
+			},
+			query: &dtos.TrendingPostsQuery{UserID: 1},
+			mock: func(h *mocks.IHistoryRepository, r *mocks.ITrendingRepository) {
+				h.On("GetCategoriesReadCountPastWeek", &models.History{UserID: 1}).Return(nil, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "ERROR | Error from TrendingRepository.GetPosts",
+			fields: fields{
+				historyRepository:  mocks.NewIHistoryRepository(t),
+				trendingRepository: mocks.NewITrendingRepository(t),
+			},
+			query: &dtos.TrendingPostsQuery{UserID: 1, IsGlobal: true},
+			mock: func(h *mocks.IHistoryRepository, r *mocks.ITrendingRepository) {
+				r.On("GetPosts", int64(0), constants.TRENDING_POSTS_LIMIT).Return(nil, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS | User's top category",
+			fields: fields{
+				historyRepository:  mocks.NewIHistoryRepository(t),
+				trendingRepository: mocks.NewITrendingRepository(t),
+			},
+			query: &dtos.TrendingPostsQuery{UserID: 1},
+			mock: func(h *mocks.IHistoryRepository, r *mocks.ITrendingRepository) {
+				h.On("GetCategoriesReadCountPastWeek", &models.History{UserID: 1}).Return([]*models.PostCategoryReadCount{{CategoryID: 3}}, nil)
+				r.On("GetPosts", int64(3), constants.TRENDING_POSTS_LIMIT).Return(mockPosts, nil)
+			},
+			want: mockPosts,
+		},
+		{
+			name: "SUCCESS | Falls back to global without reads this week",
+			fields: fields{
+				historyRepository:  mocks.NewIHistoryRepository(t),
+				trendingRepository: mocks.NewITrendingRepository(t),
+			},
+			query: &dtos.TrendingPostsQuery{UserID: 1},
+			mock: func(h *mocks.IHistoryRepository, r *mocks.ITrendingRepository) {
+				h.On("GetCategoriesReadCountPastWeek", &models.History{UserID: 1}).Return([]*models.PostCategoryReadCount{}, nil)
+				r.On("GetPosts", int64(0), constants.TRENDING_POSTS_LIMIT).Return(mockPosts, nil)
+			},
+			want: mockPosts,
+		},
+		{
+			name: "SUCCESS | Requested category",
+			fields: fields{
+				historyRepository:  mocks.NewIHistoryRepository(t),
+				trendingRepository: mocks.NewITrendingRepository(t),
+			},
+			query: &dtos.TrendingPostsQuery{UserID: 1, CategoryID: 2},
+			mock: func(h *mocks.IHistoryRepository, r *mocks.ITrendingRepository) {
+				r.On("GetPosts", int64(2), constants.TRENDING_POSTS_LIMIT).Return(mockPosts, nil)
+			},
+			want: mockPosts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &postService{
+				historyRepository:  tt.fields.historyRepository,
+				trendingRepository: tt.fields.trendingRepository,
+				postListCache:      cache.NewLRUCache[string, []*models.Post](10, time.Minute),
+			}
+
+			tt.mock(tt.fields.historyRepository, tt.fields.trendingRepository)
+			got, err := s.GetTrendingPosts(tt.query)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_postService_GetFeed(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockInterests := []*models.CategoryInterest{
+		{CategoryID: 1, CategoryName: "Finance", Score: 3},
+		{CategoryID: 2, CategoryName: "Sports", Score: 1},
+	}
+	newPost := func(id int64, category string) *models.Post {
+		post := &models.Post{ID: id, Category: models.Category{Name: category}}
+		post.CreatedAt = time.Unix(1000-id, 0)
+		return post
+	}
+	financePosts := []*models.Post{newPost(1, "Finance"), newPost(2, "Finance"), newPost(3, "Finance"), newPost(6, "Finance")}
+	sportsPosts := []*models.Post{newPost(4, "Sports")}
+	explorePosts := []*models.Post{newPost(5, "Tech")}
+	mockCursor := &dtos.FeedCursor{
+		Interests: []*dtos.FeedSourceCursor{
+			{CategoryID: 1, Score: 3, After: &dtos.PostCursor{CreatedAt: financePosts[2].CreatedAt, ID: 3}},
+			{CategoryID: 2, Score: 1, After: &dtos.PostCursor{CreatedAt: sportsPosts[0].CreatedAt, ID: 4}, IsDone: true},
+		},
+		Explore: &dtos.FeedSourceCursor{IsDone: true},
+	}
+	type fields struct {
+		postRepository    *mocks.IPostRepository
+		historyRepository *mocks.IHistoryRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		cursor      *dtos.FeedCursor
+		mock        func(*mocks.IPostRepository, *mocks.IHistoryRepository)
+		want        []*dtos.FeedItem
+		wantCursor  *dtos.FeedCursor
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from HistoryRepository.GetCategoryInterests",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				h.On("GetCategoryInterests", int64(1), constants.PERSONAL_FEED_MAX_INTERESTS).Return(nil, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "ERROR | Error from PostRepository.GetAll",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				h.On("GetCategoryInterests", int64(1), constants.PERSONAL_FEED_MAX_INTERESTS).Return(mockInterests, nil)
+				r.On("GetAll", &dtos.PostsRequestQuery{CategoryIDs: []int64{1}, UnreadByUserID: 1, Sort: constants.SORT_NEWEST, Limit: 3, IsCursorMode: true}).Return(nil, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS | Cold start only explores",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				h.On("GetCategoryInterests", int64(1), constants.PERSONAL_FEED_MAX_INTERESTS).Return([]*models.CategoryInterest{}, nil)
+				r.On("GetAll", &dtos.PostsRequestQuery{ExcludedCategoryIDs: []int64{}, UnreadByUserID: 1, Sort: constants.SORT_NEWEST, Limit: 5, IsCursorMode: true}).Return(explorePosts, nil)
+			},
+			want:       []*dtos.FeedItem{{Post: explorePosts[0], Reason: "New in Tech"}},
+			wantCursor: nil,
+		},
+		{
+			name: "SUCCESS | Mixes interests by weight with exploration",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				h.On("GetCategoryInterests", int64(1), constants.PERSONAL_FEED_MAX_INTERESTS).Return(mockInterests, nil)
+				r.On("GetAll", &dtos.PostsRequestQuery{CategoryIDs: []int64{1}, UnreadByUserID: 1, Sort: constants.SORT_NEWEST, Limit: 3, IsCursorMode: true}).Return(financePosts, nil)
+				r.On("GetAll", &dtos.PostsRequestQuery{CategoryIDs: []int64{2}, UnreadByUserID: 1, Sort: constants.SORT_NEWEST, Limit: 1, IsCursorMode: true}).Return(sportsPosts, nil)
+				r.On("GetAll", &dtos.PostsRequestQuery{ExcludedCategoryIDs: []int64{1, 2}, UnreadByUserID: 1, Sort: constants.SORT_NEWEST, Limit: 1, IsCursorMode: true}).Return([]*models.Post{}, nil)
+			},
+			want: []*dtos.FeedItem{
+				{Post: financePosts[0], Reason: "Because you read Finance"},
+				{Post: sportsPosts[0], Reason: "Because you read Sports"},
+				{Post: financePosts[1], Reason: "Because you read Finance"},
+				{Post: financePosts[2], Reason: "Because you read Finance"},
+			},
+			wantCursor: mockCursor,
+		},
+		{
+			name: "SUCCESS | Next page keeps the interests and continues each source",
+			fields: fields{
+				postRepository:    mocks.NewIPostRepository(t),
+				historyRepository: mocks.NewIHistoryRepository(t),
+			},
+			cursor: mockCursor,
+			mock: func(r *mocks.IPostRepository, h *mocks.IHistoryRepository) {
+				r.On("GetAll", &dtos.PostsRequestQuery{CategoryIDs: []int64{1}, UnreadByUserID: 1, Sort: constants.SORT_NEWEST, Limit: 3, IsCursorMode: true, Cursor: mockCursor.Interests[0].After}).Return(financePosts[3:], nil)
+			},
+			want: []*dtos.FeedItem{
+				{Post: financePosts[3], Reason: "Because you read Finance"},
+			},
+			wantCursor: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &postService{
+				postRepository:    tt.fields.postRepository,
+				historyRepository: tt.fields.historyRepository,
+			}
+
+			tt.mock(tt.fields.postRepository, tt.fields.historyRepository)
+			got, gotCursor, err := s.GetFeed(1, tt.cursor, 5)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCursor, gotCursor)
+		})
+	}
+}
+
+func Test_postService_GetAlsoReadPosts(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockUserID := int64(1)
+	mockRecentPostIDs := []int64{10, 11}
+	mockNeighbors := []*models.PostNeighbor{
+		{PostID: 10, NeighborID: 1, Neighbor: models.Post{ID: 1}, Score: 0.5},
+		{PostID: 10, NeighborID: 2, Neighbor: models.Post{ID: 2}, Score: 0.4},
+		{PostID: 11, NeighborID: 3, Neighbor: models.Post{ID: 3}, Score: 0.3},
+		{PostID: 11, NeighborID: 2, Neighbor: models.Post{ID: 2}, Score: 0.3},
+	}
+	mockPosts := []*models.Post{{ID: 1}, {ID: 2}}
+	type fields struct {
+		historyRepository      *mocks.IHistoryRepository
+		trendingRepository     *mocks.ITrendingRepository
+		postNeighborRepository *mocks.IPostNeighborRepository
+		postNeighborsCache     cache.Cache[int64, []*models.PostNeighbor]
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(fields)
+		want        []*models.Post
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from PostNeighborRepository.GetByPostIDs",
+			fields: fields{
+				historyRepository:      mocks.NewIHistoryRepository(t),
+				trendingRepository:     mocks.NewITrendingRepository(t),
+				postNeighborRepository: mocks.NewIPostNeighborRepository(t),
+				postNeighborsCache:     cache.NewLRUCache[int64, []*models.PostNeighbor](10, time.Minute),
+			},
+			mock: func(f fields) {
+				f.historyRepository.On("GetRecentPostIDs", mockUserID, constants.ALSO_READ_RECENT_READS).Return(mockRecentPostIDs, nil)
+				f.postNeighborRepository.On("GetByPostIDs", mockRecentPostIDs).Return(nil, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS | Sums neighbour scores and excludes read posts",
+			fields: fields{
+				historyRepository:      mocks.NewIHistoryRepository(t),
+				trendingRepository:     mocks.NewITrendingRepository(t),
+				postNeighborRepository: mocks.NewIPostNeighborRepository(t),
+				postNeighborsCache:     cache.NewLRUCache[int64, []*models.PostNeighbor](10, time.Minute),
+			},
+			mock: func(f fields) {
+				f.historyRepository.On("GetRecentPostIDs", mockUserID, constants.ALSO_READ_RECENT_READS).Return(mockRecentPostIDs, nil)
+				f.postNeighborRepository.On("GetByPostIDs", mockRecentPostIDs).Return(mockNeighbors, nil)
+				f.historyRepository.On("GetReadPostIDs", mockUserID, []int64{1, 2, 3}).Return([]int64{3}, nil)
+			},
+			want: []*models.Post{{ID: 2}, {ID: 1}},
+		},
+		{
+			name: "SUCCESS | Cache hit still excludes posts read since",
+			fields: fields{
+				historyRepository:      mocks.NewIHistoryRepository(t),
+				trendingRepository:     mocks.NewITrendingRepository(t),
+				postNeighborRepository: mocks.NewIPostNeighborRepository(t),
+				postNeighborsCache: func() cache.Cache[int64, []*models.PostNeighbor] {
+					c := cache.NewLRUCache[int64, []*models.PostNeighbor](10, time.Minute)
+					c.Set(10, mockNeighbors[:2])
+					c.Set(11, mockNeighbors[2:])
+					return c
+				}(),
+			},
+			mock: func(f fields) {
+				f.historyRepository.On("GetRecentPostIDs", mockUserID, constants.ALSO_READ_RECENT_READS).Return(mockRecentPostIDs, nil)
+				f.historyRepository.On("GetReadPostIDs", mockUserID, []int64{1, 2, 3}).Return([]int64{1, 2}, nil)
+			},
+			want: []*models.Post{{ID: 3}},
+		},
+		{
+			name: "SUCCESS | Falls back to trending without neighbours",
+			fields: fields{
+				historyRepository:      mocks.NewIHistoryRepository(t),
+				trendingRepository:     mocks.NewITrendingRepository(t),
+				postNeighborRepository: mocks.NewIPostNeighborRepository(t),
+				postNeighborsCache:     cache.NewLRUCache[int64, []*models.PostNeighbor](10, time.Minute),
+			},
+			mock: func(f fields) {
+				f.historyRepository.On("GetRecentPostIDs", mockUserID, constants.ALSO_READ_RECENT_READS).Return(mockRecentPostIDs, nil)
+				f.postNeighborRepository.On("GetByPostIDs", mockRecentPostIDs).Return([]*models.PostNeighbor{}, nil)
+				f.trendingRepository.On("GetPosts", int64(0), constants.ALSO_READ_LIMIT).Return(mockPosts, nil)
+			},
+			want: mockPosts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &postService{
+				historyRepository:      tt.fields.historyRepository,
+				trendingRepository:     tt.fields.trendingRepository,
+				postNeighborRepository: tt.fields.postNeighborRepository,
+				postNeighborsCache:     tt.fields.postNeighborsCache,
+			}
+
+			tt.mock(tt.fields)
+			got, err := s.GetAlsoReadPosts(mockUserID)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// IRenewalService drives automatic renewals from the scheduler. Renewal
+// invoices are completed like any other invoice, see invoiceService.UpdateStatus.
+type IRenewalService interface {
+	IssueRenewalInvoices() (int, error)
+	SendRenewalReminders() (int, int, error)
+}
+
+type renewalService struct {
+	invoiceRepository          repositories.IInvoiceRepository
+	subscriptionRepository     repositories.ISubscriptionRepository
+	userSubscriptionRepository repositories.IUserSubscriptionRepository
+	notificationRepository     repositories.INotificationRepository
+}
+
+type RenewalServiceConfig struct {
+	invoiceRepository          repositories.IInvoiceRepository
+	subscriptionRepository     repositories.ISubscriptionRepository
+	userSubscriptionRepository repositories.IUserSubscriptionRepository
+	notificationRepository     repositories.INotificationRepository
+}
+
+func NewRenewalService(c *RenewalServiceConfig) IRenewalService {
+	return &renewalService{
+		invoiceRepository:          c.invoiceRepository,
+		subscriptionRepository:     c.subscriptionRepository,
+		userSubscriptionRepository: c.userSubscriptionRepository,
+		notificationRepository:     c.notificationRepository,
+	}
+}
+
+// IssueRenewalInvoices bills auto-renewing subscriptions that end soon at the
+// current price of their plan, and opens their grace period. Subscriptions of
+// retired plans are switched back to manual renewal instead.
+func (s *renewalService) IssueRenewalInvoices() (int, error) {
+	now := time.Now()
+
+	userSubscriptions, err := s.userSubscriptionRepository.GetDueForRenewal(
+		now.Add(-constants.RENEWAL_GRACE_PERIOD),
+		now.Add(constants.RENEWAL_INVOICE_LEAD_TIME),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	issued := 0
+	for _, userSubscription := range userSubscriptions {
+		subscription, err := s.subscriptionRepository.GetCurrentByPlanID(userSubscription.Subscription.PlanID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return issued, err
+		}
+
+		if err != nil || !subscription.IsPurchasable() {
+			_, err = s.userSubscriptionRepository.SetAutoRenew(userSubscription.UserID, int64(userSubscription.ID), false)
+			if err != nil {
+				return issued, err
+			}
+
+			err = s.notify(userSubscription.UserID, models.NOTIFICATION_RENEWAL_FAILED, nil, fmt.Sprintf(
+				"Your %s subscription can not be renewed because the plan is no longer offered. It ends on %s.",
+				userSubscription.Subscription.Name, formatRenewalDate(userSubscription.DateEnded),
+			))
+			if err != nil {
+				return issued, err
+			}
+			continue
+		}
+
+		userSubscriptionID := int64(userSubscription.ID)
+		dueAt := userSubscription.DateEnded
+
+		invoice, err := s.invoiceRepository.Insert(&models.Invoice{
+			UserID:         userSubscription.UserID,
+			Status:         models.WAITING,
+			OriginalPrice:  subscription.Price,
+			Total:          subscription.Price,
+			SubscriptionID: subscription.ID,
+			RenewalOfID:    &userSubscriptionID,
+			DueAt:          &dueAt,
+		})
+		if err != nil {
+			return issued, err
+		}
+
+		graceEndsAt := dueAt.Add(constants.RENEWAL_GRACE_PERIOD)
+		err = s.userSubscriptionRepository.SetGraceEndsAt(userSubscriptionID, &graceEndsAt)
+		if err != nil {
+			return issued, err
+		}
+
+		invoiceID := int64(invoice.ID)
+		err = s.notify(userSubscription.UserID, models.NOTIFICATION_RENEWAL_INVOICE, &invoiceID, fmt.Sprintf(
+			"Your %s subscription renews on %s. Pay invoice %s of %d to keep reading.",
+			subscription.Name, formatRenewalDate(dueAt), invoice.Code, invoice.Total,
+		))
+		if err != nil {
+			return issued, err
+		}
+
+		issued++
+	}
+
+	return issued, nil
+}
+
+// SendRenewalReminders runs the dunning sequence for unpaid renewal invoices.
+// Each run sends at most one reminder per invoice, and invoices still unpaid
+// after the grace period expire and turn auto-renew off. It returns how many
+// invoices were reminded and expired.
+func (s *renewalService) SendRenewalReminders() (int, int, error) {
+	now := time.Now()
+
+	invoices, err := s.invoiceRepository.GetWaitingRenewals()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	reminded, expired := 0, 0
+	for _, invoice := range invoices {
+		if invoice.DueAt == nil || invoice.RenewalOfID == nil {
+			continue
+		}
+
+		invoiceID := int64(invoice.ID)
+		graceEndsAt := invoice.DueAt.Add(constants.RENEWAL_GRACE_PERIOD)
+
+		if now.After(graceEndsAt) {
+			_, rowsAffected, err := s.invoiceRepository.UpdateIfStatus(&models.Invoice{
+				Model:  gorm.Model{ID: invoice.ID},
+				Status: models.EXPIRED,
+			}, models.WAITING)
+			if err != nil {
+				return reminded, expired, err
+			}
+
+			// The invoice was paid since it was loaded.
+			if rowsAffected == 0 {
+				continue
+			}
+
+			_, err = s.userSubscriptionRepository.SetAutoRenew(invoice.UserID, *invoice.RenewalOfID, false)
+			if err != nil {
+				return reminded, expired, err
+			}
+
+			err = s.notify(invoice.UserID, models.NOTIFICATION_RENEWAL_FAILED, &invoiceID, fmt.Sprintf(
+				"Invoice %s was not paid in time, so your %s subscription has ended and auto-renew is off.",
+				invoice.Code, invoice.Subscription.Name,
+			))
+			if err != nil {
+				return reminded, expired, err
+			}
+
+			expired++
+			continue
+		}
+
+		if invoice.RemindersSent >= len(constants.RENEWAL_REMINDER_OFFSETS) ||
+			now.Before(invoice.DueAt.Add(constants.RENEWAL_REMINDER_OFFSETS[invoice.RemindersSent])) {
+			continue
+		}
+
+		_, rowsAffected, err := s.invoiceRepository.UpdateIfStatus(&models.Invoice{
+			Model:          gorm.Model{ID: invoice.ID},
+			RemindersSent:  invoice.RemindersSent + 1,
+			LastRemindedAt: &now,
+		}, models.WAITING)
+		if err != nil {
+			return reminded, expired, err
+		}
+
+		if rowsAffected == 0 {
+			continue
+		}
+
+		err = s.notify(invoice.UserID, models.NOTIFICATION_RENEWAL_REMINDER, &invoiceID, fmt.Sprintf(
+			"Invoice %s for your %s subscription is still unpaid. Pay it before %s to keep reading.",
+			invoice.Code, invoice.Subscription.Name, formatRenewalDate(graceEndsAt),
+		))
+		if err != nil {
+			return reminded, expired, err
+		}
+
+		reminded++
+	}
+
+	return reminded, expired, nil
+}
+
+func (s *renewalService) notify(userID int64, kind models.NotificationKind, invoiceID *int64, message string) error {
+	_, err := s.notificationRepository.Insert(&models.Notification{
+		UserID:    userID,
+		Kind:      kind,
+		Message:   message,
+		InvoiceID: invoiceID,
+	})
+
+	return err
+}
+
+func formatRenewalDate(t time.Time) string {
+	location, err := time.LoadLocation(constants.DEFAULT_TIMEZONE)
+	if err != nil {
+		location = time.UTC
+	}
+
+	return t.In(location).Format("2 January 2006")
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/models"
+	"final-project-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestNewRenewalService(t *testing.T) {
+	NewRenewalService(&RenewalServiceConfig{
+		invoiceRepository:          mocks.NewIInvoiceRepository(t),
+		subscriptionRepository:     mocks.NewISubscriptionRepository(t),
+		userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
+		notificationRepository:     mocks.NewINotificationRepository(t),
+	})
+}
+
+func Test_renewalService_IssueRenewalInvoices(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockDateEnded := time.Now().Add(48 * time.Hour)
+	mockUserSubscription := &models.UserSubscriptions{
+		Model:          gorm.Model{ID: 3},
+		UserID:         1,
+		SubscriptionID: 1,
+		Subscription:   models.Subscription{ID: 1, PlanID: 1, Name: "gold"},
+		DateEnded:      mockDateEnded,
+		AutoRenew:      true,
+	}
+	mockRetiredAt := time.Now()
+
+	type fields struct {
+		invoiceRepository          *mocks.IInvoiceRepository
+		subscriptionRepository     *mocks.ISubscriptionRepository
+		userSubscriptionRepository *mocks.IUserSubscriptionRepository
+		notificationRepository     *mocks.INotificationRepository
+	}
+	newFields := func() fields {
+		return fields{
+			invoiceRepository:          mocks.NewIInvoiceRepository(t),
+			subscriptionRepository:     mocks.NewISubscriptionRepository(t),
+			userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
+			notificationRepository:     mocks.NewINotificationRepository(t),
+		}
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(fields)
+		want        int
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:   "ERROR | Error from userSubscriptionRepository.GetDueForRenewal",
+			fields: newFields(),
+			mock: func(f fields) {
+				f.userSubscriptionRepository.On("GetDueForRenewal", mock.Anything, mock.Anything).Return(nil, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name:   "SUCCESS | Retired plan turns auto-renew off",
+			fields: newFields(),
+			mock: func(f fields) {
+				f.userSubscriptionRepository.On("GetDueForRenewal", mock.Anything, mock.Anything).Return([]*models.UserSubscriptions{mockUserSubscription}, nil)
+				f.subscriptionRepository.On("GetCurrentByPlanID", int64(1)).Return(&models.Subscription{ID: 1, RetiredAt: &mockRetiredAt}, nil)
+				f.userSubscriptionRepository.On("SetAutoRenew", int64(1), int64(3), false).Return(int64(1), nil)
+				f.notificationRepository.On("Insert", mock.MatchedBy(func(n *models.Notification) bool {
+					return n.UserID == 1 && n.Kind == models.NOTIFICATION_RENEWAL_FAILED
+				})).Return(&models.Notification{}, nil)
+			},
+			want: 0,
+		},
+		{
+			name:   "SUCCESS | Bills the current version and opens the grace period",
+			fields: newFields(),
+			mock: func(f fields) {
+				f.userSubscriptionRepository.On("GetDueForRenewal", mock.Anything, mock.Anything).Return([]*models.UserSubscriptions{mockUserSubscription}, nil)
+				f.subscriptionRepository.On("GetCurrentByPlanID", int64(1)).Return(&models.Subscription{ID: 2, PlanID: 1, Version: 2, Name: "gold", Price: 95000}, nil)
+				f.invoiceRepository.On("Insert", mock.MatchedBy(func(i *models.Invoice) bool {
+					return i.UserID == 1 && i.SubscriptionID == 2 && i.Total == 95000 && i.Status == models.WAITING &&
+						*i.RenewalOfID == 3 && i.DueAt.Equal(mockDateEnded)
+				})).Return(&models.Invoice{Model: gorm.Model{ID: 7}, Code: "INV-2-100007", Total: 95000}, nil)
+				graceEndsAt := mockDateEnded.Add(constants.RENEWAL_GRACE_PERIOD)
+				f.userSubscriptionRepository.On("SetGraceEndsAt", int64(3), &graceEndsAt).Return(nil)
+				f.notificationRepository.On("Insert", mock.MatchedBy(func(n *models.Notification) bool {
+					return n.Kind == models.NOTIFICATION_RENEWAL_INVOICE && *n.InvoiceID == 7
+				})).Return(&models.Notification{}, nil)
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &renewalService{
+				invoiceRepository:          tt.fields.invoiceRepository,
+				subscriptionRepository:     tt.fields.subscriptionRepository,
+				userSubscriptionRepository: tt.fields.userSubscriptionRepository,
+				notificationRepository:     tt.fields.notificationRepository,
+			}
+
+			tt.mock(tt.fields)
+			got, err := s.IssueRenewalInvoices()
+
+			if tt.wantErr {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_renewalService_SendRenewalReminders(t *testing.T) {
+	mockUserSubscriptionID := int64(3)
+	newInvoice := func(id uint, dueAt time.Time, remindersSent int) *models.Invoice {
+		return &models.Invoice{
+			Model:         gorm.Model{ID: id},
+			UserID:        1,
+			Status:        models.WAITING,
+			RenewalOfID:   &mockUserSubscriptionID,
+			DueAt:         &dueAt,
+			RemindersSent: remindersSent,
+			Subscription:  models.Subscription{Name: "gold"},
+		}
+	}
+
+	type fields struct {
+		invoiceRepository          *mocks.IInvoiceRepository
+		userSubscriptionRepository *mocks.IUserSubscriptionRepository
+		notificationRepository     *mocks.INotificationRepository
+	}
+	tests := []struct {
+		name         string
+		mock         func(fields)
+		wantReminded int
+		wantExpired  int
+	}{
+		{
+			name: "SUCCESS | Reminder not due yet",
+			mock: func(f fields) {
+				f.invoiceRepository.On("GetWaitingRenewals").Return([]*models.Invoice{
+					newInvoice(1, time.Now().Add(24*time.Hour), 0),
+				}, nil)
+			},
+		},
+		{
+			name: "SUCCESS | Sends the next reminder of the sequence",
+			mock: func(f fields) {
+				f.invoiceRepository.On("GetWaitingRenewals").Return([]*models.Invoice{
+					newInvoice(1, time.Now().Add(-4*24*time.Hour), 1),
+				}, nil)
+				f.invoiceRepository.On("UpdateIfStatus", mock.MatchedBy(func(i *models.Invoice) bool {
+					return i.ID == 1 && i.RemindersSent == 2 && i.LastRemindedAt != nil
+				}), models.WAITING).Return(&models.Invoice{}, 1, nil)
+				f.notificationRepository.On("Insert", mock.MatchedBy(func(n *models.Notification) bool {
+					return n.Kind == models.NOTIFICATION_RENEWAL_REMINDER
+				})).Return(&models.Notification{}, nil)
+			},
+			wantReminded: 1,
+		},
+		{
+			name: "SUCCESS | Expires invoice unpaid after the grace period",
+			mock: func(f fields) {
+				f.invoiceRepository.On("GetWaitingRenewals").Return([]*models.Invoice{
+					newInvoice(1, time.Now().Add(-constants.RENEWAL_GRACE_PERIOD-time.Hour), 3),
+				}, nil)
+				f.invoiceRepository.On("UpdateIfStatus", mock.MatchedBy(func(i *models.Invoice) bool {
+					return i.ID == 1 && i.Status == models.EXPIRED
+				}), models.WAITING).Return(&models.Invoice{}, 1, nil)
+				f.userSubscriptionRepository.On("SetAutoRenew", int64(1), mockUserSubscriptionID, false).Return(int64(1), nil)
+				f.notificationRepository.On("Insert", mock.MatchedBy(func(n *models.Notification) bool {
+					return n.Kind == models.NOTIFICATION_RENEWAL_FAILED
+				})).Return(&models.Notification{}, nil)
+			},
+			wantExpired: 1,
+		},
+		{
+			name: "SUCCESS | Keeps auto-renew when the invoice was paid in the meantime",
+			mock: func(f fields) {
+				f.invoiceRepository.On("GetWaitingRenewals").Return([]*models.Invoice{
+					newInvoice(1, time.Now().Add(-constants.RENEWAL_GRACE_PERIOD-time.Hour), 3),
+				}, nil)
+				f.invoiceRepository.On("UpdateIfStatus", mock.Anything, models.WAITING).Return(&models.Invoice{}, 0, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := fields{
+				invoiceRepository:          mocks.NewIInvoiceRepository(t),
+				userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
+				notificationRepository:     mocks.NewINotificationRepository(t),
+			}
+			s := &renewalService{
+				invoiceRepository:          f.invoiceRepository,
+				userSubscriptionRepository: f.userSubscriptionRepository,
+				notificationRepository:     f.notificationRepository,
+			}
+
+			tt.mock(f)
+			reminded, expired, err := s.SendRenewalReminders()
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantReminded, reminded)
+			assert.Equal(t, tt.wantExpired, expired)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package services
 
 import (
+	"final-project-backend/internal/cache"
+	"final-project-backend/internal/constants"
 	"final-project-backend/internal/helpers"
+	"final-project-backend/internal/models"
 	"final-project-backend/internal/repositories"
 )
 
@@ -15,6 +18,12 @@ type Services struct {
 	Invoice          IInvoiceService
 	Gift             IGiftService
 	Voucher          IVoucherService
+	Author           IAuthorService
+	ShareLink        IShareLinkService
+	Achievement      IAchievementService
+	Renewal          IRenewalService
+	Notification     INotificationService
+	Trial            ITrialService
 }
 
 func New(r *repositories.Repositories) *Services {
@@ -23,9 +32,20 @@ func New(r *repositories.Repositories) *Services {
 			userRepository:      r.Users,
 			userTokenRepository: r.UserTokens,
 			hasher:              helpers.NewHasher(),
+			mailer:              helpers.NewMailer(),
 		}),
 		User: NewUserService(&UserServiceConfig{userRepository: r.Users}),
-		Post: NewPostService(&PostServiceConfig{postRepository: r.Posts, historyRepository: r.Histories}),
+		Post: NewPostService(&PostServiceConfig{
+			postRepository:         r.Posts,
+			historyRepository:      r.Histories,
+			trendingRepository:     r.Trending,
+			postNeighborRepository: r.PostNeighbors,
+			relatedPostsCache:      cache.NewLRUCache[int64, []*models.Post](constants.RELATED_POSTS_CACHE_SIZE, constants.RELATED_POSTS_CACHE_TTL),
+			postNeighborsCache:     cache.NewLRUCache[int64, []*models.PostNeighbor](constants.POST_NEIGHBORS_CACHE_SIZE, constants.POST_NEIGHBORS_CACHE_TTL),
+			postListCache:          cache.NewLRUCache[string, []*models.Post](constants.POST_LIST_CACHE_SIZE, constants.POST_LIST_CACHE_TTL),
+			typesCache:             cache.NewLRUCache[string, []*models.PostType](1, constants.TAXONOMY_CACHE_TTL),
+			categoriesCache:        cache.NewLRUCache[string, []*models.Category](1, constants.TAXONOMY_CACHE_TTL),
+		}),
 		History: NewHistoryService(&HistoryServiceConfig{
 			historyRepository: r.Histories,
 		}),
@@ -33,6 +53,7 @@ func New(r *repositories.Repositories) *Services {
 		UserSubscription: NewUserSubscriptionService(&UserSubscriptionServiceConfig{
 			subscriptionRepository:     r.Subscriptions,
 			userSubscriptionRepository: r.UserSubscriptions,
+			quotaLedgerRepository:      r.QuotaLedgers,
 		}),
 		Invoice: NewInvoiceService(&InvoiceServiceConfig{
 			invoiceRepository:          r.Invoices,
@@ -44,6 +65,8 @@ func New(r *repositories.Repositories) *Services {
 			voucherRepository:          r.Vouchers,
 			userVoucherRepository:      r.UserVouchers,
 			userSpendingRepository:     r.UserSpendings,
+			notificationRepository:     r.Notifications,
+			trialRepository:            r.Trials,
 		}),
 		Gift: NewGiftService(&GiftServiceConfig{
 			giftRepository: r.Gifts,
@@ -52,5 +75,32 @@ func New(r *repositories.Repositories) *Services {
 			voucherRepository:     r.Vouchers,
 			userVoucherRepository: r.UserVouchers,
 		}),
+		Author: NewAuthorService(&AuthorServiceConfig{
+			authorRepository: r.Authors,
+		}),
+		ShareLink: NewShareLinkService(&ShareLinkServiceConfig{
+			shareLinkRepository: r.ShareLinks,
+			postRepository:      r.Posts,
+			historyRepository:   r.Histories,
+		}),
+		Achievement: NewAchievementService(&AchievementServiceConfig{
+			achievementRepository: r.Achievements,
+			historyRepository:     r.Histories,
+			userRepository:        r.Users,
+		}),
+		Renewal: NewRenewalService(&RenewalServiceConfig{
+			invoiceRepository:          r.Invoices,
+			subscriptionRepository:     r.Subscriptions,
+			userSubscriptionRepository: r.UserSubscriptions,
+			notificationRepository:     r.Notifications,
+		}),
+		Notification: NewNotificationService(&NotificationServiceConfig{
+			notificationRepository: r.Notifications,
+		}),
+		Trial: NewTrialService(&TrialServiceConfig{
+			trialRepository:        r.Trials,
+			subscriptionRepository: r.Subscriptions,
+			notificationRepository: r.Notifications,
+		}),
 	}
 }
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"final-project-backend/internal/constants"
+	errn "final-project-backend/internal/errors"
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+type IShareLinkService interface {
+	Create(userID int64, postID int64) (*models.ShareLink, int64, error)
+	Redeem(shareLinkID int64, postID int64, visitorKey string, visitorUserID int64) (*models.Post, error)
+}
+
+type shareLinkService struct {
+	shareLinkRepository repositories.IShareLinkRepository
+	postRepository      repositories.IPostRepository
+	historyRepository   repositories.IHistoryRepository
+}
+
+type ShareLinkServiceConfig struct {
+	shareLinkRepository repositories.IShareLinkRepository
+	postRepository      repositories.IPostRepository
+	historyRepository   repositories.IHistoryRepository
+}
+
+func NewShareLinkService(c *ShareLinkServiceConfig) IShareLinkService {
+	return &shareLinkService{
+		shareLinkRepository: c.shareLinkRepository,
+		postRepository:      c.postRepository,
+		historyRepository:   c.historyRepository,
+	}
+}
+
+// Create only lets members share premium posts they have unlocked themselves,
+// and returns how many links the member has left this month.
+func (s *shareLinkService) Create(userID int64, postID int64) (*models.ShareLink, int64, error) {
+	post, err := s.postRepository.GetByID(postID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if post.Type.Quota == 0 {
+		return nil, 0, errn.ErrPostIsFree
+	}
+
+	_, err = s.historyRepository.GetByUserAndPostID(userID, postID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, errn.ErrPostNotUnlocked
+		}
+
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	shareLink := &models.ShareLink{
+		UserID:    userID,
+		PostID:    postID,
+		ExpiresAt: now.Add(constants.SHARE_LINK_TTL),
+	}
+
+	totalShared, isCreated, err := s.shareLinkRepository.InsertWithinLimit(shareLink, monthStart, constants.SHARE_LINK_MONTHLY_LIMIT)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !isCreated {
+		return nil, 0, errn.ErrShareLinkLimitReached
+	}
+
+	shareLink.Post = *post
+
+	return shareLink, constants.SHARE_LINK_MONTHLY_LIMIT - totalShared - 1, nil
+}
+
+// Redeem does not spend quota or create a reading history, so the visitor
+// only gets this one read. The sharer opening their own link is not counted.
+func (s *shareLinkService) Redeem(shareLinkID int64, postID int64, visitorKey string, visitorUserID int64) (*models.Post, error) {
+	shareLink, err := s.shareLinkRepository.GetByID(shareLinkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if shareLink.PostID != postID {
+		return nil, errn.ErrInvalidShareLink
+	}
+
+	if !time.Now().Before(shareLink.ExpiresAt) {
+		return nil, errn.ErrShareLinkExpired
+	}
+
+	post, err := s.postRepository.GetByID(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if visitorUserID == shareLink.UserID {
+		return post, nil
+	}
+
+	_, err = s.shareLinkRepository.InsertRedemption(shareLink, &models.ShareLinkRedemption{
+		ShareLinkID: shareLink.ID,
+		VisitorKey:  visitorKey,
+		UserID:      visitorUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
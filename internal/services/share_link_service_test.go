@@ -0,0 +1,308 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"final-project-backend/internal/constants"
+	errn "final-project-backend/internal/errors"
+	"final-project-backend/internal/models"
+	mocks "final-project-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestNewShareLinkService(t *testing.T) {
+	NewShareLinkService(&ShareLinkServiceConfig{
+		shareLinkRepository: mocks.NewIShareLinkRepository(t),
+		postRepository:      mocks.NewIPostRepository(t),
+		historyRepository:   mocks.NewIHistoryRepository(t),
+	})
+}
+
+func Test_shareLinkService_Create(t *testing.T) {
+	mockUserID := int64(1)
+	mockPremiumPost := &models.Post{ID: 2, Type: models.PostType{Quota: 2}}
+	mockFreePost := &models.Post{ID: 3}
+	mockShareLink := &models.ShareLink{ID: 4, UserID: mockUserID, PostID: mockPremiumPost.ID}
+	mockError := fmt.Errorf("error")
+	type fields struct {
+		shareLinkRepository *mocks.IShareLinkRepository
+		postRepository      *mocks.IPostRepository
+		historyRepository   *mocks.IHistoryRepository
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		postID        int64
+		mock          func(*mocks.IShareLinkRepository, *mocks.IPostRepository, *mocks.IHistoryRepository)
+		want          *models.ShareLink
+		wantRemaining int64
+		wantErr       bool
+		expectedErr   error
+	}{
+		{
+			name: "ERROR | Error from postRepository.GetByID",
+			fields: fields{
+				shareLinkRepository: mocks.NewIShareLinkRepository(t),
+				postRepository:      mocks.NewIPostRepository(t),
+				historyRepository:   mocks.NewIHistoryRepository(t),
+			},
+			postID: mockPremiumPost.ID,
+			mock: func(sr *mocks.IShareLinkRepository, pr *mocks.IPostRepository, hr *mocks.IHistoryRepository) {
+				pr.On("GetByID", mockPremiumPost.ID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "ERROR | Post is free",
+			fields: fields{
+				shareLinkRepository: mocks.NewIShareLinkRepository(t),
+				postRepository:      mocks.NewIPostRepository(t),
+				historyRepository:   mocks.NewIHistoryRepository(t),
+			},
+			postID: mockFreePost.ID,
+			mock: func(sr *mocks.IShareLinkRepository, pr *mocks.IPostRepository, hr *mocks.IHistoryRepository) {
+				pr.On("GetByID", mockFreePost.ID).Return(mockFreePost, nil)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrPostIsFree,
+		},
+		{
+			name: "ERROR | Post is not unlocked by the member",
+			fields: fields{
+				shareLinkRepository: mocks.NewIShareLinkRepository(t),
+				postRepository:      mocks.NewIPostRepository(t),
+				historyRepository:   mocks.NewIHistoryRepository(t),
+			},
+			postID: mockPremiumPost.ID,
+			mock: func(sr *mocks.IShareLinkRepository, pr *mocks.IPostRepository, hr *mocks.IHistoryRepository) {
+				pr.On("GetByID", mockPremiumPost.ID).Return(mockPremiumPost, nil)
+				hr.On("GetByUserAndPostID", mockUserID, mockPremiumPost.ID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrPostNotUnlocked,
+		},
+		{
+			name: "ERROR | Monthly limit reached",
+			fields: fields{
+				shareLinkRepository: mocks.NewIShareLinkRepository(t),
+				postRepository:      mocks.NewIPostRepository(t),
+				historyRepository:   mocks.NewIHistoryRepository(t),
+			},
+			postID: mockPremiumPost.ID,
+			mock: func(sr *mocks.IShareLinkRepository, pr *mocks.IPostRepository, hr *mocks.IHistoryRepository) {
+				pr.On("GetByID", mockPremiumPost.ID).Return(mockPremiumPost, nil)
+				hr.On("GetByUserAndPostID", mockUserID, mockPremiumPost.ID).Return(&models.History{}, nil)
+				sr.On("InsertWithinLimit", mock.AnythingOfType("*models.ShareLink"), mock.AnythingOfType("time.Time"), constants.SHARE_LINK_MONTHLY_LIMIT).Return(int64(constants.SHARE_LINK_MONTHLY_LIMIT), false, nil)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrShareLinkLimitReached,
+		},
+		{
+			name: "ERROR | Error from shareLinkRepository.InsertWithinLimit",
+			fields: fields{
+				shareLinkRepository: mocks.NewIShareLinkRepository(t),
+				postRepository:      mocks.NewIPostRepository(t),
+				historyRepository:   mocks.NewIHistoryRepository(t),
+			},
+			postID: mockPremiumPost.ID,
+			mock: func(sr *mocks.IShareLinkRepository, pr *mocks.IPostRepository, hr *mocks.IHistoryRepository) {
+				pr.On("GetByID", mockPremiumPost.ID).Return(mockPremiumPost, nil)
+				hr.On("GetByUserAndPostID", mockUserID, mockPremiumPost.ID).Return(&models.History{}, nil)
+				sr.On("InsertWithinLimit", mock.AnythingOfType("*models.ShareLink"), mock.AnythingOfType("time.Time"), constants.SHARE_LINK_MONTHLY_LIMIT).Return(int64(0), false, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				shareLinkRepository: mocks.NewIShareLinkRepository(t),
+				postRepository:      mocks.NewIPostRepository(t),
+				historyRepository:   mocks.NewIHistoryRepository(t),
+			},
+			postID: mockPremiumPost.ID,
+			mock: func(sr *mocks.IShareLinkRepository, pr *mocks.IPostRepository, hr *mocks.IHistoryRepository) {
+				pr.On("GetByID", mockPremiumPost.ID).Return(mockPremiumPost, nil)
+				hr.On("GetByUserAndPostID", mockUserID, mockPremiumPost.ID).Return(&models.History{}, nil)
+				sr.On("InsertWithinLimit", mock.MatchedBy(func(shareLink *models.ShareLink) bool {
+					return shareLink.UserID == mockUserID &&
+						shareLink.PostID == mockPremiumPost.ID &&
+						shareLink.ExpiresAt.After(time.Now())
+				}), mock.AnythingOfType("time.Time"), constants.SHARE_LINK_MONTHLY_LIMIT).Run(func(args mock.Arguments) {
+					*args.Get(0).(*models.ShareLink) = *mockShareLink
+				}).Return(int64(1), true, nil)
+			},
+			want:          &models.ShareLink{ID: 4, UserID: mockUserID, PostID: mockPremiumPost.ID, Post: *mockPremiumPost},
+			wantRemaining: constants.SHARE_LINK_MONTHLY_LIMIT - 2,
+			wantErr:       false,
+			expectedErr:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &shareLinkService{
+				shareLinkRepository: tt.fields.shareLinkRepository,
+				postRepository:      tt.fields.postRepository,
+				historyRepository:   tt.fields.historyRepository,
+			}
+
+			tt.mock(tt.fields.shareLinkRepository, tt.fields.postRepository, tt.fields.historyRepository)
+			got, remaining, err := s.Create(mockUserID, tt.postID)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRemaining, remaining)
+		})
+	}
+}
+
+func Test_shareLinkService_Redeem(t *testing.T) {
+	mockSharerID := int64(1)
+	mockVisitorKey := "device:abc"
+	mockPost := &models.Post{ID: 2}
+	mockShareLink := &models.ShareLink{ID: 3, UserID: mockSharerID, PostID: mockPost.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	mockExpiredShareLink := &models.ShareLink{ID: 4, UserID: mockSharerID, PostID: mockPost.ID, ExpiresAt: time.Now().Add(-time.Hour)}
+	mockError := fmt.Errorf("error")
+	type fields struct {
+		shareLinkRepository *mocks.IShareLinkRepository
+		postRepository      *mocks.IPostRepository
+	}
+	type args struct {
+		shareLinkID   int64
+		postID        int64
+		visitorUserID int64
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		mock        func(*mocks.IShareLinkRepository, *mocks.IPostRepository)
+		want        *models.Post
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from shareLinkRepository.GetByID",
+			fields: fields{
+				shareLinkRepository: mocks.NewIShareLinkRepository(t),
+				postRepository:      mocks.NewIPostRepository(t),
+			},
+			args: args{shareLinkID: mockShareLink.ID, postID: mockPost.ID},
+			mock: func(sr *mocks.IShareLinkRepository, pr *mocks.IPostRepository) {
+				sr.On("GetByID", mockShareLink.ID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "ERROR | Share link belongs to another post",
+			fields: fields{
+				shareLinkRepository: mocks.NewIShareLinkRepository(t),
+				postRepository:      mocks.NewIPostRepository(t),
+			},
+			args: args{shareLinkID: mockShareLink.ID, postID: 99},
+			mock: func(sr *mocks.IShareLinkRepository, pr *mocks.IPostRepository) {
+				sr.On("GetByID", mockShareLink.ID).Return(mockShareLink, nil)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrInvalidShareLink,
+		},
+		{
+			name: "ERROR | Share link expired",
+			fields: fields{
+				shareLinkRepository: mocks.NewIShareLinkRepository(t),
+				postRepository:      mocks.NewIPostRepository(t),
+			},
+			args: args{shareLinkID: mockExpiredShareLink.ID, postID: mockPost.ID},
+			mock: func(sr *mocks.IShareLinkRepository, pr *mocks.IPostRepository) {
+				sr.On("GetByID", mockExpiredShareLink.ID).Return(mockExpiredShareLink, nil)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrShareLinkExpired,
+		},
+		{
+			name: "ERROR | Error from shareLinkRepository.InsertRedemption",
+			fields: fields{
+				shareLinkRepository: mocks.NewIShareLinkRepository(t),
+				postRepository:      mocks.NewIPostRepository(t),
+			},
+			args: args{shareLinkID: mockShareLink.ID, postID: mockPost.ID},
+			mock: func(sr *mocks.IShareLinkRepository, pr *mocks.IPostRepository) {
+				sr.On("GetByID", mockShareLink.ID).Return(mockShareLink, nil)
+				pr.On("GetByID", mockPost.ID).Return(mockPost, nil)
+				sr.On("InsertRedemption", mockShareLink, &models.ShareLinkRedemption{
+					ShareLinkID: mockShareLink.ID,
+					VisitorKey:  mockVisitorKey,
+				}).Return(false, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS | Sharer opening their own link is not recorded",
+			fields: fields{
+				shareLinkRepository: mocks.NewIShareLinkRepository(t),
+				postRepository:      mocks.NewIPostRepository(t),
+			},
+			args: args{shareLinkID: mockShareLink.ID, postID: mockPost.ID, visitorUserID: mockSharerID},
+			mock: func(sr *mocks.IShareLinkRepository, pr *mocks.IPostRepository) {
+				sr.On("GetByID", mockShareLink.ID).Return(mockShareLink, nil)
+				pr.On("GetByID", mockPost.ID).Return(mockPost, nil)
+			},
+			want:        mockPost,
+			wantErr:     false,
+			expectedErr: nil,
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				shareLinkRepository: mocks.NewIShareLinkRepository(t),
+				postRepository:      mocks.NewIPostRepository(t),
+			},
+			args: args{shareLinkID: mockShareLink.ID, postID: mockPost.ID},
+			mock: func(sr *mocks.IShareLinkRepository, pr *mocks.IPostRepository) {
+				sr.On("GetByID", mockShareLink.ID).Return(mockShareLink, nil)
+				pr.On("GetByID", mockPost.ID).Return(mockPost, nil)
+				sr.On("InsertRedemption", mockShareLink, &models.ShareLinkRedemption{
+					ShareLinkID: mockShareLink.ID,
+					VisitorKey:  mockVisitorKey,
+				}).Return(true, nil)
+			},
+			want:        mockPost,
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &shareLinkService{
+				shareLinkRepository: tt.fields.shareLinkRepository,
+				postRepository:      tt.fields.postRepository,
+			}
+
+			tt.mock(tt.fields.shareLinkRepository, tt.fields.postRepository)
+			got, err := s.Redeem(tt.args.shareLinkID, tt.args.postID, mockVisitorKey, tt.args.visitorUserID)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
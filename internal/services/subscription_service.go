@@ -1,12 +1,20 @@
 package services
 
 import (
+	"final-project-backend/internal/dtos"
+	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/models"
 	"final-project-backend/internal/repositories"
+
+	"gorm.io/gorm"
 )
 
 type ISubscriptionService interface {
 	GetAll() ([]*models.Subscription, error)
+	GetAllVersions() ([]*models.Subscription, error)
+	Create(subscription *models.Subscription) (*models.Subscription, error)
+	Update(planID int64, request *dtos.UpdateSubscriptionRequest) (*models.Subscription, error)
+	Retire(planID int64) error
 }
 
 type subscriptionService struct {
@@ -30,3 +38,108 @@ func (s *subscriptionService) GetAll() ([]*models.Subscription, error) {
 
 	return subscriptions, nil
 }
+
+func (s *subscriptionService) GetAllVersions() ([]*models.Subscription, error) {
+	subscriptions, err := s.subscriptionRepository.GetAllVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (s *subscriptionService) Create(subscription *models.Subscription) (*models.Subscription, error) {
+	if !isValidPlanPrice(subscription) {
+		return nil, errn.ErrInvalidSubscriptionPlan
+	}
+
+	createdSubscription, err := s.subscriptionRepository.Insert(subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return createdSubscription, nil
+}
+
+// Update changes the current version of a plan. Price or quota changes
+// publish a new version and leave the old one untouched for the invoices and
+// user subscriptions that reference it; a name-only change is applied in
+// place.
+func (s *subscriptionService) Update(planID int64, request *dtos.UpdateSubscriptionRequest) (*models.Subscription, error) {
+	current, err := s.subscriptionRepository.GetCurrentByPlanID(planID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !current.IsPurchasable() {
+		return nil, errn.ErrSubscriptionNotAvailable
+	}
+
+	next := &models.Subscription{
+		PlanID: current.PlanID,
+		Name:   current.Name,
+		Price:  current.Price,
+		Quota:  current.Quota,
+		Type:   current.Type,
+	}
+
+	if request.Name != nil && *request.Name != "" {
+		next.Name = *request.Name
+	}
+
+	if request.Price != nil {
+		next.Price = *request.Price
+	}
+
+	if request.Quota != nil {
+		next.Quota = *request.Quota
+	}
+
+	if !isValidPlanPrice(next) {
+		return nil, errn.ErrInvalidSubscriptionPlan
+	}
+
+	if next.Price != current.Price || next.Quota != current.Quota {
+		return s.subscriptionRepository.InsertVersion(next)
+	}
+
+	if next.Name == current.Name {
+		return current, nil
+	}
+
+	renamed, rowsAffected, err := s.subscriptionRepository.Rename(current.ID, next.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return renamed, nil
+}
+
+// Retire takes a plan off sale. Members who already bought it keep their
+// subscription.
+func (s *subscriptionService) Retire(planID int64) error {
+	rowsAffected, err := s.subscriptionRepository.Retire(planID)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// isValidPlanPrice makes sure paid plans cost something and trial plans are
+// free.
+func isValidPlanPrice(subscription *models.Subscription) bool {
+	if subscription.IsTrial() {
+		return subscription.Price == 0
+	}
+
+	return subscription.Price > 0
+}
@@ -3,7 +3,10 @@ package services
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"final-project-backend/internal/dtos"
+	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/models"
 	mocks "final-project-backend/mocks"
 
@@ -74,3 +77,94 @@ func Test_subscriptionService_GetAll(t *testing.T) {
 		})
 	}
 }
+
+func Test_subscriptionService_Update(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockPlanID := int64(1)
+	mockCurrent := &models.Subscription{ID: 4, PlanID: mockPlanID, Version: 2, Name: "Standard", Price: 30000, Quota: 5}
+	mockRetiredAt := time.Now()
+	newName := "Standard Plus"
+	newPrice := 35000
+	samePrice := 30000
+
+	type fields struct {
+		subscriptionRepository *mocks.ISubscriptionRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		request     *dtos.UpdateSubscriptionRequest
+		mock        func(*mocks.ISubscriptionRepository)
+		want        *models.Subscription
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from SubscriptionRepository.GetCurrentByPlanID",
+			fields: fields{
+				subscriptionRepository: mocks.NewISubscriptionRepository(t),
+			},
+			request: &dtos.UpdateSubscriptionRequest{Price: &newPrice},
+			mock: func(r *mocks.ISubscriptionRepository) {
+				r.On("GetCurrentByPlanID", mockPlanID).Return(nil, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "ERROR | Error from retired plan",
+			fields: fields{
+				subscriptionRepository: mocks.NewISubscriptionRepository(t),
+			},
+			request: &dtos.UpdateSubscriptionRequest{Price: &newPrice},
+			mock: func(r *mocks.ISubscriptionRepository) {
+				r.On("GetCurrentByPlanID", mockPlanID).Return(&models.Subscription{PlanID: mockPlanID, RetiredAt: &mockRetiredAt}, nil)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrSubscriptionNotAvailable,
+		},
+		{
+			name: "SUCCESS | Price change publishes a new version",
+			fields: fields{
+				subscriptionRepository: mocks.NewISubscriptionRepository(t),
+			},
+			request: &dtos.UpdateSubscriptionRequest{Price: &newPrice},
+			mock: func(r *mocks.ISubscriptionRepository) {
+				r.On("GetCurrentByPlanID", mockPlanID).Return(mockCurrent, nil)
+				r.On("InsertVersion", &models.Subscription{PlanID: mockPlanID, Name: "Standard", Price: newPrice, Quota: 5}).
+					Return(&models.Subscription{ID: 5, PlanID: mockPlanID, Version: 3, Name: "Standard", Price: newPrice, Quota: 5}, nil)
+			},
+			want: &models.Subscription{ID: 5, PlanID: mockPlanID, Version: 3, Name: "Standard", Price: newPrice, Quota: 5},
+		},
+		{
+			name: "SUCCESS | Name change is applied in place",
+			fields: fields{
+				subscriptionRepository: mocks.NewISubscriptionRepository(t),
+			},
+			request: &dtos.UpdateSubscriptionRequest{Name: &newName, Price: &samePrice},
+			mock: func(r *mocks.ISubscriptionRepository) {
+				r.On("GetCurrentByPlanID", mockPlanID).Return(mockCurrent, nil)
+				r.On("Rename", int64(4), newName).Return(&models.Subscription{ID: 4, PlanID: mockPlanID, Version: 2, Name: newName}, 1, nil)
+			},
+			want: &models.Subscription{ID: 4, PlanID: mockPlanID, Version: 2, Name: newName},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &subscriptionService{
+				subscriptionRepository: tt.fields.subscriptionRepository,
+			}
+
+			tt.mock(tt.fields.subscriptionRepository)
+			got, err := s.Update(mockPlanID, tt.request)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
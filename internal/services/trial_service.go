@@ -0,0 +1,169 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"final-project-backend/internal/constants"
+	errn "final-project-backend/internal/errors"
+	"final-project-backend/internal/models"
+	"final-project-backend/internal/repositories"
+)
+
+type ITrialService interface {
+	Grant(user *models.User) (*models.UserSubscriptions, error)
+	SendExpiryNotifications() (int, int, error)
+	GetStats() (*models.TrialStats, error)
+}
+
+type trialService struct {
+	trialRepository        repositories.ITrialRepository
+	subscriptionRepository repositories.ISubscriptionRepository
+	notificationRepository repositories.INotificationRepository
+}
+
+type TrialServiceConfig struct {
+	trialRepository        repositories.ITrialRepository
+	subscriptionRepository repositories.ISubscriptionRepository
+	notificationRepository repositories.INotificationRepository
+}
+
+func NewTrialService(c *TrialServiceConfig) ITrialService {
+	return &trialService{
+		trialRepository:        c.trialRepository,
+		subscriptionRepository: c.subscriptionRepository,
+		notificationRepository: c.notificationRepository,
+	}
+}
+
+// Grant starts the current trial plan for a member who has just verified
+// their email. Each member and each email identity gets one trial at most.
+// Limiting trials per payment identity as well has to wait until payments
+// carry one: invoices are settled by code with no card or account attached,
+// and a trial needs no payment at all.
+func (s *trialService) Grant(user *models.User) (*models.UserSubscriptions, error) {
+	subscription, err := s.subscriptionRepository.GetCurrentTrial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	userSubscription := &models.UserSubscriptions{
+		UserID:         user.ID,
+		SubscriptionID: subscription.ID,
+		Subscription:   *subscription,
+		RemainingQuota: subscription.Quota,
+		DateStarted:    now,
+		DateEnded:      now.AddDate(0, 0, constants.TRIAL_DURATION_DAYS),
+		IsTrial:        true,
+	}
+
+	isGranted, err := s.trialRepository.Grant(&models.TrialGrant{
+		UserID:    user.ID,
+		Identity:  trialIdentity(user.Email),
+		ExpiresAt: userSubscription.DateEnded,
+	}, userSubscription)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isGranted {
+		return nil, errn.ErrTrialAlreadyUsed
+	}
+
+	err = s.notify(user.ID, models.NOTIFICATION_TRIAL_STARTED, fmt.Sprintf("Your free trial with %d quota runs until %s.", subscription.Quota, formatRenewalDate(userSubscription.DateEnded)))
+	if err != nil {
+		return nil, err
+	}
+
+	return userSubscription, nil
+}
+
+// SendExpiryNotifications reminds members whose trial ends soon and tells
+// those whose trial has ended without buying a plan. It returns how many were
+// reminded and how many were told their trial expired.
+func (s *trialService) SendExpiryNotifications() (int, int, error) {
+	reminded := 0
+	expired := 0
+
+	endingSoon, err := s.trialRepository.GetEndingSoon(time.Now().Add(constants.TRIAL_EXPIRY_REMINDER))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, trialGrant := range endingSoon {
+		err = s.notify(trialGrant.UserID, models.NOTIFICATION_TRIAL_ENDING, fmt.Sprintf("Your free trial ends on %s. Subscribe to keep reading.", formatRenewalDate(trialGrant.ExpiresAt)))
+		if err != nil {
+			return reminded, expired, err
+		}
+
+		err = s.trialRepository.SetReminded(trialGrant.ID)
+		if err != nil {
+			return reminded, expired, err
+		}
+		reminded++
+	}
+
+	ended, err := s.trialRepository.GetExpired()
+	if err != nil {
+		return reminded, expired, err
+	}
+
+	for _, trialGrant := range ended {
+		err = s.notify(trialGrant.UserID, models.NOTIFICATION_TRIAL_EXPIRED, "Your free trial has ended. Subscribe to keep reading.")
+		if err != nil {
+			return reminded, expired, err
+		}
+
+		err = s.trialRepository.SetExpiryNotified(trialGrant.ID)
+		if err != nil {
+			return reminded, expired, err
+		}
+		expired++
+	}
+
+	return reminded, expired, nil
+}
+
+func (s *trialService) GetStats() (*models.TrialStats, error) {
+	stats, err := s.trialRepository.GetStats()
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (s *trialService) notify(userID int64, kind models.NotificationKind, message string) error {
+	_, err := s.notificationRepository.Insert(&models.Notification{
+		UserID:  userID,
+		Kind:    kind,
+		Message: message,
+	})
+
+	return err
+}
+
+// trialIdentity normalizes an email address so that plus-addressed and, on
+// Gmail, dotted aliases of one mailbox count as the same person.
+func trialIdentity(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+
+	local, domain := email[:at], email[at+1:]
+	if plus := strings.Index(local, "+"); plus >= 0 {
+		local = local[:plus]
+	}
+
+	if domain == "gmail.com" || domain == "googlemail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+		domain = "gmail.com"
+	}
+
+	return local + "@" + domain
+}
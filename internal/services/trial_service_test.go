@@ -0,0 +1,208 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"final-project-backend/internal/constants"
+	errn "final-project-backend/internal/errors"
+	"final-project-backend/internal/models"
+	"final-project-backend/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestNewTrialService(t *testing.T) {
+	NewTrialService(&TrialServiceConfig{
+		trialRepository:        mocks.NewITrialRepository(t),
+		subscriptionRepository: mocks.NewISubscriptionRepository(t),
+		notificationRepository: mocks.NewINotificationRepository(t),
+	})
+}
+
+func Test_trialIdentity(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{email: "Member@Example.com", want: "member@example.com"},
+		{email: "member+news@example.com", want: "member@example.com"},
+		{email: "m.e.m.b.e.r+1@googlemail.com", want: "member@gmail.com"},
+		{email: "m.ember@example.com", want: "m.ember@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			assert.Equal(t, tt.want, trialIdentity(tt.email))
+		})
+	}
+}
+
+func Test_trialService_Grant(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockUser := &models.User{ID: 1, Email: "member+trial@example.com"}
+	mockTrialPlan := &models.Subscription{ID: 4, PlanID: 4, Name: "Trial", Quota: 3, Type: constants.PLAN_TRIAL}
+	matchesGrant := mock.MatchedBy(func(g *models.TrialGrant) bool {
+		return g.UserID == mockUser.ID && g.Identity == "member@example.com"
+	})
+	matchesUserSubscription := mock.MatchedBy(func(u *models.UserSubscriptions) bool {
+		return u.IsTrial && u.RemainingQuota == 3 && u.DateEnded.Equal(u.DateStarted.AddDate(0, 0, 7))
+	})
+
+	type fields struct {
+		trialRepository        *mocks.ITrialRepository
+		subscriptionRepository *mocks.ISubscriptionRepository
+		notificationRepository *mocks.INotificationRepository
+	}
+	newFields := func() fields {
+		return fields{
+			trialRepository:        mocks.NewITrialRepository(t),
+			subscriptionRepository: mocks.NewISubscriptionRepository(t),
+			notificationRepository: mocks.NewINotificationRepository(t),
+		}
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(fields)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:   "ERROR | No trial plan on offer",
+			fields: newFields(),
+			mock: func(f fields) {
+				f.subscriptionRepository.On("GetCurrentTrial").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name:   "ERROR | Error from TrialRepository.Grant",
+			fields: newFields(),
+			mock: func(f fields) {
+				f.subscriptionRepository.On("GetCurrentTrial").Return(mockTrialPlan, nil)
+				f.trialRepository.On("Grant", matchesGrant, matchesUserSubscription).Return(false, mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name:   "ERROR | Member or identity already had a trial",
+			fields: newFields(),
+			mock: func(f fields) {
+				f.subscriptionRepository.On("GetCurrentTrial").Return(mockTrialPlan, nil)
+				f.trialRepository.On("Grant", matchesGrant, matchesUserSubscription).Return(false, nil)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrTrialAlreadyUsed,
+		},
+		{
+			name:   "SUCCESS",
+			fields: newFields(),
+			mock: func(f fields) {
+				f.subscriptionRepository.On("GetCurrentTrial").Return(mockTrialPlan, nil)
+				f.trialRepository.On("Grant", matchesGrant, matchesUserSubscription).Return(true, nil)
+				f.notificationRepository.On("Insert", mock.MatchedBy(func(n *models.Notification) bool {
+					return n.UserID == mockUser.ID && n.Kind == models.NOTIFICATION_TRIAL_STARTED
+				})).Return(&models.Notification{}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &trialService{
+				trialRepository:        tt.fields.trialRepository,
+				subscriptionRepository: tt.fields.subscriptionRepository,
+				notificationRepository: tt.fields.notificationRepository,
+			}
+
+			tt.mock(tt.fields)
+			got, err := s.Grant(mockUser)
+
+			if tt.wantErr {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, got.IsTrial)
+			assert.Equal(t, mockTrialPlan.ID, got.SubscriptionID)
+		})
+	}
+}
+
+func Test_trialService_SendExpiryNotifications(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockEndingSoon := &models.TrialGrant{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	mockExpired := &models.TrialGrant{ID: 2, UserID: 2, ExpiresAt: time.Now().Add(-time.Hour)}
+
+	type fields struct {
+		trialRepository        *mocks.ITrialRepository
+		notificationRepository *mocks.INotificationRepository
+	}
+	newFields := func() fields {
+		return fields{
+			trialRepository:        mocks.NewITrialRepository(t),
+			notificationRepository: mocks.NewINotificationRepository(t),
+		}
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		mock         func(fields)
+		wantReminded int
+		wantExpired  int
+		wantErr      bool
+	}{
+		{
+			name:   "ERROR | Error from TrialRepository.GetEndingSoon",
+			fields: newFields(),
+			mock: func(f fields) {
+				f.trialRepository.On("GetEndingSoon", mock.Anything).Return(nil, mockError)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "SUCCESS | Reminds ending trials and notifies expired ones once",
+			fields: newFields(),
+			mock: func(f fields) {
+				f.trialRepository.On("GetEndingSoon", mock.Anything).Return([]*models.TrialGrant{mockEndingSoon}, nil)
+				f.notificationRepository.On("Insert", mock.MatchedBy(func(n *models.Notification) bool {
+					return n.UserID == 1 && n.Kind == models.NOTIFICATION_TRIAL_ENDING
+				})).Return(&models.Notification{}, nil)
+				f.trialRepository.On("SetReminded", int64(1)).Return(nil)
+				f.trialRepository.On("GetExpired").Return([]*models.TrialGrant{mockExpired}, nil)
+				f.notificationRepository.On("Insert", mock.MatchedBy(func(n *models.Notification) bool {
+					return n.UserID == 2 && n.Kind == models.NOTIFICATION_TRIAL_EXPIRED
+				})).Return(&models.Notification{}, nil)
+				f.trialRepository.On("SetExpiryNotified", int64(2)).Return(nil)
+			},
+			wantReminded: 1,
+			wantExpired:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &trialService{
+				trialRepository:        tt.fields.trialRepository,
+				notificationRepository: tt.fields.notificationRepository,
+			}
+
+			tt.mock(tt.fields)
+			reminded, expired, err := s.SendExpiryNotifications()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantReminded, reminded)
+			assert.Equal(t, tt.wantExpired, expired)
+		})
+	}
+}
@@ -1,33 +1,48 @@
 package services
 
 import (
+	"math"
 	"time"
 
+	"final-project-backend/internal/constants"
+	"final-project-backend/internal/dtos"
 	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/models"
 	"final-project-backend/internal/repositories"
+
+	"gorm.io/gorm"
 )
 
 type IUserSubscriptionService interface {
 	AddUserSubscription(userID int64, subscriptionID int64) (*models.UserSubscriptions, error)
 	GetAllUserSubscriptions(userID int64) ([]*models.UserSubscriptions, error)
-	ValidateUserQuota(userID int64, quotaNeeded int) error
+	UnlockPost(userID int64, postID int64, quota int) error
+	GetRemainingQuota(userID int64) (int, error)
+	GetQuotaLedger(userID int64, limit int, page int) ([]*models.QuotaLedger, int64, int64, error)
+	SetAutoRenew(userID int64, userSubscriptionID int64, autoRenew bool) (*models.UserSubscriptions, error)
+	Cancel(userID int64, userSubscriptionID int64) (*models.UserSubscriptions, error)
+	Pause(userID int64, userSubscriptionID int64, days int) (*models.UserSubscriptions, error)
+	Resume(userID int64, userSubscriptionID int64) (*models.UserSubscriptions, error)
+	GetByQuery(query *dtos.UserSubscriptionsRequestQuery) ([]*models.UserSubscriptions, int64, int64, error)
 }
 
 type userSubscriptionService struct {
 	userSubscriptionRepository repositories.IUserSubscriptionRepository
 	subscriptionRepository     repositories.ISubscriptionRepository
+	quotaLedgerRepository      repositories.IQuotaLedgerRepository
 }
 
 type UserSubscriptionServiceConfig struct {
 	userSubscriptionRepository repositories.IUserSubscriptionRepository
 	subscriptionRepository     repositories.ISubscriptionRepository
+	quotaLedgerRepository      repositories.IQuotaLedgerRepository
 }
 
 func NewUserSubscriptionService(c *UserSubscriptionServiceConfig) IUserSubscriptionService {
 	return &userSubscriptionService{
 		userSubscriptionRepository: c.userSubscriptionRepository,
 		subscriptionRepository:     c.subscriptionRepository,
+		quotaLedgerRepository:      c.quotaLedgerRepository,
 	}
 }
 
@@ -38,6 +53,12 @@ func (s *userSubscriptionService) AddUserSubscription(userID int64, subscription
 		return nil, err
 	}
 
+	// Trials are only ever granted once, see trialService.Grant. Members buy
+	// plans through invoices, this is only for admins granting a plan.
+	if !subscription.IsPurchasable() || subscription.IsTrial() {
+		return nil, errn.ErrSubscriptionNotAvailable
+	}
+
 	userSubscription := &models.UserSubscriptions{
 		UserID:         userID,
 		SubscriptionID: subscriptionID,
@@ -63,11 +84,10 @@ func (s *userSubscriptionService) GetAllUserSubscriptions(userID int64) ([]*mode
 	return userSubscriptions, nil
 }
 
-func (s *userSubscriptionService) ValidateUserQuota(userID int64, quotaNeeded int) error {
+func (s *userSubscriptionService) GetRemainingQuota(userID int64) (int, error) {
 	userSubscriptions, err := s.userSubscriptionRepository.GetOngoingUserSubscriptions(userID)
-
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	totalQuota := 0
@@ -75,26 +95,160 @@ func (s *userSubscriptionService) ValidateUserQuota(userID int64, quotaNeeded in
 		totalQuota += subscription.RemainingQuota
 	}
 
-	if totalQuota < quotaNeeded {
+	return totalQuota, nil
+}
+
+// UnlockPost is a no-op for posts the member has already unlocked, so reading
+// a post again never costs quota twice.
+func (s *userSubscriptionService) UnlockPost(userID int64, postID int64, quota int) error {
+	_, isUnlocked, err := s.userSubscriptionRepository.UnlockPost(userID, postID, quota)
+	if err != nil {
+		return err
+	}
+
+	if !isUnlocked {
 		return errn.ErrNotEnoughQuota
 	}
 
-	for _, subscription := range userSubscriptions {
-		if quotaNeeded > subscription.RemainingQuota {
-			quotaNeeded -= subscription.RemainingQuota
-			_, err := s.userSubscriptionRepository.DecrementQuota(subscription, subscription.RemainingQuota)
-
-			if err != nil {
-				return err
-			}
-		} else {
-			_, err := s.userSubscriptionRepository.DecrementQuota(subscription, quotaNeeded)
-			if err != nil {
-				return err
-			}
-			break
+	return nil
+}
+
+func (s *userSubscriptionService) GetQuotaLedger(userID int64, limit int, page int) ([]*models.QuotaLedger, int64, int64, error) {
+	entries, err := s.quotaLedgerRepository.GetByUserID(userID, limit, page)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	totalRows, err := s.quotaLedgerRepository.CountByUserID(userID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	totalPages := int64(math.Ceil(float64(totalRows) / float64(limit)))
+
+	return entries, totalRows, totalPages, nil
+}
+
+func (s *userSubscriptionService) SetAutoRenew(userID int64, userSubscriptionID int64, autoRenew bool) (*models.UserSubscriptions, error) {
+	if autoRenew {
+		userSubscription, err := s.getOwned(userID, userSubscriptionID)
+		if err != nil {
+			return nil, err
+		}
+
+		if userSubscription.IsTrial {
+			return nil, errn.ErrTrialSubscription
 		}
 	}
 
-	return nil
+	rowsAffected, err := s.userSubscriptionRepository.SetAutoRenew(userID, userSubscriptionID, autoRenew)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return s.userSubscriptionRepository.GetByID(userSubscriptionID)
+}
+
+// Cancel stops the subscription from renewing. The member keeps access and
+// quota until the current period ends.
+func (s *userSubscriptionService) Cancel(userID int64, userSubscriptionID int64) (*models.UserSubscriptions, error) {
+	_, err := s.getOwned(userID, userSubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := s.userSubscriptionRepository.Cancel(userSubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, errn.ErrSubscriptionAlreadyCancelled
+	}
+
+	return s.userSubscriptionRepository.GetByID(userSubscriptionID)
+}
+
+// Pause freezes the subscription: its quota cannot be spent and its end date
+// moves forward by the paused days, so no paid time is lost.
+func (s *userSubscriptionService) Pause(userID int64, userSubscriptionID int64, days int) (*models.UserSubscriptions, error) {
+	if days < 1 || days > constants.SUBSCRIPTION_MAX_PAUSE_DAYS {
+		return nil, errn.ErrInvalidPauseDuration
+	}
+
+	userSubscription, err := s.getOwned(userID, userSubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userSubscription.IsTrial {
+		return nil, errn.ErrTrialSubscription
+	}
+
+	if userSubscription.PausedDays+days > constants.SUBSCRIPTION_MAX_PAUSE_DAYS {
+		return nil, errn.ErrPauseLimitReached
+	}
+
+	rowsAffected, err := s.userSubscriptionRepository.Pause(userSubscriptionID, days)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, errn.ErrSubscriptionNotPausable
+	}
+
+	return s.userSubscriptionRepository.GetByID(userSubscriptionID)
+}
+
+func (s *userSubscriptionService) Resume(userID int64, userSubscriptionID int64) (*models.UserSubscriptions, error) {
+	_, err := s.getOwned(userID, userSubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := s.userSubscriptionRepository.Resume(userSubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, errn.ErrSubscriptionNotPaused
+	}
+
+	return s.userSubscriptionRepository.GetByID(userSubscriptionID)
+}
+
+func (s *userSubscriptionService) GetByQuery(query *dtos.UserSubscriptionsRequestQuery) ([]*models.UserSubscriptions, int64, int64, error) {
+	userSubscriptions, err := s.userSubscriptionRepository.GetByQuery(query)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	totalRows, err := s.userSubscriptionRepository.CountByQuery(query)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	totalPages := int64(math.Ceil(float64(totalRows) / float64(query.Limit)))
+
+	return userSubscriptions, totalRows, totalPages, nil
+}
+
+// getOwned hides other members' subscriptions behind a not found error.
+func (s *userSubscriptionService) getOwned(userID int64, userSubscriptionID int64) (*models.UserSubscriptions, error) {
+	userSubscription, err := s.userSubscriptionRepository.GetByID(userSubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userSubscription.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return userSubscription, nil
 }
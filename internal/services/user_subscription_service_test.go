@@ -3,6 +3,7 @@ package services
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	errn "final-project-backend/internal/errors"
 	"final-project-backend/internal/models"
@@ -16,6 +17,7 @@ import (
 func Test_userSubscriptionService_AddUserSubscription(t *testing.T) {
 	mockUserID := int64(1)
 	mockSubscriptionID := int64(1)
+	mockSupersededAt := time.Now()
 	mockError := fmt.Errorf("error")
 	type fields struct {
 		userSubscriptionRepository *mocks.IUserSubscriptionRepository
@@ -51,6 +53,23 @@ func Test_userSubscriptionService_AddUserSubscription(t *testing.T) {
 			wantErr:     true,
 			expectedErr: mockError,
 		},
+		{
+			name: "ERROR | Plan version no longer on sale",
+			fields: fields{
+				subscriptionRepository:     mocks.NewISubscriptionRepository(t),
+				userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
+			},
+			args: args{
+				userID:         mockUserID,
+				subscriptionID: mockSubscriptionID,
+			},
+			mock: func(usr *mocks.IUserSubscriptionRepository, sr *mocks.ISubscriptionRepository) {
+				sr.On("GetByID", mockSubscriptionID).Return(&models.Subscription{ID: mockSubscriptionID, SupersededAt: &mockSupersededAt}, nil)
+			},
+			want:        nil,
+			wantErr:     true,
+			expectedErr: errn.ErrSubscriptionNotAvailable,
+		},
 		{
 			name: "ERROR | Error from userSubscriptionRepository.Insert",
 			fields: fields{
@@ -117,150 +136,101 @@ func Test_userSubscriptionService_AddUserSubscription(t *testing.T) {
 	}
 }
 
-func Test_userSubscriptionService_ValidateUserQuota(t *testing.T) {
+func Test_userSubscriptionService_UnlockPost(t *testing.T) {
 	mockError := fmt.Errorf("error")
 	mockUserID := int64(1)
+	mockPostID := int64(2)
 	mockQuotaNeeded := 2
 	type fields struct {
 		userSubscriptionRepository *mocks.IUserSubscriptionRepository
-		subscriptionRepository     *mocks.ISubscriptionRepository
-	}
-	type args struct {
-		userID      int64
-		quotaNeeded int
 	}
 	tests := []struct {
 		name        string
 		fields      fields
-		args        args
-		mock        func(*mocks.IUserSubscriptionRepository, *mocks.ISubscriptionRepository)
+		mock        func(*mocks.IUserSubscriptionRepository)
 		wantErr     bool
 		expectedErr error
 	}{
 		{
-			name: "ERROR | Error from userSubscriptionRepository.GetOngoingUserSubscriptions",
+			name: "ERROR | Error from userSubscriptionRepository.UnlockPost",
 			fields: fields{
-				subscriptionRepository:     mocks.NewISubscriptionRepository(t),
 				userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
 			},
-			args: args{
-				userID:      mockUserID,
-				quotaNeeded: mockQuotaNeeded,
-			},
-			mock: func(usr *mocks.IUserSubscriptionRepository, sr *mocks.ISubscriptionRepository) {
-				usr.On("GetOngoingUserSubscriptions", mockUserID).Return(nil, mockError)
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("UnlockPost", mockUserID, mockPostID, mockQuotaNeeded).Return(nil, false, mockError)
 			},
 			wantErr:     true,
 			expectedErr: mockError,
 		},
-		{
-			name: "ERROR | 0 subscriptions data",
-			fields: fields{
-				subscriptionRepository:     mocks.NewISubscriptionRepository(t),
-				userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
-			},
-			args: args{
-				userID:      mockUserID,
-				quotaNeeded: mockQuotaNeeded,
-			},
-			mock: func(usr *mocks.IUserSubscriptionRepository, sr *mocks.ISubscriptionRepository) {
-				usr.On("GetOngoingUserSubscriptions", mockUserID).Return([]*models.UserSubscriptions{}, nil)
-			},
-			wantErr:     true,
-			expectedErr: errn.ErrNotEnoughQuota,
-		},
 		{
 			name: "ERROR | not enough quota from available subscriptions",
 			fields: fields{
-				subscriptionRepository:     mocks.NewISubscriptionRepository(t),
 				userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
 			},
-			args: args{
-				userID:      mockUserID,
-				quotaNeeded: mockQuotaNeeded,
-			},
-			mock: func(usr *mocks.IUserSubscriptionRepository, sr *mocks.ISubscriptionRepository) {
-				usr.On("GetOngoingUserSubscriptions", mockUserID).Return([]*models.UserSubscriptions{
-					{
-						RemainingQuota: mockQuotaNeeded - 1,
-					},
-				}, nil)
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("UnlockPost", mockUserID, mockPostID, mockQuotaNeeded).Return([]*models.QuotaLedger{}, false, nil)
 			},
 			wantErr:     true,
 			expectedErr: errn.ErrNotEnoughQuota,
 		},
 		{
-			name: "ERROR | Error from DecrementQuota when quotaneeded > remainingquota",
+			name: "SUCCESS",
 			fields: fields{
-				subscriptionRepository:     mocks.NewISubscriptionRepository(t),
 				userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
 			},
-			args: args{
-				userID:      mockUserID,
-				quotaNeeded: mockQuotaNeeded,
-			},
-			mock: func(usr *mocks.IUserSubscriptionRepository, sr *mocks.ISubscriptionRepository) {
-				usr.On("GetOngoingUserSubscriptions", mockUserID).Return([]*models.UserSubscriptions{
-					{
-						Model: gorm.Model{
-							ID: 1,
-						},
-						RemainingQuota: mockQuotaNeeded - 1,
-					},
-					{
-						Model: gorm.Model{
-							ID: 2,
-						},
-						RemainingQuota: mockQuotaNeeded,
-					},
-				}, nil)
-				usr.On("DecrementQuota", &models.UserSubscriptions{
-					Model: gorm.Model{
-						ID: 1,
-					},
-					RemainingQuota: mockQuotaNeeded - 1,
-				}, mockQuotaNeeded-1).Return(nil, mockError)
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("UnlockPost", mockUserID, mockPostID, mockQuotaNeeded).Return([]*models.QuotaLedger{
+					{UserSubscriptionID: 1, Amount: 1},
+					{UserSubscriptionID: 2, Amount: 1},
+				}, true, nil)
 			},
-			wantErr:     true,
-			expectedErr: mockError,
+			wantErr:     false,
+			expectedErr: nil,
 		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &userSubscriptionService{
+				userSubscriptionRepository: tt.fields.userSubscriptionRepository,
+			}
+
+			tt.mock(tt.fields.userSubscriptionRepository)
+			err := s.UnlockPost(mockUserID, mockPostID, mockQuotaNeeded)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func Test_userSubscriptionService_GetQuotaLedger(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockUserID := int64(1)
+	mockEntries := []*models.QuotaLedger{{ID: 1, UserID: mockUserID, Amount: 2}}
+	type fields struct {
+		quotaLedgerRepository *mocks.IQuotaLedgerRepository
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		mock           func(*mocks.IQuotaLedgerRepository)
+		want           []*models.QuotaLedger
+		wantTotalPages int64
+		wantErr        bool
+		expectedErr    error
+	}{
 		{
-			name: "ERROR | Error from DecrementQuota when quotaneeded < remainingquota",
+			name: "ERROR | Error from quotaLedgerRepository.CountByUserID",
 			fields: fields{
-				subscriptionRepository:     mocks.NewISubscriptionRepository(t),
-				userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
+				quotaLedgerRepository: mocks.NewIQuotaLedgerRepository(t),
 			},
-			args: args{
-				userID:      mockUserID,
-				quotaNeeded: mockQuotaNeeded,
-			},
-			mock: func(usr *mocks.IUserSubscriptionRepository, sr *mocks.ISubscriptionRepository) {
-				usr.On("GetOngoingUserSubscriptions", mockUserID).Return([]*models.UserSubscriptions{
-					{
-						Model: gorm.Model{
-							ID: 1,
-						},
-						RemainingQuota: mockQuotaNeeded - 1,
-					},
-					{
-						Model: gorm.Model{
-							ID: 2,
-						},
-						RemainingQuota: mockQuotaNeeded,
-					},
-				}, nil)
-				usr.On("DecrementQuota", &models.UserSubscriptions{
-					Model: gorm.Model{
-						ID: 1,
-					},
-					RemainingQuota: mockQuotaNeeded - 1,
-				}, mockQuotaNeeded-1).Return(nil, nil)
-				usr.On("DecrementQuota", &models.UserSubscriptions{
-					Model: gorm.Model{
-						ID: 2,
-					},
-					RemainingQuota: mockQuotaNeeded,
-				}, 1).Return(nil, mockError)
+			mock: func(r *mocks.IQuotaLedgerRepository) {
+				r.On("GetByUserID", mockUserID, 10, 1).Return(mockEntries, nil)
+				r.On("CountByUserID", mockUserID).Return(int64(0), mockError)
 			},
 			wantErr:     true,
 			expectedErr: mockError,
@@ -268,61 +238,34 @@ func Test_userSubscriptionService_ValidateUserQuota(t *testing.T) {
 		{
 			name: "SUCCESS",
 			fields: fields{
-				subscriptionRepository:     mocks.NewISubscriptionRepository(t),
-				userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
+				quotaLedgerRepository: mocks.NewIQuotaLedgerRepository(t),
 			},
-			args: args{
-				userID:      mockUserID,
-				quotaNeeded: mockQuotaNeeded,
+			mock: func(r *mocks.IQuotaLedgerRepository) {
+				r.On("GetByUserID", mockUserID, 10, 1).Return(mockEntries, nil)
+				r.On("CountByUserID", mockUserID).Return(int64(11), nil)
 			},
-			mock: func(usr *mocks.IUserSubscriptionRepository, sr *mocks.ISubscriptionRepository) {
-				usr.On("GetOngoingUserSubscriptions", mockUserID).Return([]*models.UserSubscriptions{
-					{
-						Model: gorm.Model{
-							ID: 1,
-						},
-						RemainingQuota: mockQuotaNeeded - 1,
-					},
-					{
-						Model: gorm.Model{
-							ID: 2,
-						},
-						RemainingQuota: mockQuotaNeeded,
-					},
-				}, nil)
-				usr.On("DecrementQuota", &models.UserSubscriptions{
-					Model: gorm.Model{
-						ID: 1,
-					},
-					RemainingQuota: mockQuotaNeeded - 1,
-				}, mockQuotaNeeded-1).Return(nil, nil)
-				usr.On("DecrementQuota", &models.UserSubscriptions{
-					Model: gorm.Model{
-						ID: 2,
-					},
-					RemainingQuota: mockQuotaNeeded,
-				}, 1).Return(nil, nil)
-			},
-			wantErr:     false,
-			expectedErr: nil,
+			want:           mockEntries,
+			wantTotalPages: 2,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &userSubscriptionService{
-				subscriptionRepository:     tt.fields.subscriptionRepository,
-				userSubscriptionRepository: tt.fields.userSubscriptionRepository,
+				quotaLedgerRepository: tt.fields.quotaLedgerRepository,
 			}
 
-			tt.mock(tt.fields.userSubscriptionRepository, tt.fields.subscriptionRepository)
-			err := s.ValidateUserQuota(tt.args.userID, tt.args.quotaNeeded)
+			tt.mock(tt.fields.quotaLedgerRepository)
+			got, _, totalPages, err := s.GetQuotaLedger(mockUserID, 10, 1)
 
-			if !tt.wantErr {
-				assert.NoError(t, err)
-			} else {
-				assert.Error(t, err)
+			if tt.wantErr {
 				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+				return
 			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantTotalPages, totalPages)
 		})
 	}
 }
@@ -398,3 +341,291 @@ func Test_userSubscriptionService_GetAllUserSubscriptions(t *testing.T) {
 		})
 	}
 }
+
+func Test_userSubscriptionService_GetRemainingQuota(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockUserID := int64(1)
+	type fields struct {
+		userSubscriptionRepository *mocks.IUserSubscriptionRepository
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		mock        func(*mocks.IUserSubscriptionRepository)
+		want        int
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Error from userSubscriptionRepository.GetOngoingUserSubscriptions",
+			fields: fields{
+				userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
+			},
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("GetOngoingUserSubscriptions", mockUserID).Return(nil, mockError)
+			},
+			want:        0,
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "SUCCESS",
+			fields: fields{
+				userSubscriptionRepository: mocks.NewIUserSubscriptionRepository(t),
+			},
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("GetOngoingUserSubscriptions", mockUserID).Return([]*models.UserSubscriptions{
+					{RemainingQuota: 2},
+					{RemainingQuota: 3},
+				}, nil)
+			},
+			want:        5,
+			wantErr:     false,
+			expectedErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &userSubscriptionService{
+				userSubscriptionRepository: tt.fields.userSubscriptionRepository,
+			}
+
+			tt.mock(tt.fields.userSubscriptionRepository)
+			got, err := s.GetRemainingQuota(mockUserID)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_userSubscriptionService_Pause(t *testing.T) {
+	mockError := fmt.Errorf("error")
+	mockUserID := int64(1)
+	mockUserSubscriptionID := int64(2)
+	mockUserSubscription := &models.UserSubscriptions{UserID: mockUserID}
+	tests := []struct {
+		name        string
+		days        int
+		mock        func(*mocks.IUserSubscriptionRepository)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:        "ERROR | Pause longer than allowed",
+			days:        31,
+			mock:        func(usr *mocks.IUserSubscriptionRepository) {},
+			wantErr:     true,
+			expectedErr: errn.ErrInvalidPauseDuration,
+		},
+		{
+			name: "ERROR | Subscription of another member",
+			days: 7,
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("GetByID", mockUserSubscriptionID).Return(&models.UserSubscriptions{UserID: 9}, nil)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "ERROR | Error from userSubscriptionRepository.Pause",
+			days: 7,
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("GetByID", mockUserSubscriptionID).Return(mockUserSubscription, nil)
+				usr.On("Pause", mockUserSubscriptionID, 7).Return(int64(0), mockError)
+			},
+			wantErr:     true,
+			expectedErr: mockError,
+		},
+		{
+			name: "ERROR | Subscription already paused or ended",
+			days: 7,
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("GetByID", mockUserSubscriptionID).Return(mockUserSubscription, nil)
+				usr.On("Pause", mockUserSubscriptionID, 7).Return(int64(0), nil)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrSubscriptionNotPausable,
+		},
+		{
+			name: "SUCCESS",
+			days: 7,
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("GetByID", mockUserSubscriptionID).Return(mockUserSubscription, nil)
+				usr.On("Pause", mockUserSubscriptionID, 7).Return(int64(1), nil)
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usr := mocks.NewIUserSubscriptionRepository(t)
+			s := &userSubscriptionService{
+				userSubscriptionRepository: usr,
+			}
+
+			tt.mock(usr)
+			got, err := s.Pause(mockUserID, mockUserSubscriptionID, tt.days)
+
+			if tt.wantErr {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, mockUserSubscription, got)
+		})
+	}
+}
+
+func Test_userSubscriptionService_PauseResumePause(t *testing.T) {
+	mockUserID := int64(1)
+	mockUserSubscriptionID := int64(2)
+	mockUserSubscription := &models.UserSubscriptions{UserID: mockUserID}
+
+	usr := mocks.NewIUserSubscriptionRepository(t)
+	s := &userSubscriptionService{
+		userSubscriptionRepository: usr,
+	}
+
+	usr.On("GetByID", mockUserSubscriptionID).Return(mockUserSubscription, nil)
+	usr.On("Pause", mockUserSubscriptionID, mock.Anything).Run(func(args mock.Arguments) {
+		mockUserSubscription.PausedDays += args.Int(1)
+	}).Return(int64(1), nil)
+	// Resumed with 5 whole days left.
+	usr.On("Resume", mockUserSubscriptionID).Run(func(args mock.Arguments) {
+		mockUserSubscription.PausedDays -= 5
+	}).Return(int64(1), nil)
+
+	_, err := s.Pause(mockUserID, mockUserSubscriptionID, 20)
+	assert.NoError(t, err)
+
+	_, err = s.Resume(mockUserID, mockUserSubscriptionID)
+	assert.NoError(t, err)
+	assert.Equal(t, 15, mockUserSubscription.PausedDays)
+
+	_, err = s.Pause(mockUserID, mockUserSubscriptionID, 20)
+	assert.EqualError(t, err, errn.ErrPauseLimitReached.Error())
+
+	_, err = s.Pause(mockUserID, mockUserSubscriptionID, 15)
+	assert.NoError(t, err)
+	assert.Equal(t, 30, mockUserSubscription.PausedDays)
+
+	_, err = s.Pause(mockUserID, mockUserSubscriptionID, 1)
+	assert.EqualError(t, err, errn.ErrPauseLimitReached.Error())
+}
+
+func Test_userSubscriptionService_Resume(t *testing.T) {
+	mockUserID := int64(1)
+	mockUserSubscriptionID := int64(2)
+	mockUserSubscription := &models.UserSubscriptions{UserID: mockUserID}
+	tests := []struct {
+		name        string
+		mock        func(*mocks.IUserSubscriptionRepository)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Subscription not found",
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("GetByID", mockUserSubscriptionID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr:     true,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "ERROR | Subscription is not paused",
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("GetByID", mockUserSubscriptionID).Return(mockUserSubscription, nil)
+				usr.On("Resume", mockUserSubscriptionID).Return(int64(0), nil)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrSubscriptionNotPaused,
+		},
+		{
+			name: "SUCCESS",
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("GetByID", mockUserSubscriptionID).Return(mockUserSubscription, nil)
+				usr.On("Resume", mockUserSubscriptionID).Return(int64(1), nil)
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usr := mocks.NewIUserSubscriptionRepository(t)
+			s := &userSubscriptionService{
+				userSubscriptionRepository: usr,
+			}
+
+			tt.mock(usr)
+			got, err := s.Resume(mockUserID, mockUserSubscriptionID)
+
+			if tt.wantErr {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, mockUserSubscription, got)
+		})
+	}
+}
+
+func Test_userSubscriptionService_Cancel(t *testing.T) {
+	mockUserID := int64(1)
+	mockUserSubscriptionID := int64(2)
+	mockUserSubscription := &models.UserSubscriptions{UserID: mockUserID}
+	tests := []struct {
+		name        string
+		mock        func(*mocks.IUserSubscriptionRepository)
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "ERROR | Subscription already cancelled",
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("GetByID", mockUserSubscriptionID).Return(mockUserSubscription, nil)
+				usr.On("Cancel", mockUserSubscriptionID).Return(int64(0), nil)
+			},
+			wantErr:     true,
+			expectedErr: errn.ErrSubscriptionAlreadyCancelled,
+		},
+		{
+			name: "SUCCESS",
+			mock: func(usr *mocks.IUserSubscriptionRepository) {
+				usr.On("GetByID", mockUserSubscriptionID).Return(mockUserSubscription, nil)
+				usr.On("Cancel", mockUserSubscriptionID).Return(int64(1), nil)
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usr := mocks.NewIUserSubscriptionRepository(t)
+			s := &userSubscriptionService{
+				userSubscriptionRepository: usr,
+			}
+
+			tt.mock(usr)
+			got, err := s.Cancel(mockUserID, mockUserSubscriptionID)
+
+			if tt.wantErr {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, mockUserSubscription, got)
+		})
+	}
+}
@@ -2,9 +2,12 @@ package main
 
 import (
 	"log"
+	// Member timezones are resolved even where the host has no zoneinfo.
+	_ "time/tzdata"
 
 	"final-project-backend/db"
 	"final-project-backend/internal/handlers"
+	"final-project-backend/internal/jobs"
 	"final-project-backend/internal/middlewares"
 	"final-project-backend/internal/repositories"
 	"final-project-backend/internal/routes"
@@ -24,6 +27,10 @@ func main() {
 	s := services.New(rp)
 	h := handlers.New(s)
 
+	scheduler := jobs.New(s)
+	scheduler.Start()
+	defer scheduler.Stop()
+
 	r := gin.Default()
 	r.Use(middlewares.Cors)
 	routes.InitRoutes(r, h)
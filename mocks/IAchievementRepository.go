@@ -0,0 +1,172 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAchievementRepository is an autogenerated mock type for the IAchievementRepository type
+type IAchievementRepository struct {
+	mock.Mock
+}
+
+// Award provides a mock function with given fields: userAchievement, userVoucher
+func (_m *IAchievementRepository) Award(userAchievement *models.UserAchievement, userVoucher *models.UserVoucher) (bool, error) {
+	ret := _m.Called(userAchievement, userVoucher)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*models.UserAchievement, *models.UserVoucher) bool); ok {
+		r0 = rf(userAchievement, userVoucher)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.UserAchievement, *models.UserVoucher) error); ok {
+		r1 = rf(userAchievement, userVoucher)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: userID
+func (_m *IAchievementRepository) GetByUserID(userID int64) ([]*models.UserAchievement, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*models.UserAchievement
+	if rf, ok := ret.Get(0).(func(int64) []*models.UserAchievement); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.UserAchievement)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRuleByID provides a mock function with given fields: id
+func (_m *IAchievementRepository) GetRuleByID(id int64) (*models.AchievementRule, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.AchievementRule
+	if rf, ok := ret.Get(0).(func(int64) *models.AchievementRule); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AchievementRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRules provides a mock function with given fields:
+func (_m *IAchievementRepository) GetRules() ([]*models.AchievementRule, error) {
+	ret := _m.Called()
+
+	var r0 []*models.AchievementRule
+	if rf, ok := ret.Get(0).(func() []*models.AchievementRule); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AchievementRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertRule provides a mock function with given fields: rule
+func (_m *IAchievementRepository) InsertRule(rule *models.AchievementRule) (*models.AchievementRule, error) {
+	ret := _m.Called(rule)
+
+	var r0 *models.AchievementRule
+	if rf, ok := ret.Get(0).(func(*models.AchievementRule) *models.AchievementRule); ok {
+		r0 = rf(rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AchievementRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.AchievementRule) error); ok {
+		r1 = rf(rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRule provides a mock function with given fields: rule
+func (_m *IAchievementRepository) UpdateRule(rule *models.AchievementRule) (*models.AchievementRule, int, error) {
+	ret := _m.Called(rule)
+
+	var r0 *models.AchievementRule
+	if rf, ok := ret.Get(0).(func(*models.AchievementRule) *models.AchievementRule); ok {
+		r0 = rf(rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AchievementRule)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*models.AchievementRule) int); ok {
+		r1 = rf(rule)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*models.AchievementRule) error); ok {
+		r2 = rf(rule)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewIAchievementRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIAchievementRepository creates a new instance of IAchievementRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIAchievementRepository(t mockConstructorTestingTNewIAchievementRepository) *IAchievementRepository {
+	mock := &IAchievementRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
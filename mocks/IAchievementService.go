@@ -0,0 +1,121 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAchievementService is an autogenerated mock type for the IAchievementService type
+type IAchievementService struct {
+	mock.Mock
+}
+
+// CreateRule provides a mock function with given fields: rule
+func (_m *IAchievementService) CreateRule(rule *models.AchievementRule) (*models.AchievementRule, error) {
+	ret := _m.Called(rule)
+
+	var r0 *models.AchievementRule
+	if rf, ok := ret.Get(0).(func(*models.AchievementRule) *models.AchievementRule); ok {
+		r0 = rf(rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AchievementRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.AchievementRule) error); ok {
+		r1 = rf(rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRules provides a mock function with given fields:
+func (_m *IAchievementService) GetRules() ([]*models.AchievementRule, error) {
+	ret := _m.Called()
+
+	var r0 []*models.AchievementRule
+	if rf, ok := ret.Get(0).(func() []*models.AchievementRule); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AchievementRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserAchievements provides a mock function with given fields: userID
+func (_m *IAchievementService) GetUserAchievements(userID int64) (*models.AchievementSummary, error) {
+	ret := _m.Called(userID)
+
+	var r0 *models.AchievementSummary
+	if rf, ok := ret.Get(0).(func(int64) *models.AchievementSummary); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AchievementSummary)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRule provides a mock function with given fields: rule
+func (_m *IAchievementService) UpdateRule(rule *models.AchievementRule) (*models.AchievementRule, error) {
+	ret := _m.Called(rule)
+
+	var r0 *models.AchievementRule
+	if rf, ok := ret.Get(0).(func(*models.AchievementRule) *models.AchievementRule); ok {
+		r0 = rf(rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AchievementRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.AchievementRule) error); ok {
+		r1 = rf(rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIAchievementService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIAchievementService creates a new instance of IAchievementService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIAchievementService(t mockConstructorTestingTNewIAchievementService) *IAchievementService {
+	mock := &IAchievementService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -118,6 +118,43 @@ func (_m *IAuthService) Register(user *models.User, referredCode *string) (*mode
 	return r0, r1, r2, r3
 }
 
+// SendVerificationEmail provides a mock function with given fields: user, link
+func (_m *IAuthService) SendVerificationEmail(user *models.User, link string) error {
+	ret := _m.Called(user, link)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.User, string) error); ok {
+		r0 = rf(user, link)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyEmail provides a mock function with given fields: userID, email
+func (_m *IAuthService) VerifyEmail(userID int64, email string) (*models.User, error) {
+	ret := _m.Called(userID, email)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(int64, string) *models.User); ok {
+		r0 = rf(userID, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(userID, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewIAuthService interface {
 	mock.TestingT
 	Cleanup(func())
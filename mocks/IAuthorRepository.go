@@ -0,0 +1,198 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAuthorRepository is an autogenerated mock type for the IAuthorRepository type
+type IAuthorRepository struct {
+	mock.Mock
+}
+
+// CountFollowers provides a mock function with given fields: authorID
+func (_m *IAuthorRepository) CountFollowers(authorID int64) (int64, error) {
+	ret := _m.Called(authorID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(authorID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Follow provides a mock function with given fields: follower
+func (_m *IAuthorRepository) Follow(follower *models.AuthorFollower) error {
+	ret := _m.Called(follower)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.AuthorFollower) error); ok {
+		r0 = rf(follower)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields:
+func (_m *IAuthorRepository) GetAll() ([]*models.Author, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Author
+	if rf, ok := ret.Get(0).(func() []*models.Author); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *IAuthorRepository) GetByID(id int64) (*models.Author, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Author
+	if rf, ok := ret.Get(0).(func(int64) *models.Author); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Insert provides a mock function with given fields: author
+func (_m *IAuthorRepository) Insert(author *models.Author) (*models.Author, error) {
+	ret := _m.Called(author)
+
+	var r0 *models.Author
+	if rf, ok := ret.Get(0).(func(*models.Author) *models.Author); ok {
+		r0 = rf(author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Author) error); ok {
+		r1 = rf(author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsFollowing provides a mock function with given fields: userID, authorID
+func (_m *IAuthorRepository) IsFollowing(userID int64, authorID int64) (bool, error) {
+	ret := _m.Called(userID, authorID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64, int64) bool); ok {
+		r0 = rf(userID, authorID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(userID, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unfollow provides a mock function with given fields: follower
+func (_m *IAuthorRepository) Unfollow(follower *models.AuthorFollower) error {
+	ret := _m.Called(follower)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.AuthorFollower) error); ok {
+		r0 = rf(follower)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: author
+func (_m *IAuthorRepository) Update(author *models.Author) (*models.Author, int, error) {
+	ret := _m.Called(author)
+
+	var r0 *models.Author
+	if rf, ok := ret.Get(0).(func(*models.Author) *models.Author); ok {
+		r0 = rf(author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Author)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*models.Author) int); ok {
+		r1 = rf(author)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*models.Author) error); ok {
+		r2 = rf(author)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewIAuthorRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIAuthorRepository creates a new instance of IAuthorRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIAuthorRepository(t mockConstructorTestingTNewIAuthorRepository) *IAuthorRepository {
+	mock := &IAuthorRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
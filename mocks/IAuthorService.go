@@ -0,0 +1,172 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAuthorService is an autogenerated mock type for the IAuthorService type
+type IAuthorService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: author
+func (_m *IAuthorService) Create(author *models.Author) (*models.Author, error) {
+	ret := _m.Called(author)
+
+	var r0 *models.Author
+	if rf, ok := ret.Get(0).(func(*models.Author) *models.Author); ok {
+		r0 = rf(author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Author) error); ok {
+		r1 = rf(author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Follow provides a mock function with given fields: userID, authorID
+func (_m *IAuthorService) Follow(userID int64, authorID int64) error {
+	ret := _m.Called(userID, authorID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(userID, authorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields:
+func (_m *IAuthorService) GetAll() ([]*models.Author, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Author
+	if rf, ok := ret.Get(0).(func() []*models.Author); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *IAuthorService) GetByID(id int64) (*models.Author, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Author
+	if rf, ok := ret.Get(0).(func(int64) *models.Author); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProfile provides a mock function with given fields: authorID, userID
+func (_m *IAuthorService) GetProfile(authorID int64, userID int64) (*models.AuthorProfile, error) {
+	ret := _m.Called(authorID, userID)
+
+	var r0 *models.AuthorProfile
+	if rf, ok := ret.Get(0).(func(int64, int64) *models.AuthorProfile); ok {
+		r0 = rf(authorID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthorProfile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(authorID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unfollow provides a mock function with given fields: userID, authorID
+func (_m *IAuthorService) Unfollow(userID int64, authorID int64) error {
+	ret := _m.Called(userID, authorID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(userID, authorID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: author
+func (_m *IAuthorService) Update(author *models.Author) (*models.Author, error) {
+	ret := _m.Called(author)
+
+	var r0 *models.Author
+	if rf, ok := ret.Get(0).(func(*models.Author) *models.Author); ok {
+		r0 = rf(author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Author) error); ok {
+		r1 = rf(author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIAuthorService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIAuthorService creates a new instance of IAuthorService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIAuthorService(t mockConstructorTestingTNewIAuthorService) *IAuthorService {
+	mock := &IAuthorService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
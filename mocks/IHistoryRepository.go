@@ -3,9 +3,14 @@
 package mocks
 
 import (
-	models "final-project-backend/internal/models"
+	constants "final-project-backend/internal/constants"
+	dtos "final-project-backend/internal/dtos"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "final-project-backend/internal/models"
+
+	time "time"
 )
 
 // IHistoryRepository is an autogenerated mock type for the IHistoryRepository type
@@ -13,22 +18,20 @@ type IHistoryRepository struct {
 	mock.Mock
 }
 
-// GetByUserAndPostID provides a mock function with given fields: userID, postID
-func (_m *IHistoryRepository) GetByUserAndPostID(userID int64, postID int64) (*models.History, error) {
-	ret := _m.Called(userID, postID)
+// CountByQuery provides a mock function with given fields: query
+func (_m *IHistoryRepository) CountByQuery(query *dtos.HistoriesRequestQuery) (int64, error) {
+	ret := _m.Called(query)
 
-	var r0 *models.History
-	if rf, ok := ret.Get(0).(func(int64, int64) *models.History); ok {
-		r0 = rf(userID, postID)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*dtos.HistoriesRequestQuery) int64); ok {
+		r0 = rf(query)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.History)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(userID, postID)
+	if rf, ok := ret.Get(1).(func(*dtos.HistoriesRequestQuery) error); ok {
+		r1 = rf(query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -36,13 +39,13 @@ func (_m *IHistoryRepository) GetByUserAndPostID(userID int64, postID int64) (*m
 	return r0, r1
 }
 
-// GetByUserID provides a mock function with given fields: userID
-func (_m *IHistoryRepository) GetByUserID(userID int64) ([]*models.History, error) {
-	ret := _m.Called(userID)
+// GetByQuery provides a mock function with given fields: query
+func (_m *IHistoryRepository) GetByQuery(query *dtos.HistoriesRequestQuery) ([]*models.History, error) {
+	ret := _m.Called(query)
 
 	var r0 []*models.History
-	if rf, ok := ret.Get(0).(func(int64) []*models.History); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(*dtos.HistoriesRequestQuery) []*models.History); ok {
+		r0 = rf(query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.History)
@@ -50,8 +53,31 @@ func (_m *IHistoryRepository) GetByUserID(userID int64) ([]*models.History, erro
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(*dtos.HistoriesRequestQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserAndPostID provides a mock function with given fields: userID, postID
+func (_m *IHistoryRepository) GetByUserAndPostID(userID int64, postID int64) (*models.History, error) {
+	ret := _m.Called(userID, postID)
+
+	var r0 *models.History
+	if rf, ok := ret.Get(0).(func(int64, int64) *models.History); ok {
+		r0 = rf(userID, postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.History)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(userID, postID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -105,22 +131,179 @@ func (_m *IHistoryRepository) GetCategoriesReadCountPastWeek(structConditions *m
 	return r0, r1
 }
 
-// GetTrendingPostsByCategory provides a mock function with given fields: categoryID
-func (_m *IHistoryRepository) GetTrendingPostsByCategory(categoryID int64) ([]*models.Post, error) {
-	ret := _m.Called(categoryID)
+// GetCategoryInterests provides a mock function with given fields: userID, limit
+func (_m *IHistoryRepository) GetCategoryInterests(userID int64, limit int) ([]*models.CategoryInterest, error) {
+	ret := _m.Called(userID, limit)
+
+	var r0 []*models.CategoryInterest
+	if rf, ok := ret.Get(0).(func(int64, int) []*models.CategoryInterest); ok {
+		r0 = rf(userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CategoryInterest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadCountsByCategory provides a mock function with given fields: userID
+func (_m *IHistoryRepository) GetReadCountsByCategory(userID int64) ([]*models.PostCategoryReadCount, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*models.PostCategoryReadCount
+	if rf, ok := ret.Get(0).(func(int64) []*models.PostCategoryReadCount); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PostCategoryReadCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadPostIDs provides a mock function with given fields: userID, postIDs
+func (_m *IHistoryRepository) GetReadPostIDs(userID int64, postIDs []int64) ([]int64, error) {
+	ret := _m.Called(userID, postIDs)
 
-	var r0 []*models.Post
-	if rf, ok := ret.Get(0).(func(int64) []*models.Post); ok {
-		r0 = rf(categoryID)
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(int64, []int64) []int64); ok {
+		r0 = rf(userID, postIDs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Post)
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, []int64) error); ok {
+		r1 = rf(userID, postIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadStats provides a mock function with given fields: postID
+func (_m *IHistoryRepository) GetReadStats(postID int64) (*models.PostReadStats, error) {
+	ret := _m.Called(postID)
+
+	var r0 *models.PostReadStats
+	if rf, ok := ret.Get(0).(func(int64) *models.PostReadStats); ok {
+		r0 = rf(postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PostReadStats)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(categoryID)
+		r1 = rf(postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadingDays provides a mock function with given fields: userID, timezone
+func (_m *IHistoryRepository) GetReadingDays(userID int64, timezone string) ([]time.Time, error) {
+	ret := _m.Called(userID, timezone)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(int64, string) []time.Time); ok {
+		r0 = rf(userID, timezone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(userID, timezone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecentPostIDs provides a mock function with given fields: userID, limit
+func (_m *IHistoryRepository) GetRecentPostIDs(userID int64, limit int) ([]int64, error) {
+	ret := _m.Called(userID, limit)
+
+	var r0 []int64
+	if rf, ok := ret.Get(0).(func(int64, int) []int64); ok {
+		r0 = rf(userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Hide provides a mock function with given fields: userID, postID
+func (_m *IHistoryRepository) Hide(userID int64, postID int64) (int64, error) {
+	ret := _m.Called(userID, postID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(userID, postID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(userID, postID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HideAll provides a mock function with given fields: userID
+func (_m *IHistoryRepository) HideAll(userID int64) (int64, error) {
+	ret := _m.Called(userID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -151,6 +334,96 @@ func (_m *IHistoryRepository) Insert(history *models.History) (*models.History,
 	return r0, r1
 }
 
+// SetLiked provides a mock function with given fields: userID, postID, isLiked
+func (_m *IHistoryRepository) SetLiked(userID int64, postID int64, isLiked bool) (*models.History, bool, error) {
+	ret := _m.Called(userID, postID, isLiked)
+
+	var r0 *models.History
+	if rf, ok := ret.Get(0).(func(int64, int64, bool) *models.History); ok {
+		r0 = rf(userID, postID, isLiked)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.History)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(int64, int64, bool) bool); ok {
+		r1 = rf(userID, postID, isLiked)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64, int64, bool) error); ok {
+		r2 = rf(userID, postID, isLiked)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetReaction provides a mock function with given fields: userID, postID, reaction
+func (_m *IHistoryRepository) SetReaction(userID int64, postID int64, reaction constants.Reaction) (*models.History, bool, error) {
+	ret := _m.Called(userID, postID, reaction)
+
+	var r0 *models.History
+	if rf, ok := ret.Get(0).(func(int64, int64, constants.Reaction) *models.History); ok {
+		r0 = rf(userID, postID, reaction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.History)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(int64, int64, constants.Reaction) bool); ok {
+		r1 = rf(userID, postID, reaction)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64, int64, constants.Reaction) error); ok {
+		r2 = rf(userID, postID, reaction)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetShared provides a mock function with given fields: userID, postID
+func (_m *IHistoryRepository) SetShared(userID int64, postID int64) (*models.History, bool, error) {
+	ret := _m.Called(userID, postID)
+
+	var r0 *models.History
+	if rf, ok := ret.Get(0).(func(int64, int64) *models.History); ok {
+		r0 = rf(userID, postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.History)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(int64, int64) bool); ok {
+		r1 = rf(userID, postID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64, int64) error); ok {
+		r2 = rf(userID, postID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Update provides a mock function with given fields: history
 func (_m *IHistoryRepository) Update(history *models.History) (*models.History, int, error) {
 	ret := _m.Called(history)
@@ -181,6 +454,29 @@ func (_m *IHistoryRepository) Update(history *models.History) (*models.History,
 	return r0, r1, r2
 }
 
+// UpdateProgress provides a mock function with given fields: userID, postID, progress
+func (_m *IHistoryRepository) UpdateProgress(userID int64, postID int64, progress int) (*models.History, error) {
+	ret := _m.Called(userID, postID, progress)
+
+	var r0 *models.History
+	if rf, ok := ret.Get(0).(func(int64, int64, int) *models.History); ok {
+		r0 = rf(userID, postID, progress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.History)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64, int) error); ok {
+		r1 = rf(userID, postID, progress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateSingleColumn provides a mock function with given fields: key, history
 func (_m *IHistoryRepository) UpdateSingleColumn(key string, history *models.History) (*models.History, int, error) {
 	ret := _m.Called(key, history)
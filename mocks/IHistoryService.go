@@ -3,9 +3,11 @@
 package mocks
 
 import (
-	models "final-project-backend/internal/models"
+	dtos "final-project-backend/internal/dtos"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "final-project-backend/internal/models"
 )
 
 // IHistoryService is an autogenerated mock type for the IHistoryService type
@@ -13,6 +15,78 @@ type IHistoryService struct {
 	mock.Mock
 }
 
+// Clear provides a mock function with given fields: userID
+func (_m *IHistoryService) Clear(userID int64) (int64, error) {
+	ret := _m.Called(userID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountByQuery provides a mock function with given fields: query
+func (_m *IHistoryService) CountByQuery(query *dtos.HistoriesRequestQuery) (int64, int64, error) {
+	ret := _m.Called(query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*dtos.HistoriesRequestQuery) int64); ok {
+		r0 = rf(query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(*dtos.HistoriesRequestQuery) int64); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*dtos.HistoriesRequestQuery) error); ok {
+		r2 = rf(query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetByQuery provides a mock function with given fields: query
+func (_m *IHistoryService) GetByQuery(query *dtos.HistoriesRequestQuery) ([]*models.History, error) {
+	ret := _m.Called(query)
+
+	var r0 []*models.History
+	if rf, ok := ret.Get(0).(func(*dtos.HistoriesRequestQuery) []*models.History); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.History)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*dtos.HistoriesRequestQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByUserAndPostID provides a mock function with given fields: userID, postID
 func (_m *IHistoryService) GetByUserAndPostID(userID int64, postID int64) (*models.History, error) {
 	ret := _m.Called(userID, postID)
@@ -36,8 +110,8 @@ func (_m *IHistoryService) GetByUserAndPostID(userID int64, postID int64) (*mode
 	return r0, r1
 }
 
-// GetByUserID provides a mock function with given fields: userID
-func (_m *IHistoryService) GetByUserID(userID int64) ([]*models.History, error) {
+// GetContinueReading provides a mock function with given fields: userID
+func (_m *IHistoryService) GetContinueReading(userID int64) ([]*models.History, error) {
 	ret := _m.Called(userID)
 
 	var r0 []*models.History
@@ -59,6 +133,20 @@ func (_m *IHistoryService) GetByUserID(userID int64) ([]*models.History, error)
 	return r0, r1
 }
 
+// Remove provides a mock function with given fields: userID, postID
+func (_m *IHistoryService) Remove(userID int64, postID int64) error {
+	ret := _m.Called(userID, postID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(userID, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateOrInsert provides a mock function with given fields: history
 func (_m *IHistoryService) UpdateOrInsert(history *models.History) (*models.History, error) {
 	ret := _m.Called(history)
@@ -82,6 +170,29 @@ func (_m *IHistoryService) UpdateOrInsert(history *models.History) (*models.Hist
 	return r0, r1
 }
 
+// UpdateProgress provides a mock function with given fields: userID, postID, progress
+func (_m *IHistoryService) UpdateProgress(userID int64, postID int64, progress int) (*models.History, error) {
+	ret := _m.Called(userID, postID, progress)
+
+	var r0 *models.History
+	if rf, ok := ret.Get(0).(func(int64, int64, int) *models.History); ok {
+		r0 = rf(userID, postID, progress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.History)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64, int) error); ok {
+		r1 = rf(userID, postID, progress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewIHistoryService interface {
 	mock.TestingT
 	Cleanup(func())
@@ -13,6 +13,27 @@ type IInvoiceRepository struct {
 	mock.Mock
 }
 
+// CountOpenPlanChanges provides a mock function with given fields: userSubscriptionID
+func (_m *IInvoiceRepository) CountOpenPlanChanges(userSubscriptionID int64) (int64, error) {
+	ret := _m.Called(userSubscriptionID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(userSubscriptionID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userSubscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAll provides a mock function with given fields: _a0
 func (_m *IInvoiceRepository) GetAll(_a0 *models.Invoice) ([]*models.Invoice, error) {
 	ret := _m.Called(_a0)
@@ -105,6 +126,29 @@ func (_m *IInvoiceRepository) GetByID(id int64) (*models.Invoice, error) {
 	return r0, r1
 }
 
+// GetWaitingRenewals provides a mock function with given fields:
+func (_m *IInvoiceRepository) GetWaitingRenewals() ([]*models.Invoice, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Invoice
+	if rf, ok := ret.Get(0).(func() []*models.Invoice); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Invoice)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Insert provides a mock function with given fields: invoice
 func (_m *IInvoiceRepository) Insert(invoice *models.Invoice) (*models.Invoice, error) {
 	ret := _m.Called(invoice)
@@ -158,6 +202,36 @@ func (_m *IInvoiceRepository) Update(invoice *models.Invoice) (*models.Invoice,
 	return r0, r1, r2
 }
 
+// UpdateIfStatus provides a mock function with given fields: invoice, status
+func (_m *IInvoiceRepository) UpdateIfStatus(invoice *models.Invoice, status models.InvoiceStatus) (*models.Invoice, int, error) {
+	ret := _m.Called(invoice, status)
+
+	var r0 *models.Invoice
+	if rf, ok := ret.Get(0).(func(*models.Invoice, models.InvoiceStatus) *models.Invoice); ok {
+		r0 = rf(invoice, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invoice)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*models.Invoice, models.InvoiceStatus) int); ok {
+		r1 = rf(invoice, status)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*models.Invoice, models.InvoiceStatus) error); ok {
+		r2 = rf(invoice, status)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 type mockConstructorTestingTNewIInvoiceRepository interface {
 	mock.TestingT
 	Cleanup(func())
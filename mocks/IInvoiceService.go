@@ -13,13 +13,13 @@ type IInvoiceService struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: invoice
-func (_m *IInvoiceService) Create(invoice *models.Invoice) (*models.Invoice, error) {
-	ret := _m.Called(invoice)
+// Create provides a mock function with given fields: quote
+func (_m *IInvoiceService) Create(quote *models.PriceQuote) (*models.Invoice, error) {
+	ret := _m.Called(quote)
 
 	var r0 *models.Invoice
-	if rf, ok := ret.Get(0).(func(*models.Invoice) *models.Invoice); ok {
-		r0 = rf(invoice)
+	if rf, ok := ret.Get(0).(func(*models.PriceQuote) *models.Invoice); ok {
+		r0 = rf(quote)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Invoice)
@@ -27,8 +27,8 @@ func (_m *IInvoiceService) Create(invoice *models.Invoice) (*models.Invoice, err
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.Invoice) error); ok {
-		r1 = rf(invoice)
+	if rf, ok := ret.Get(1).(func(*models.PriceQuote) error); ok {
+		r1 = rf(quote)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -151,6 +151,29 @@ func (_m *IInvoiceService) GetUserInvoices(userID int64) ([]*models.Invoice, err
 	return r0, r1
 }
 
+// Quote provides a mock function with given fields: request
+func (_m *IInvoiceService) Quote(request *models.PriceQuote) (*models.PriceQuote, error) {
+	ret := _m.Called(request)
+
+	var r0 *models.PriceQuote
+	if rf, ok := ret.Get(0).(func(*models.PriceQuote) *models.PriceQuote); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PriceQuote)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.PriceQuote) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateStatus provides a mock function with given fields: code, status
 func (_m *IInvoiceService) UpdateStatus(code string, status models.InvoiceStatus) (*models.Invoice, []*models.Gift, []*models.Voucher, error) {
 	ret := _m.Called(code, status)
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// INotificationRepository is an autogenerated mock type for the INotificationRepository type
+type INotificationRepository struct {
+	mock.Mock
+}
+
+// CountByUserID provides a mock function with given fields: userID
+func (_m *INotificationRepository) CountByUserID(userID int64) (int64, error) {
+	ret := _m.Called(userID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: userID, limit, page
+func (_m *INotificationRepository) GetByUserID(userID int64, limit int, page int) ([]*models.Notification, error) {
+	ret := _m.Called(userID, limit, page)
+
+	var r0 []*models.Notification
+	if rf, ok := ret.Get(0).(func(int64, int, int) []*models.Notification); ok {
+		r0 = rf(userID, limit, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Notification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int, int) error); ok {
+		r1 = rf(userID, limit, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Insert provides a mock function with given fields: notification
+func (_m *INotificationRepository) Insert(notification *models.Notification) (*models.Notification, error) {
+	ret := _m.Called(notification)
+
+	var r0 *models.Notification
+	if rf, ok := ret.Get(0).(func(*models.Notification) *models.Notification); ok {
+		r0 = rf(notification)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Notification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Notification) error); ok {
+		r1 = rf(notification)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewINotificationRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewINotificationRepository creates a new instance of INotificationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewINotificationRepository(t mockConstructorTestingTNewINotificationRepository) *INotificationRepository {
+	mock := &INotificationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,66 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// INotificationService is an autogenerated mock type for the INotificationService type
+type INotificationService struct {
+	mock.Mock
+}
+
+// GetByUserID provides a mock function with given fields: userID, limit, page
+func (_m *INotificationService) GetByUserID(userID int64, limit int, page int) ([]*models.Notification, int64, int64, error) {
+	ret := _m.Called(userID, limit, page)
+
+	var r0 []*models.Notification
+	if rf, ok := ret.Get(0).(func(int64, int, int) []*models.Notification); ok {
+		r0 = rf(userID, limit, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Notification)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(int64, int, int) int64); ok {
+		r1 = rf(userID, limit, page)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 int64
+	if rf, ok := ret.Get(2).(func(int64, int, int) int64); ok {
+		r2 = rf(userID, limit, page)
+	} else {
+		r2 = ret.Get(2).(int64)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(int64, int, int) error); ok {
+		r3 = rf(userID, limit, page)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+type mockConstructorTestingTNewINotificationService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewINotificationService creates a new instance of INotificationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewINotificationService(t mockConstructorTestingTNewINotificationService) *INotificationService {
+	mock := &INotificationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,73 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IPostNeighborRepository is an autogenerated mock type for the IPostNeighborRepository type
+type IPostNeighborRepository struct {
+	mock.Mock
+}
+
+// GetByPostIDs provides a mock function with given fields: postIDs
+func (_m *IPostNeighborRepository) GetByPostIDs(postIDs []int64) ([]*models.PostNeighbor, error) {
+	ret := _m.Called(postIDs)
+
+	var r0 []*models.PostNeighbor
+	if rf, ok := ret.Get(0).(func([]int64) []*models.PostNeighbor); ok {
+		r0 = rf(postIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PostNeighbor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]int64) error); ok {
+		r1 = rf(postIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Refresh provides a mock function with given fields:
+func (_m *IPostNeighborRepository) Refresh() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIPostNeighborRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIPostNeighborRepository creates a new instance of IPostNeighborRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIPostNeighborRepository(t mockConstructorTestingTNewIPostNeighborRepository) *IPostNeighborRepository {
+	mock := &IPostNeighborRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -119,6 +119,52 @@ func (_m *IPostRepository) GetCategories() ([]*models.Category, error) {
 	return r0, r1
 }
 
+// GetRelatedPosts provides a mock function with given fields: post, limit
+func (_m *IPostRepository) GetRelatedPosts(post *models.Post, limit int) ([]*models.Post, error) {
+	ret := _m.Called(post, limit)
+
+	var r0 []*models.Post
+	if rf, ok := ret.Get(0).(func(*models.Post, int) []*models.Post); ok {
+		r0 = rf(post, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Post, int) error); ok {
+		r1 = rf(post, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSitemapEntries provides a mock function with given fields: offset, limit
+func (_m *IPostRepository) GetSitemapEntries(offset int, limit int) ([]*models.Post, error) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []*models.Post
+	if rf, ok := ret.Get(0).(func(int, int) []*models.Post); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTopLikedAndSharedPost provides a mock function with given fields: structConditions
 func (_m *IPostRepository) GetTopLikedAndSharedPost(structConditions *models.Post) ([]*models.Post, error) {
 	ret := _m.Called(structConditions)
@@ -188,6 +234,73 @@ func (_m *IPostRepository) Insert(post *models.Post) (*models.Post, error) {
 	return r0, r1
 }
 
+// InsertCategory provides a mock function with given fields: category
+func (_m *IPostRepository) InsertCategory(category *models.Category) (*models.Category, error) {
+	ret := _m.Called(category)
+
+	var r0 *models.Category
+	if rf, ok := ret.Get(0).(func(*models.Category) *models.Category); ok {
+		r0 = rf(category)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Category) error); ok {
+		r1 = rf(category)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertType provides a mock function with given fields: postType
+func (_m *IPostRepository) InsertType(postType *models.PostType) (*models.PostType, error) {
+	ret := _m.Called(postType)
+
+	var r0 *models.PostType
+	if rf, ok := ret.Get(0).(func(*models.PostType) *models.PostType); ok {
+		r0 = rf(postType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PostType)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.PostType) error); ok {
+		r1 = rf(postType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileCounters provides a mock function with given fields:
+func (_m *IPostRepository) ReconcileCounters() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: post
 func (_m *IPostRepository) Update(post *models.Post) (*models.Post, int, error) {
 	ret := _m.Called(post)
@@ -3,6 +3,9 @@
 package mocks
 
 import (
+	cache "final-project-backend/internal/cache"
+	constants "final-project-backend/internal/constants"
+
 	dtos "final-project-backend/internal/dtos"
 
 	mock "github.com/stretchr/testify/mock"
@@ -43,6 +46,27 @@ func (_m *IPostService) CountByQuery(query *dtos.PostsRequestQuery) (int64, int6
 	return r0, r1, r2
 }
 
+// CountSitemapPages provides a mock function with given fields:
+func (_m *IPostService) CountSitemapPages() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: post
 func (_m *IPostService) Create(post *models.Post) (*models.Post, error) {
 	ret := _m.Called(post)
@@ -66,6 +90,52 @@ func (_m *IPostService) Create(post *models.Post) (*models.Post, error) {
 	return r0, r1
 }
 
+// CreateCategory provides a mock function with given fields: category
+func (_m *IPostService) CreateCategory(category *models.Category) (*models.Category, error) {
+	ret := _m.Called(category)
+
+	var r0 *models.Category
+	if rf, ok := ret.Get(0).(func(*models.Category) *models.Category); ok {
+		r0 = rf(category)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Category) error); ok {
+		r1 = rf(category)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateType provides a mock function with given fields: postType
+func (_m *IPostService) CreateType(postType *models.PostType) (*models.PostType, error) {
+	ret := _m.Called(postType)
+
+	var r0 *models.PostType
+	if rf, ok := ret.Get(0).(func(*models.PostType) *models.PostType); ok {
+		r0 = rf(postType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PostType)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.PostType) error); ok {
+		r1 = rf(postType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: id
 func (_m *IPostService) Delete(id int64) error {
 	ret := _m.Called(id)
@@ -103,6 +173,98 @@ func (_m *IPostService) GetAll(query *dtos.PostsRequestQuery) ([]*models.Post, e
 	return r0, r1
 }
 
+// GetAllByCursor provides a mock function with given fields: query
+func (_m *IPostService) GetAllByCursor(query *dtos.PostsRequestQuery) ([]*models.Post, string, string, error) {
+	ret := _m.Called(query)
+
+	var r0 []*models.Post
+	if rf, ok := ret.Get(0).(func(*dtos.PostsRequestQuery) []*models.Post); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Post)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(*dtos.PostsRequestQuery) string); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 string
+	if rf, ok := ret.Get(2).(func(*dtos.PostsRequestQuery) string); ok {
+		r2 = rf(query)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(*dtos.PostsRequestQuery) error); ok {
+		r3 = rf(query)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// GetAlsoReadPosts provides a mock function with given fields: userID
+func (_m *IPostService) GetAlsoReadPosts(userID int64) ([]*models.Post, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*models.Post
+	if rf, ok := ret.Get(0).(func(int64) []*models.Post); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAnalytics provides a mock function with given fields: postID
+func (_m *IPostService) GetAnalytics(postID int64) (*models.Post, *models.PostReadStats, error) {
+	ret := _m.Called(postID)
+
+	var r0 *models.Post
+	if rf, ok := ret.Get(0).(func(int64) *models.Post); ok {
+		r0 = rf(postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Post)
+		}
+	}
+
+	var r1 *models.PostReadStats
+	if rf, ok := ret.Get(1).(func(int64) *models.PostReadStats); ok {
+		r1 = rf(postID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.PostReadStats)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64) error); ok {
+		r2 = rf(postID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetByID provides a mock function with given fields: id
 func (_m *IPostService) GetByID(id int64) (*models.Post, error) {
 	ret := _m.Called(id)
@@ -126,6 +288,22 @@ func (_m *IPostService) GetByID(id int64) (*models.Post, error) {
 	return r0, r1
 }
 
+// GetCacheStats provides a mock function with given fields:
+func (_m *IPostService) GetCacheStats() map[string]cache.Stats {
+	ret := _m.Called()
+
+	var r0 map[string]cache.Stats
+	if rf, ok := ret.Get(0).(func() map[string]cache.Stats); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]cache.Stats)
+		}
+	}
+
+	return r0
+}
+
 // GetCategories provides a mock function with given fields:
 func (_m *IPostService) GetCategories() ([]*models.Category, error) {
 	ret := _m.Called()
@@ -149,6 +327,38 @@ func (_m *IPostService) GetCategories() ([]*models.Category, error) {
 	return r0, r1
 }
 
+// GetFeed provides a mock function with given fields: userID, cursor, limit
+func (_m *IPostService) GetFeed(userID int64, cursor *dtos.FeedCursor, limit int) ([]*dtos.FeedItem, *dtos.FeedCursor, error) {
+	ret := _m.Called(userID, cursor, limit)
+
+	var r0 []*dtos.FeedItem
+	if rf, ok := ret.Get(0).(func(int64, *dtos.FeedCursor, int) []*dtos.FeedItem); ok {
+		r0 = rf(userID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dtos.FeedItem)
+		}
+	}
+
+	var r1 *dtos.FeedCursor
+	if rf, ok := ret.Get(1).(func(int64, *dtos.FeedCursor, int) *dtos.FeedCursor); ok {
+		r1 = rf(userID, cursor, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*dtos.FeedCursor)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64, *dtos.FeedCursor, int) error); ok {
+		r2 = rf(userID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetRecommendedPosts provides a mock function with given fields: userID
 func (_m *IPostService) GetRecommendedPosts(userID int64) ([]*models.Post, error) {
 	ret := _m.Called(userID)
@@ -172,13 +382,13 @@ func (_m *IPostService) GetRecommendedPosts(userID int64) ([]*models.Post, error
 	return r0, r1
 }
 
-// GetTrendingPosts provides a mock function with given fields: userID
-func (_m *IPostService) GetTrendingPosts(userID int64) ([]*models.Post, error) {
-	ret := _m.Called(userID)
+// GetRelatedPosts provides a mock function with given fields: postID, userID
+func (_m *IPostService) GetRelatedPosts(postID int64, userID int64) ([]*models.Post, error) {
+	ret := _m.Called(postID, userID)
 
 	var r0 []*models.Post
-	if rf, ok := ret.Get(0).(func(int64) []*models.Post); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(int64, int64) []*models.Post); ok {
+		r0 = rf(postID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Post)
@@ -186,8 +396,54 @@ func (_m *IPostService) GetTrendingPosts(userID int64) ([]*models.Post, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(postID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSitemapPage provides a mock function with given fields: page
+func (_m *IPostService) GetSitemapPage(page int) ([]*models.Post, error) {
+	ret := _m.Called(page)
+
+	var r0 []*models.Post
+	if rf, ok := ret.Get(0).(func(int) []*models.Post); ok {
+		r0 = rf(page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrendingPosts provides a mock function with given fields: query
+func (_m *IPostService) GetTrendingPosts(query *dtos.TrendingPostsQuery) ([]*models.Post, error) {
+	ret := _m.Called(query)
+
+	var r0 []*models.Post
+	if rf, ok := ret.Get(0).(func(*dtos.TrendingPostsQuery) []*models.Post); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*dtos.TrendingPostsQuery) error); ok {
+		r1 = rf(query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -218,6 +474,11 @@ func (_m *IPostService) GetTypes() ([]*models.PostType, error) {
 	return r0, r1
 }
 
+// InvalidateCaches provides a mock function with given fields:
+func (_m *IPostService) InvalidateCaches() {
+	_m.Called()
+}
+
 // Like provides a mock function with given fields: postID, userID, isLike
 func (_m *IPostService) Like(postID int64, userID int64, isLike bool) (*models.Post, *models.History, error) {
 	ret := _m.Called(postID, userID, isLike)
@@ -250,6 +511,101 @@ func (_m *IPostService) Like(postID int64, userID int64, isLike bool) (*models.P
 	return r0, r1, r2
 }
 
+// React provides a mock function with given fields: postID, userID, reaction
+func (_m *IPostService) React(postID int64, userID int64, reaction constants.Reaction) (*models.Post, *models.History, error) {
+	ret := _m.Called(postID, userID, reaction)
+
+	var r0 *models.Post
+	if rf, ok := ret.Get(0).(func(int64, int64, constants.Reaction) *models.Post); ok {
+		r0 = rf(postID, userID, reaction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Post)
+		}
+	}
+
+	var r1 *models.History
+	if rf, ok := ret.Get(1).(func(int64, int64, constants.Reaction) *models.History); ok {
+		r1 = rf(postID, userID, reaction)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.History)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64, int64, constants.Reaction) error); ok {
+		r2 = rf(postID, userID, reaction)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReconcileCounters provides a mock function with given fields:
+func (_m *IPostService) ReconcileCounters() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshPostNeighbors provides a mock function with given fields:
+func (_m *IPostService) RefreshPostNeighbors() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshTrending provides a mock function with given fields:
+func (_m *IPostService) RefreshTrending() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Share provides a mock function with given fields: postID, userID
 func (_m *IPostService) Share(postID int64, userID int64) (*models.Post, *models.History, error) {
 	ret := _m.Called(postID, userID)
@@ -0,0 +1,73 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IQuotaLedgerRepository is an autogenerated mock type for the IQuotaLedgerRepository type
+type IQuotaLedgerRepository struct {
+	mock.Mock
+}
+
+// CountByUserID provides a mock function with given fields: userID
+func (_m *IQuotaLedgerRepository) CountByUserID(userID int64) (int64, error) {
+	ret := _m.Called(userID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByUserID provides a mock function with given fields: userID, limit, page
+func (_m *IQuotaLedgerRepository) GetByUserID(userID int64, limit int, page int) ([]*models.QuotaLedger, error) {
+	ret := _m.Called(userID, limit, page)
+
+	var r0 []*models.QuotaLedger
+	if rf, ok := ret.Get(0).(func(int64, int, int) []*models.QuotaLedger); ok {
+		r0 = rf(userID, limit, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.QuotaLedger)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int, int) error); ok {
+		r1 = rf(userID, limit, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIQuotaLedgerRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIQuotaLedgerRepository creates a new instance of IQuotaLedgerRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIQuotaLedgerRepository(t mockConstructorTestingTNewIQuotaLedgerRepository) *IQuotaLedgerRepository {
+	mock := &IQuotaLedgerRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,74 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// IRenewalService is an autogenerated mock type for the IRenewalService type
+type IRenewalService struct {
+	mock.Mock
+}
+
+// IssueRenewalInvoices provides a mock function with given fields:
+func (_m *IRenewalService) IssueRenewalInvoices() (int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendRenewalReminders provides a mock function with given fields:
+func (_m *IRenewalService) SendRenewalReminders() (int, int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func() int); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewIRenewalService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIRenewalService creates a new instance of IRenewalService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIRenewalService(t mockConstructorTestingTNewIRenewalService) *IRenewalService {
+	mock := &IRenewalService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
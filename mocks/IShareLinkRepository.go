@@ -0,0 +1,103 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IShareLinkRepository is an autogenerated mock type for the IShareLinkRepository type
+type IShareLinkRepository struct {
+	mock.Mock
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *IShareLinkRepository) GetByID(id int64) (*models.ShareLink, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.ShareLink
+	if rf, ok := ret.Get(0).(func(int64) *models.ShareLink); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ShareLink)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertRedemption provides a mock function with given fields: shareLink, redemption
+func (_m *IShareLinkRepository) InsertRedemption(shareLink *models.ShareLink, redemption *models.ShareLinkRedemption) (bool, error) {
+	ret := _m.Called(shareLink, redemption)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*models.ShareLink, *models.ShareLinkRedemption) bool); ok {
+		r0 = rf(shareLink, redemption)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.ShareLink, *models.ShareLinkRedemption) error); ok {
+		r1 = rf(shareLink, redemption)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertWithinLimit provides a mock function with given fields: shareLink, since, limit
+func (_m *IShareLinkRepository) InsertWithinLimit(shareLink *models.ShareLink, since time.Time, limit int) (int64, bool, error) {
+	ret := _m.Called(shareLink, since, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*models.ShareLink, time.Time, int) int64); ok {
+		r0 = rf(shareLink, since, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(*models.ShareLink, time.Time, int) bool); ok {
+		r1 = rf(shareLink, since, limit)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*models.ShareLink, time.Time, int) error); ok {
+		r2 = rf(shareLink, since, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewIShareLinkRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIShareLinkRepository creates a new instance of IShareLinkRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIShareLinkRepository(t mockConstructorTestingTNewIShareLinkRepository) *IShareLinkRepository {
+	mock := &IShareLinkRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IShareLinkService is an autogenerated mock type for the IShareLinkService type
+type IShareLinkService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: userID, postID
+func (_m *IShareLinkService) Create(userID int64, postID int64) (*models.ShareLink, int64, error) {
+	ret := _m.Called(userID, postID)
+
+	var r0 *models.ShareLink
+	if rf, ok := ret.Get(0).(func(int64, int64) *models.ShareLink); ok {
+		r0 = rf(userID, postID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ShareLink)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(int64, int64) int64); ok {
+		r1 = rf(userID, postID)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64, int64) error); ok {
+		r2 = rf(userID, postID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Redeem provides a mock function with given fields: shareLinkID, postID, visitorKey, visitorUserID
+func (_m *IShareLinkService) Redeem(shareLinkID int64, postID int64, visitorKey string, visitorUserID int64) (*models.Post, error) {
+	ret := _m.Called(shareLinkID, postID, visitorKey, visitorUserID)
+
+	var r0 *models.Post
+	if rf, ok := ret.Get(0).(func(int64, int64, string, int64) *models.Post); ok {
+		r0 = rf(shareLinkID, postID, visitorKey, visitorUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64, string, int64) error); ok {
+		r1 = rf(shareLinkID, postID, visitorKey, visitorUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewIShareLinkService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIShareLinkService creates a new instance of IShareLinkService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIShareLinkService(t mockConstructorTestingTNewIShareLinkService) *IShareLinkService {
+	mock := &IShareLinkService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
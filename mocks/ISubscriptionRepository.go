@@ -36,6 +36,29 @@ func (_m *ISubscriptionRepository) GetAll() ([]*models.Subscription, error) {
 	return r0, r1
 }
 
+// GetAllVersions provides a mock function with given fields:
+func (_m *ISubscriptionRepository) GetAllVersions() ([]*models.Subscription, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Subscription
+	if rf, ok := ret.Get(0).(func() []*models.Subscription); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: id
 func (_m *ISubscriptionRepository) GetByID(id int64) (*models.Subscription, error) {
 	ret := _m.Called(id)
@@ -59,6 +82,149 @@ func (_m *ISubscriptionRepository) GetByID(id int64) (*models.Subscription, erro
 	return r0, r1
 }
 
+// GetCurrentByPlanID provides a mock function with given fields: planID
+func (_m *ISubscriptionRepository) GetCurrentByPlanID(planID int64) (*models.Subscription, error) {
+	ret := _m.Called(planID)
+
+	var r0 *models.Subscription
+	if rf, ok := ret.Get(0).(func(int64) *models.Subscription); ok {
+		r0 = rf(planID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(planID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCurrentTrial provides a mock function with given fields:
+func (_m *ISubscriptionRepository) GetCurrentTrial() (*models.Subscription, error) {
+	ret := _m.Called()
+
+	var r0 *models.Subscription
+	if rf, ok := ret.Get(0).(func() *models.Subscription); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Insert provides a mock function with given fields: subscription
+func (_m *ISubscriptionRepository) Insert(subscription *models.Subscription) (*models.Subscription, error) {
+	ret := _m.Called(subscription)
+
+	var r0 *models.Subscription
+	if rf, ok := ret.Get(0).(func(*models.Subscription) *models.Subscription); ok {
+		r0 = rf(subscription)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Subscription) error); ok {
+		r1 = rf(subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertVersion provides a mock function with given fields: subscription
+func (_m *ISubscriptionRepository) InsertVersion(subscription *models.Subscription) (*models.Subscription, error) {
+	ret := _m.Called(subscription)
+
+	var r0 *models.Subscription
+	if rf, ok := ret.Get(0).(func(*models.Subscription) *models.Subscription); ok {
+		r0 = rf(subscription)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Subscription) error); ok {
+		r1 = rf(subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Rename provides a mock function with given fields: id, name
+func (_m *ISubscriptionRepository) Rename(id int64, name string) (*models.Subscription, int, error) {
+	ret := _m.Called(id, name)
+
+	var r0 *models.Subscription
+	if rf, ok := ret.Get(0).(func(int64, string) *models.Subscription); ok {
+		r0 = rf(id, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int64, string) int); ok {
+		r1 = rf(id, name)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64, string) error); ok {
+		r2 = rf(id, name)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Retire provides a mock function with given fields: planID
+func (_m *ISubscriptionRepository) Retire(planID int64) (int, error) {
+	ret := _m.Called(planID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int64) int); ok {
+		r0 = rf(planID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(planID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewISubscriptionRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -3,9 +3,11 @@
 package mocks
 
 import (
-	models "final-project-backend/internal/models"
+	dtos "final-project-backend/internal/dtos"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "final-project-backend/internal/models"
 )
 
 // ISubscriptionService is an autogenerated mock type for the ISubscriptionService type
@@ -13,6 +15,29 @@ type ISubscriptionService struct {
 	mock.Mock
 }
 
+// Create provides a mock function with given fields: subscription
+func (_m *ISubscriptionService) Create(subscription *models.Subscription) (*models.Subscription, error) {
+	ret := _m.Called(subscription)
+
+	var r0 *models.Subscription
+	if rf, ok := ret.Get(0).(func(*models.Subscription) *models.Subscription); ok {
+		r0 = rf(subscription)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Subscription) error); ok {
+		r1 = rf(subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAll provides a mock function with given fields:
 func (_m *ISubscriptionService) GetAll() ([]*models.Subscription, error) {
 	ret := _m.Called()
@@ -36,6 +61,66 @@ func (_m *ISubscriptionService) GetAll() ([]*models.Subscription, error) {
 	return r0, r1
 }
 
+// GetAllVersions provides a mock function with given fields:
+func (_m *ISubscriptionService) GetAllVersions() ([]*models.Subscription, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Subscription
+	if rf, ok := ret.Get(0).(func() []*models.Subscription); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Retire provides a mock function with given fields: planID
+func (_m *ISubscriptionService) Retire(planID int64) error {
+	ret := _m.Called(planID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(planID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: planID, request
+func (_m *ISubscriptionService) Update(planID int64, request *dtos.UpdateSubscriptionRequest) (*models.Subscription, error) {
+	ret := _m.Called(planID, request)
+
+	var r0 *models.Subscription
+	if rf, ok := ret.Get(0).(func(int64, *dtos.UpdateSubscriptionRequest) *models.Subscription); ok {
+		r0 = rf(planID, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, *dtos.UpdateSubscriptionRequest) error); ok {
+		r1 = rf(planID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewISubscriptionService interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,73 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ITrendingRepository is an autogenerated mock type for the ITrendingRepository type
+type ITrendingRepository struct {
+	mock.Mock
+}
+
+// GetPosts provides a mock function with given fields: categoryID, limit
+func (_m *ITrendingRepository) GetPosts(categoryID int64, limit int) ([]*models.Post, error) {
+	ret := _m.Called(categoryID, limit)
+
+	var r0 []*models.Post
+	if rf, ok := ret.Get(0).(func(int64, int) []*models.Post); ok {
+		r0 = rf(categoryID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Post)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(categoryID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Refresh provides a mock function with given fields:
+func (_m *ITrendingRepository) Refresh() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewITrendingRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewITrendingRepository creates a new instance of ITrendingRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewITrendingRepository(t mockConstructorTestingTNewITrendingRepository) *ITrendingRepository {
+	mock := &ITrendingRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,170 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ITrialRepository is an autogenerated mock type for the ITrialRepository type
+type ITrialRepository struct {
+	mock.Mock
+}
+
+// GetEndingSoon provides a mock function with given fields: endsBefore
+func (_m *ITrialRepository) GetEndingSoon(endsBefore time.Time) ([]*models.TrialGrant, error) {
+	ret := _m.Called(endsBefore)
+
+	var r0 []*models.TrialGrant
+	if rf, ok := ret.Get(0).(func(time.Time) []*models.TrialGrant); ok {
+		r0 = rf(endsBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TrialGrant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(endsBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpired provides a mock function with given fields:
+func (_m *ITrialRepository) GetExpired() ([]*models.TrialGrant, error) {
+	ret := _m.Called()
+
+	var r0 []*models.TrialGrant
+	if rf, ok := ret.Get(0).(func() []*models.TrialGrant); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TrialGrant)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStats provides a mock function with given fields:
+func (_m *ITrialRepository) GetStats() (*models.TrialStats, error) {
+	ret := _m.Called()
+
+	var r0 *models.TrialStats
+	if rf, ok := ret.Get(0).(func() *models.TrialStats); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TrialStats)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Grant provides a mock function with given fields: trialGrant, userSubscription
+func (_m *ITrialRepository) Grant(trialGrant *models.TrialGrant, userSubscription *models.UserSubscriptions) (bool, error) {
+	ret := _m.Called(trialGrant, userSubscription)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*models.TrialGrant, *models.UserSubscriptions) bool); ok {
+		r0 = rf(trialGrant, userSubscription)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.TrialGrant, *models.UserSubscriptions) error); ok {
+		r1 = rf(trialGrant, userSubscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkConverted provides a mock function with given fields: userID, invoiceID
+func (_m *ITrialRepository) MarkConverted(userID int64, invoiceID int64) (int64, error) {
+	ret := _m.Called(userID, invoiceID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64) int64); ok {
+		r0 = rf(userID, invoiceID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(userID, invoiceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetExpiryNotified provides a mock function with given fields: id
+func (_m *ITrialRepository) SetExpiryNotified(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetReminded provides a mock function with given fields: id
+func (_m *ITrialRepository) SetReminded(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewITrialRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewITrialRepository creates a new instance of ITrialRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewITrialRepository(t mockConstructorTestingTNewITrialRepository) *ITrialRepository {
+	mock := &ITrialRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "final-project-backend/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ITrialService is an autogenerated mock type for the ITrialService type
+type ITrialService struct {
+	mock.Mock
+}
+
+// GetStats provides a mock function with given fields:
+func (_m *ITrialService) GetStats() (*models.TrialStats, error) {
+	ret := _m.Called()
+
+	var r0 *models.TrialStats
+	if rf, ok := ret.Get(0).(func() *models.TrialStats); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TrialStats)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Grant provides a mock function with given fields: user
+func (_m *ITrialService) Grant(user *models.User) (*models.UserSubscriptions, error) {
+	ret := _m.Called(user)
+
+	var r0 *models.UserSubscriptions
+	if rf, ok := ret.Get(0).(func(*models.User) *models.UserSubscriptions); ok {
+		r0 = rf(user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserSubscriptions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.User) error); ok {
+		r1 = rf(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendExpiryNotifications provides a mock function with given fields:
+func (_m *ITrialService) SendExpiryNotifications() (int, int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func() int); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewITrialService interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewITrialService creates a new instance of ITrialService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewITrialService(t mockConstructorTestingTNewITrialService) *ITrialService {
+	mock := &ITrialService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -151,6 +151,36 @@ func (_m *IUserRepository) Insert(user *models.User) (*models.User, error) {
 	return r0, r1
 }
 
+// MarkEmailVerified provides a mock function with given fields: id, email
+func (_m *IUserRepository) MarkEmailVerified(id int64, email string) (*models.User, int, error) {
+	ret := _m.Called(id, email)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(int64, string) *models.User); ok {
+		r0 = rf(id, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(int64, string) int); ok {
+		r1 = rf(id, email)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64, string) error); ok {
+		r2 = rf(id, email)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Update provides a mock function with given fields: user
 func (_m *IUserRepository) Update(user *models.User) (*models.User, int, error) {
 	ret := _m.Called(user)
@@ -3,9 +3,14 @@
 package mocks
 
 import (
-	models "final-project-backend/internal/models"
+	constants "final-project-backend/internal/constants"
+	dtos "final-project-backend/internal/dtos"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "final-project-backend/internal/models"
+
+	time "time"
 )
 
 // IUserSubscriptionRepository is an autogenerated mock type for the IUserSubscriptionRepository type
@@ -13,22 +18,71 @@ type IUserSubscriptionRepository struct {
 	mock.Mock
 }
 
-// DecrementQuota provides a mock function with given fields: userSubscription, quota
-func (_m *IUserSubscriptionRepository) DecrementQuota(userSubscription *models.UserSubscriptions, quota int) (*models.UserSubscriptions, error) {
-	ret := _m.Called(userSubscription, quota)
+// Cancel provides a mock function with given fields: id
+func (_m *IUserSubscriptionRepository) Cancel(id int64) (int64, error) {
+	ret := _m.Called(id)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangePlan provides a mock function with given fields: id, userSubscription, policy
+func (_m *IUserSubscriptionRepository) ChangePlan(id int64, userSubscription *models.UserSubscriptions, policy constants.QuotaCarryOverPolicy) (*models.UserSubscriptions, bool, error) {
+	ret := _m.Called(id, userSubscription, policy)
 
 	var r0 *models.UserSubscriptions
-	if rf, ok := ret.Get(0).(func(*models.UserSubscriptions, int) *models.UserSubscriptions); ok {
-		r0 = rf(userSubscription, quota)
+	if rf, ok := ret.Get(0).(func(int64, *models.UserSubscriptions, constants.QuotaCarryOverPolicy) *models.UserSubscriptions); ok {
+		r0 = rf(id, userSubscription, policy)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.UserSubscriptions)
 		}
 	}
 
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(int64, *models.UserSubscriptions, constants.QuotaCarryOverPolicy) bool); ok {
+		r1 = rf(id, userSubscription, policy)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64, *models.UserSubscriptions, constants.QuotaCarryOverPolicy) error); ok {
+		r2 = rf(id, userSubscription, policy)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CountByQuery provides a mock function with given fields: query
+func (_m *IUserSubscriptionRepository) CountByQuery(query *dtos.UserSubscriptionsRequestQuery) (int64, error) {
+	ret := _m.Called(query)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(*dtos.UserSubscriptionsRequestQuery) int64); ok {
+		r0 = rf(query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.UserSubscriptions, int) error); ok {
-		r1 = rf(userSubscription, quota)
+	if rf, ok := ret.Get(1).(func(*dtos.UserSubscriptionsRequestQuery) error); ok {
+		r1 = rf(query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,6 +113,75 @@ func (_m *IUserSubscriptionRepository) GetAllUserSubscriptions(userID int64) ([]
 	return r0, r1
 }
 
+// GetByID provides a mock function with given fields: id
+func (_m *IUserSubscriptionRepository) GetByID(id int64) (*models.UserSubscriptions, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.UserSubscriptions
+	if rf, ok := ret.Get(0).(func(int64) *models.UserSubscriptions); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserSubscriptions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByQuery provides a mock function with given fields: query
+func (_m *IUserSubscriptionRepository) GetByQuery(query *dtos.UserSubscriptionsRequestQuery) ([]*models.UserSubscriptions, error) {
+	ret := _m.Called(query)
+
+	var r0 []*models.UserSubscriptions
+	if rf, ok := ret.Get(0).(func(*dtos.UserSubscriptionsRequestQuery) []*models.UserSubscriptions); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.UserSubscriptions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*dtos.UserSubscriptionsRequestQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDueForRenewal provides a mock function with given fields: endedAfter, endsBefore
+func (_m *IUserSubscriptionRepository) GetDueForRenewal(endedAfter time.Time, endsBefore time.Time) ([]*models.UserSubscriptions, error) {
+	ret := _m.Called(endedAfter, endsBefore)
+
+	var r0 []*models.UserSubscriptions
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time) []*models.UserSubscriptions); ok {
+		r0 = rf(endedAfter, endsBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.UserSubscriptions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
+		r1 = rf(endedAfter, endsBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOngoingUserSubscriptions provides a mock function with given fields: userID
 func (_m *IUserSubscriptionRepository) GetOngoingUserSubscriptions(userID int64) ([]*models.UserSubscriptions, error) {
 	ret := _m.Called(userID)
@@ -105,6 +228,127 @@ func (_m *IUserSubscriptionRepository) Insert(userSubscription *models.UserSubsc
 	return r0, r1
 }
 
+// Pause provides a mock function with given fields: id, days
+func (_m *IUserSubscriptionRepository) Pause(id int64, days int) (int64, error) {
+	ret := _m.Called(id, days)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int) int64); ok {
+		r0 = rf(id, days)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(id, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Renew provides a mock function with given fields: id, subscriptionID, dateEnded, quota
+func (_m *IUserSubscriptionRepository) Renew(id int64, subscriptionID int64, dateEnded time.Time, quota int) error {
+	ret := _m.Called(id, subscriptionID, dateEnded, quota)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, time.Time, int) error); ok {
+		r0 = rf(id, subscriptionID, dateEnded, quota)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Resume provides a mock function with given fields: id
+func (_m *IUserSubscriptionRepository) Resume(id int64) (int64, error) {
+	ret := _m.Called(id)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetAutoRenew provides a mock function with given fields: userID, id, autoRenew
+func (_m *IUserSubscriptionRepository) SetAutoRenew(userID int64, id int64, autoRenew bool) (int64, error) {
+	ret := _m.Called(userID, id, autoRenew)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, int64, bool) int64); ok {
+		r0 = rf(userID, id, autoRenew)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64, bool) error); ok {
+		r1 = rf(userID, id, autoRenew)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetGraceEndsAt provides a mock function with given fields: id, graceEndsAt
+func (_m *IUserSubscriptionRepository) SetGraceEndsAt(id int64, graceEndsAt *time.Time) error {
+	ret := _m.Called(id, graceEndsAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, *time.Time) error); ok {
+		r0 = rf(id, graceEndsAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlockPost provides a mock function with given fields: userID, postID, quota
+func (_m *IUserSubscriptionRepository) UnlockPost(userID int64, postID int64, quota int) ([]*models.QuotaLedger, bool, error) {
+	ret := _m.Called(userID, postID, quota)
+
+	var r0 []*models.QuotaLedger
+	if rf, ok := ret.Get(0).(func(int64, int64, int) []*models.QuotaLedger); ok {
+		r0 = rf(userID, postID, quota)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.QuotaLedger)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(int64, int64, int) bool); ok {
+		r1 = rf(userID, postID, quota)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64, int64, int) error); ok {
+		r2 = rf(userID, postID, quota)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Update provides a mock function with given fields: userSubscription
 func (_m *IUserSubscriptionRepository) Update(userSubscription *models.UserSubscriptions) (*models.UserSubscriptions, error) {
 	ret := _m.Called(userSubscription)
@@ -3,9 +3,11 @@
 package mocks
 
 import (
-	models "final-project-backend/internal/models"
+	dtos "final-project-backend/internal/dtos"
 
 	mock "github.com/stretchr/testify/mock"
+
+	models "final-project-backend/internal/models"
 )
 
 // IUserSubscriptionService is an autogenerated mock type for the IUserSubscriptionService type
@@ -36,6 +38,29 @@ func (_m *IUserSubscriptionService) AddUserSubscription(userID int64, subscripti
 	return r0, r1
 }
 
+// Cancel provides a mock function with given fields: userID, userSubscriptionID
+func (_m *IUserSubscriptionService) Cancel(userID int64, userSubscriptionID int64) (*models.UserSubscriptions, error) {
+	ret := _m.Called(userID, userSubscriptionID)
+
+	var r0 *models.UserSubscriptions
+	if rf, ok := ret.Get(0).(func(int64, int64) *models.UserSubscriptions); ok {
+		r0 = rf(userID, userSubscriptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserSubscriptions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(userID, userSubscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllUserSubscriptions provides a mock function with given fields: userID
 func (_m *IUserSubscriptionService) GetAllUserSubscriptions(userID int64) ([]*models.UserSubscriptions, error) {
 	ret := _m.Called(userID)
@@ -59,13 +84,177 @@ func (_m *IUserSubscriptionService) GetAllUserSubscriptions(userID int64) ([]*mo
 	return r0, r1
 }
 
-// ValidateUserQuota provides a mock function with given fields: userID, quotaNeeded
-func (_m *IUserSubscriptionService) ValidateUserQuota(userID int64, quotaNeeded int) error {
-	ret := _m.Called(userID, quotaNeeded)
+// GetByQuery provides a mock function with given fields: query
+func (_m *IUserSubscriptionService) GetByQuery(query *dtos.UserSubscriptionsRequestQuery) ([]*models.UserSubscriptions, int64, int64, error) {
+	ret := _m.Called(query)
+
+	var r0 []*models.UserSubscriptions
+	if rf, ok := ret.Get(0).(func(*dtos.UserSubscriptionsRequestQuery) []*models.UserSubscriptions); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.UserSubscriptions)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(*dtos.UserSubscriptionsRequestQuery) int64); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 int64
+	if rf, ok := ret.Get(2).(func(*dtos.UserSubscriptionsRequestQuery) int64); ok {
+		r2 = rf(query)
+	} else {
+		r2 = ret.Get(2).(int64)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(*dtos.UserSubscriptionsRequestQuery) error); ok {
+		r3 = rf(query)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// GetQuotaLedger provides a mock function with given fields: userID, limit, page
+func (_m *IUserSubscriptionService) GetQuotaLedger(userID int64, limit int, page int) ([]*models.QuotaLedger, int64, int64, error) {
+	ret := _m.Called(userID, limit, page)
+
+	var r0 []*models.QuotaLedger
+	if rf, ok := ret.Get(0).(func(int64, int, int) []*models.QuotaLedger); ok {
+		r0 = rf(userID, limit, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.QuotaLedger)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(int64, int, int) int64); ok {
+		r1 = rf(userID, limit, page)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 int64
+	if rf, ok := ret.Get(2).(func(int64, int, int) int64); ok {
+		r2 = rf(userID, limit, page)
+	} else {
+		r2 = ret.Get(2).(int64)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(int64, int, int) error); ok {
+		r3 = rf(userID, limit, page)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// GetRemainingQuota provides a mock function with given fields: userID
+func (_m *IUserSubscriptionService) GetRemainingQuota(userID int64) (int, error) {
+	ret := _m.Called(userID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int64) int); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Pause provides a mock function with given fields: userID, userSubscriptionID, days
+func (_m *IUserSubscriptionService) Pause(userID int64, userSubscriptionID int64, days int) (*models.UserSubscriptions, error) {
+	ret := _m.Called(userID, userSubscriptionID, days)
+
+	var r0 *models.UserSubscriptions
+	if rf, ok := ret.Get(0).(func(int64, int64, int) *models.UserSubscriptions); ok {
+		r0 = rf(userID, userSubscriptionID, days)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserSubscriptions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64, int) error); ok {
+		r1 = rf(userID, userSubscriptionID, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resume provides a mock function with given fields: userID, userSubscriptionID
+func (_m *IUserSubscriptionService) Resume(userID int64, userSubscriptionID int64) (*models.UserSubscriptions, error) {
+	ret := _m.Called(userID, userSubscriptionID)
+
+	var r0 *models.UserSubscriptions
+	if rf, ok := ret.Get(0).(func(int64, int64) *models.UserSubscriptions); ok {
+		r0 = rf(userID, userSubscriptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserSubscriptions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(userID, userSubscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetAutoRenew provides a mock function with given fields: userID, userSubscriptionID, autoRenew
+func (_m *IUserSubscriptionService) SetAutoRenew(userID int64, userSubscriptionID int64, autoRenew bool) (*models.UserSubscriptions, error) {
+	ret := _m.Called(userID, userSubscriptionID, autoRenew)
+
+	var r0 *models.UserSubscriptions
+	if rf, ok := ret.Get(0).(func(int64, int64, bool) *models.UserSubscriptions); ok {
+		r0 = rf(userID, userSubscriptionID, autoRenew)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserSubscriptions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64, bool) error); ok {
+		r1 = rf(userID, userSubscriptionID, autoRenew)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnlockPost provides a mock function with given fields: userID, postID, quota
+func (_m *IUserSubscriptionService) UnlockPost(userID int64, postID int64, quota int) error {
+	ret := _m.Called(userID, postID, quota)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int) error); ok {
-		r0 = rf(userID, quotaNeeded)
+	if rf, ok := ret.Get(0).(func(int64, int64, int) error); ok {
+		r0 = rf(userID, postID, quota)
 	} else {
 		r0 = ret.Error(0)
 	}